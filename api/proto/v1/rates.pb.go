@@ -21,21 +21,86 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
-type RateResponse struct {
+// Decimal is an exact decimal number equal to value * 10^-scale.
+type Decimal struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	BuyRate       string                 `protobuf:"bytes,1,opt,name=buyRate,proto3" json:"buyRate,omitempty"`
-	SellRate      string                 `protobuf:"bytes,2,opt,name=sellRate,proto3" json:"sellRate,omitempty"`
-	CantorId      int32                  `protobuf:"varint,3,opt,name=cantorId,json=cantorID,proto3" json:"cantorId,omitempty"`
-	Currency      string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
-	FetchedAt     int64                  `protobuf:"varint,5,opt,name=fetchedAt,proto3" json:"fetchedAt,omitempty"`
-	Change24H     int64                  `protobuf:"varint,6,opt,name=change24h,proto3" json:"change24h,omitempty"`
+	Value         int64                  `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`
+	Scale         int32                  `protobuf:"varint,2,opt,name=scale,proto3" json:"scale,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Decimal) Reset() {
+	*x = Decimal{}
+	mi := &file_api_proto_v1_rates_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Decimal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Decimal) ProtoMessage() {}
+
+func (x *Decimal) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_rates_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Decimal.ProtoReflect.Descriptor instead.
+func (*Decimal) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_rates_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Decimal) GetValue() int64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *Decimal) GetScale() int32 {
+	if x != nil {
+		return x.Scale
+	}
+	return 0
+}
+
+type RateResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	BuyRate   string                 `protobuf:"bytes,1,opt,name=buyRate,proto3" json:"buyRate,omitempty"`
+	SellRate  string                 `protobuf:"bytes,2,opt,name=sellRate,proto3" json:"sellRate,omitempty"`
+	CantorId  int32                  `protobuf:"varint,3,opt,name=cantorId,json=cantorID,proto3" json:"cantorId,omitempty"`
+	Currency  string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	FetchedAt int64                  `protobuf:"varint,5,opt,name=fetchedAt,proto3" json:"fetchedAt,omitempty"`
+	Change24H int64                  `protobuf:"varint,6,opt,name=change24h,proto3" json:"change24h,omitempty"`
+	Buy       *Decimal               `protobuf:"bytes,7,opt,name=buy,proto3" json:"buy,omitempty"`
+	Sell      *Decimal               `protobuf:"bytes,8,opt,name=sell,proto3" json:"sell,omitempty"`
+	// True when this is the last known value served while a refresh runs, or an archived value
+	// returned because the live scrape failed.
+	Stale bool `protobuf:"varint,9,opt,name=stale,proto3" json:"stale,omitempty"`
+	// Position of the update in the RATES JetStream stream, set by StreamRates when JetStream is
+	// available. Pass the last one seen as resume_from to continue after a reconnect. Sequences never
+	// decrease along a stream; while a throttled update is held back, later ones carry the position
+	// before it.
+	Sequence uint64 `protobuf:"varint,10,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// True for the current rates StreamRates sends before the live updates.
+	Snapshot      bool `protobuf:"varint,11,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *RateResponse) Reset() {
 	*x = RateResponse{}
-	mi := &file_api_proto_v1_rates_proto_msgTypes[0]
+	mi := &file_api_proto_v1_rates_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -47,7 +112,7 @@ func (x *RateResponse) String() string {
 func (*RateResponse) ProtoMessage() {}
 
 func (x *RateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_v1_rates_proto_msgTypes[0]
+	mi := &file_api_proto_v1_rates_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -60,7 +125,7 @@ func (x *RateResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RateResponse.ProtoReflect.Descriptor instead.
 func (*RateResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_v1_rates_proto_rawDescGZIP(), []int{0}
+	return file_api_proto_v1_rates_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *RateResponse) GetBuyRate() string {
@@ -105,18 +170,128 @@ func (x *RateResponse) GetChange24H() int64 {
 	return 0
 }
 
-type HistoryPoint struct {
+func (x *RateResponse) GetBuy() *Decimal {
+	if x != nil {
+		return x.Buy
+	}
+	return nil
+}
+
+func (x *RateResponse) GetSell() *Decimal {
+	if x != nil {
+		return x.Sell
+	}
+	return nil
+}
+
+func (x *RateResponse) GetStale() bool {
+	if x != nil {
+		return x.Stale
+	}
+	return false
+}
+
+func (x *RateResponse) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *RateResponse) GetSnapshot() bool {
+	if x != nil {
+		return x.Snapshot
+	}
+	return false
+}
+
+// Candle is the open, high, low and close of a rate within one history bucket.
+type Candle struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Time          int64                  `protobuf:"varint,1,opt,name=time,proto3" json:"time,omitempty"`
-	BuyRate       int64                  `protobuf:"varint,2,opt,name=buyRate,proto3" json:"buyRate,omitempty"`
-	SellRate      int64                  `protobuf:"varint,3,opt,name=sellRate,proto3" json:"sellRate,omitempty"`
+	Open          *Decimal               `protobuf:"bytes,1,opt,name=open,proto3" json:"open,omitempty"`
+	High          *Decimal               `protobuf:"bytes,2,opt,name=high,proto3" json:"high,omitempty"`
+	Low           *Decimal               `protobuf:"bytes,3,opt,name=low,proto3" json:"low,omitempty"`
+	Close         *Decimal               `protobuf:"bytes,4,opt,name=close,proto3" json:"close,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Candle) Reset() {
+	*x = Candle{}
+	mi := &file_api_proto_v1_rates_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Candle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Candle) ProtoMessage() {}
+
+func (x *Candle) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_rates_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Candle.ProtoReflect.Descriptor instead.
+func (*Candle) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_rates_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Candle) GetOpen() *Decimal {
+	if x != nil {
+		return x.Open
+	}
+	return nil
+}
+
+func (x *Candle) GetHigh() *Decimal {
+	if x != nil {
+		return x.High
+	}
+	return nil
+}
+
+func (x *Candle) GetLow() *Decimal {
+	if x != nil {
+		return x.Low
+	}
+	return nil
+}
+
+func (x *Candle) GetClose() *Decimal {
+	if x != nil {
+		return x.Close
+	}
+	return nil
+}
+
+type HistoryPoint struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Time  int64                  `protobuf:"varint,1,opt,name=time,proto3" json:"time,omitempty"`
+	// Legacy milli-unit values (rate * 1000), kept for older clients.
+	BuyRate  int64    `protobuf:"varint,2,opt,name=buyRate,proto3" json:"buyRate,omitempty"`
+	SellRate int64    `protobuf:"varint,3,opt,name=sellRate,proto3" json:"sellRate,omitempty"`
+	Buy      *Decimal `protobuf:"bytes,4,opt,name=buy,proto3" json:"buy,omitempty"`
+	Sell     *Decimal `protobuf:"bytes,5,opt,name=sell,proto3" json:"sell,omitempty"`
+	// Set only for agg=ohlc; buy and sell then hold the close.
+	BuyCandle     *Candle `protobuf:"bytes,6,opt,name=buyCandle,proto3" json:"buyCandle,omitempty"`
+	SellCandle    *Candle `protobuf:"bytes,7,opt,name=sellCandle,proto3" json:"sellCandle,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *HistoryPoint) Reset() {
 	*x = HistoryPoint{}
-	mi := &file_api_proto_v1_rates_proto_msgTypes[1]
+	mi := &file_api_proto_v1_rates_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -128,7 +303,7 @@ func (x *HistoryPoint) String() string {
 func (*HistoryPoint) ProtoMessage() {}
 
 func (x *HistoryPoint) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_v1_rates_proto_msgTypes[1]
+	mi := &file_api_proto_v1_rates_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -141,7 +316,7 @@ func (x *HistoryPoint) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HistoryPoint.ProtoReflect.Descriptor instead.
 func (*HistoryPoint) Descriptor() ([]byte, []int) {
-	return file_api_proto_v1_rates_proto_rawDescGZIP(), []int{1}
+	return file_api_proto_v1_rates_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *HistoryPoint) GetTime() int64 {
@@ -165,17 +340,48 @@ func (x *HistoryPoint) GetSellRate() int64 {
 	return 0
 }
 
+func (x *HistoryPoint) GetBuy() *Decimal {
+	if x != nil {
+		return x.Buy
+	}
+	return nil
+}
+
+func (x *HistoryPoint) GetSell() *Decimal {
+	if x != nil {
+		return x.Sell
+	}
+	return nil
+}
+
+func (x *HistoryPoint) GetBuyCandle() *Candle {
+	if x != nil {
+		return x.BuyCandle
+	}
+	return nil
+}
+
+func (x *HistoryPoint) GetSellCandle() *Candle {
+	if x != nil {
+		return x.SellCandle
+	}
+	return nil
+}
+
 type HistoryResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Points        []*HistoryPoint        `protobuf:"bytes,1,rep,name=points,proto3" json:"points,omitempty"`
-	Currency      string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Points   []*HistoryPoint        `protobuf:"bytes,1,rep,name=points,proto3" json:"points,omitempty"`
+	Currency string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	// Bucket width (e.g. "1h") and aggregation the points were computed with.
+	Interval      string `protobuf:"bytes,3,opt,name=interval,proto3" json:"interval,omitempty"`
+	Agg           string `protobuf:"bytes,4,opt,name=agg,proto3" json:"agg,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *HistoryResponse) Reset() {
 	*x = HistoryResponse{}
-	mi := &file_api_proto_v1_rates_proto_msgTypes[2]
+	mi := &file_api_proto_v1_rates_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -187,7 +393,7 @@ func (x *HistoryResponse) String() string {
 func (*HistoryResponse) ProtoMessage() {}
 
 func (x *HistoryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_v1_rates_proto_msgTypes[2]
+	mi := &file_api_proto_v1_rates_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -200,7 +406,7 @@ func (x *HistoryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HistoryResponse.ProtoReflect.Descriptor instead.
 func (*HistoryResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_v1_rates_proto_rawDescGZIP(), []int{2}
+	return file_api_proto_v1_rates_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *HistoryResponse) GetPoints() []*HistoryPoint {
@@ -217,6 +423,20 @@ func (x *HistoryResponse) GetCurrency() string {
 	return ""
 }
 
+func (x *HistoryResponse) GetInterval() string {
+	if x != nil {
+		return x.Interval
+	}
+	return ""
+}
+
+func (x *HistoryResponse) GetAgg() string {
+	if x != nil {
+		return x.Agg
+	}
+	return ""
+}
+
 type RateRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Currency      string                 `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`
@@ -226,7 +446,7 @@ type RateRequest struct {
 
 func (x *RateRequest) Reset() {
 	*x = RateRequest{}
-	mi := &file_api_proto_v1_rates_proto_msgTypes[3]
+	mi := &file_api_proto_v1_rates_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -238,7 +458,7 @@ func (x *RateRequest) String() string {
 func (*RateRequest) ProtoMessage() {}
 
 func (x *RateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_v1_rates_proto_msgTypes[3]
+	mi := &file_api_proto_v1_rates_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -251,7 +471,7 @@ func (x *RateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RateRequest.ProtoReflect.Descriptor instead.
 func (*RateRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_v1_rates_proto_rawDescGZIP(), []int{3}
+	return file_api_proto_v1_rates_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *RateRequest) GetCurrency() string {
@@ -274,7 +494,7 @@ type ScrapeCompletedEvent struct {
 
 func (x *ScrapeCompletedEvent) Reset() {
 	*x = ScrapeCompletedEvent{}
-	mi := &file_api_proto_v1_rates_proto_msgTypes[4]
+	mi := &file_api_proto_v1_rates_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -286,7 +506,7 @@ func (x *ScrapeCompletedEvent) String() string {
 func (*ScrapeCompletedEvent) ProtoMessage() {}
 
 func (x *ScrapeCompletedEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_v1_rates_proto_msgTypes[4]
+	mi := &file_api_proto_v1_rates_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -299,7 +519,7 @@ func (x *ScrapeCompletedEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScrapeCompletedEvent.ProtoReflect.Descriptor instead.
 func (*ScrapeCompletedEvent) Descriptor() ([]byte, []int) {
-	return file_api_proto_v1_rates_proto_rawDescGZIP(), []int{4}
+	return file_api_proto_v1_rates_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ScrapeCompletedEvent) GetProviderId() string {
@@ -346,7 +566,7 @@ type RateListResponse struct {
 
 func (x *RateListResponse) Reset() {
 	*x = RateListResponse{}
-	mi := &file_api_proto_v1_rates_proto_msgTypes[5]
+	mi := &file_api_proto_v1_rates_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -358,7 +578,7 @@ func (x *RateListResponse) String() string {
 func (*RateListResponse) ProtoMessage() {}
 
 func (x *RateListResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_v1_rates_proto_msgTypes[5]
+	mi := &file_api_proto_v1_rates_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -371,7 +591,7 @@ func (x *RateListResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RateListResponse.ProtoReflect.Descriptor instead.
 func (*RateListResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_v1_rates_proto_rawDescGZIP(), []int{5}
+	return file_api_proto_v1_rates_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *RateListResponse) GetResults() []*RateResponse {
@@ -382,15 +602,34 @@ func (x *RateListResponse) GetResults() []*RateResponse {
 }
 
 type StreamRatesRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Currencies    []string               `protobuf:"bytes,1,rep,name=currencies,proto3" json:"currencies,omitempty"`
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Currencies []string               `protobuf:"bytes,1,rep,name=currencies,proto3" json:"currencies,omitempty"`
+	// Sequence of the last update received; missed updates after it are replayed first. When they
+	// are no longer retained, the current rates are sent instead.
+	ResumeFrom uint64 `protobuf:"varint,2,opt,name=resume_from,json=resumeFrom,proto3" json:"resume_from,omitempty"`
+	// Send the latest rate of every cantor in the requested currencies, marked as snapshot, before
+	// the live updates.
+	IncludeSnapshot bool `protobuf:"varint,3,opt,name=include_snapshot,json=includeSnapshot,proto3" json:"include_snapshot,omitempty"`
+	// Only these cantors; empty means all.
+	CantorIds []int32 `protobuf:"varint,4,rep,packed,name=cantor_ids,json=cantorIDs,proto3" json:"cantor_ids,omitempty"`
+	// Only cantors within radius_km of lat/lon, and inside bbox when set.
+	Lat      float64      `protobuf:"fixed64,5,opt,name=lat,proto3" json:"lat,omitempty"`
+	Lon      float64      `protobuf:"fixed64,6,opt,name=lon,proto3" json:"lon,omitempty"`
+	RadiusKm float64      `protobuf:"fixed64,7,opt,name=radius_km,json=radiusKm,proto3" json:"radius_km,omitempty"`
+	Bbox     *BoundingBox `protobuf:"bytes,8,opt,name=bbox,proto3" json:"bbox,omitempty"`
+	// Skip updates moving neither buy nor sell by at least this many basis points since the last rate
+	// sent for the cantor and currency.
+	MinChangeBp int64 `protobuf:"varint,9,opt,name=min_change_bp,json=minChangeBP,proto3" json:"min_change_bp,omitempty"`
+	// Send at most one update per cantor and currency per interval; the latest one held back is sent
+	// when the interval ends. Resuming never skips a held back update.
+	ThrottleMs    int64 `protobuf:"varint,10,opt,name=throttle_ms,json=throttleMS,proto3" json:"throttle_ms,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *StreamRatesRequest) Reset() {
 	*x = StreamRatesRequest{}
-	mi := &file_api_proto_v1_rates_proto_msgTypes[6]
+	mi := &file_api_proto_v1_rates_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -402,7 +641,7 @@ func (x *StreamRatesRequest) String() string {
 func (*StreamRatesRequest) ProtoMessage() {}
 
 func (x *StreamRatesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_v1_rates_proto_msgTypes[6]
+	mi := &file_api_proto_v1_rates_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -415,7 +654,7 @@ func (x *StreamRatesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StreamRatesRequest.ProtoReflect.Descriptor instead.
 func (*StreamRatesRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_v1_rates_proto_rawDescGZIP(), []int{6}
+	return file_api_proto_v1_rates_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *StreamRatesRequest) GetCurrencies() []string {
@@ -425,46 +664,1958 @@ func (x *StreamRatesRequest) GetCurrencies() []string {
 	return nil
 }
 
-var File_api_proto_v1_rates_proto protoreflect.FileDescriptor
+func (x *StreamRatesRequest) GetResumeFrom() uint64 {
+	if x != nil {
+		return x.ResumeFrom
+	}
+	return 0
+}
 
-const file_api_proto_v1_rates_proto_rawDesc = "" +
-	"\n" +
-	"\x18api/proto/v1/rates.proto\x12\x02v1\"\xb8\x01\n" +
-	"\fRateResponse\x12\x18\n" +
-	"\abuyRate\x18\x01 \x01(\tR\abuyRate\x12\x1a\n" +
-	"\bsellRate\x18\x02 \x01(\tR\bsellRate\x12\x1a\n" +
-	"\bcantorId\x18\x03 \x01(\x05R\bcantorID\x12\x1a\n" +
-	"\bcurrency\x18\x04 \x01(\tR\bcurrency\x12\x1c\n" +
-	"\tfetchedAt\x18\x05 \x01(\x03R\tfetchedAt\x12\x1c\n" +
-	"\tchange24h\x18\x06 \x01(\x03R\tchange24h\"X\n" +
-	"\fHistoryPoint\x12\x12\n" +
-	"\x04time\x18\x01 \x01(\x03R\x04time\x12\x18\n" +
-	"\abuyRate\x18\x02 \x01(\x03R\abuyRate\x12\x1a\n" +
-	"\bsellRate\x18\x03 \x01(\x03R\bsellRate\"W\n" +
-	"\x0fHistoryResponse\x12(\n" +
-	"\x06points\x18\x01 \x03(\v2\x10.v1.HistoryPointR\x06points\x12\x1a\n" +
-	"\bcurrency\x18\x02 \x01(\tR\bcurrency\")\n" +
-	"\vRateRequest\x12\x1a\n" +
-	"\bcurrency\x18\x01 \x01(\tR\bcurrency\"\xb0\x01\n" +
-	"\x14ScrapeCompletedEvent\x12\x1e\n" +
-	"\n" +
-	"providerId\x18\x01 \x01(\tR\n" +
-	"providerID\x12 \n" +
-	"\vscraperType\x18\x02 \x01(\tR\vscraperType\x12\x1e\n" +
-	"\n" +
-	"durationMs\x18\x03 \x01(\x03R\n" +
-	"durationMS\x12\x1c\n" +
-	"\ttimestamp\x18\x04 \x01(\x03R\ttimestamp\x12\x18\n" +
-	"\atraceId\x18\x05 \x01(\tR\atraceID\">\n" +
-	"\x10RateListResponse\x12*\n" +
-	"\aresults\x18\x01 \x03(\v2\x10.v1.RateResponseR\aresults\"4\n" +
-	"\x12StreamRatesRequest\x12\x1e\n" +
-	"\n" +
-	"currencies\x18\x01 \x03(\tR\n" +
-	"currencies2\x7f\n" +
-	"\fRatesService\x129\n" +
-	"\vStreamRates\x12\x16.v1.StreamRatesRequest\x1a\x10.v1.RateResponse0\x01\x124\n" +
-	"\vGetAllRates\x12\x0f.v1.RateRequest\x1a\x14.v1.RateListResponseB$Z\"github.com/Niutaq/Gix/api/proto/v1b\x06proto3"
+func (x *StreamRatesRequest) GetIncludeSnapshot() bool {
+	if x != nil {
+		return x.IncludeSnapshot
+	}
+	return false
+}
+
+func (x *StreamRatesRequest) GetCantorIds() []int32 {
+	if x != nil {
+		return x.CantorIds
+	}
+	return nil
+}
+
+func (x *StreamRatesRequest) GetLat() float64 {
+	if x != nil {
+		return x.Lat
+	}
+	return 0
+}
+
+func (x *StreamRatesRequest) GetLon() float64 {
+	if x != nil {
+		return x.Lon
+	}
+	return 0
+}
+
+func (x *StreamRatesRequest) GetRadiusKm() float64 {
+	if x != nil {
+		return x.RadiusKm
+	}
+	return 0
+}
+
+func (x *StreamRatesRequest) GetBbox() *BoundingBox {
+	if x != nil {
+		return x.Bbox
+	}
+	return nil
+}
+
+func (x *StreamRatesRequest) GetMinChangeBp() int64 {
+	if x != nil {
+		return x.MinChangeBp
+	}
+	return 0
+}
+
+func (x *StreamRatesRequest) GetThrottleMs() int64 {
+	if x != nil {
+		return x.ThrottleMs
+	}
+	return 0
+}
+
+// BoundingBox is a map viewport. min_lon > max_lon describes a box crossing the antimeridian.
+type BoundingBox struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MinLat        float64                `protobuf:"fixed64,1,opt,name=min_lat,json=minLat,proto3" json:"min_lat,omitempty"`
+	MinLon        float64                `protobuf:"fixed64,2,opt,name=min_lon,json=minLon,proto3" json:"min_lon,omitempty"`
+	MaxLat        float64                `protobuf:"fixed64,3,opt,name=max_lat,json=maxLat,proto3" json:"max_lat,omitempty"`
+	MaxLon        float64                `protobuf:"fixed64,4,opt,name=max_lon,json=maxLon,proto3" json:"max_lon,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BoundingBox) Reset() {
+	*x = BoundingBox{}
+	mi := &file_api_proto_v1_rates_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BoundingBox) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BoundingBox) ProtoMessage() {}
+
+func (x *BoundingBox) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_rates_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BoundingBox.ProtoReflect.Descriptor instead.
+func (*BoundingBox) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_rates_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *BoundingBox) GetMinLat() float64 {
+	if x != nil {
+		return x.MinLat
+	}
+	return 0
+}
+
+func (x *BoundingBox) GetMinLon() float64 {
+	if x != nil {
+		return x.MinLon
+	}
+	return 0
+}
+
+func (x *BoundingBox) GetMaxLat() float64 {
+	if x != nil {
+		return x.MaxLat
+	}
+	return 0
+}
+
+func (x *BoundingBox) GetMaxLon() float64 {
+	if x != nil {
+		return x.MaxLon
+	}
+	return 0
+}
+
+// QuoteRequest asks where exchanging `amount` of `currency` is most profitable.
+// side "sell" means the user sells the currency for PLN, "buy" means the user buys it with PLN.
+type QuoteRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Currency string                 `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`
+	Amount   *Decimal               `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Side     string                 `protobuf:"bytes,3,opt,name=side,proto3" json:"side,omitempty"`
+	Lat      float64                `protobuf:"fixed64,4,opt,name=lat,proto3" json:"lat,omitempty"`
+	Lon      float64                `protobuf:"fixed64,5,opt,name=lon,proto3" json:"lon,omitempty"`
+	RadiusKm float64                `protobuf:"fixed64,6,opt,name=radiusKm,proto3" json:"radiusKm,omitempty"`
+	// PLN deducted from the result (or added to the cost) per kilometre of distance.
+	DistancePenalty *Decimal `protobuf:"bytes,7,opt,name=distancePenalty,proto3" json:"distancePenalty,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *QuoteRequest) Reset() {
+	*x = QuoteRequest{}
+	mi := &file_api_proto_v1_rates_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuoteRequest) ProtoMessage() {}
+
+func (x *QuoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_rates_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuoteRequest.ProtoReflect.Descriptor instead.
+func (*QuoteRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_rates_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *QuoteRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *QuoteRequest) GetAmount() *Decimal {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *QuoteRequest) GetSide() string {
+	if x != nil {
+		return x.Side
+	}
+	return ""
+}
+
+func (x *QuoteRequest) GetLat() float64 {
+	if x != nil {
+		return x.Lat
+	}
+	return 0
+}
+
+func (x *QuoteRequest) GetLon() float64 {
+	if x != nil {
+		return x.Lon
+	}
+	return 0
+}
+
+func (x *QuoteRequest) GetRadiusKm() float64 {
+	if x != nil {
+		return x.RadiusKm
+	}
+	return 0
+}
+
+func (x *QuoteRequest) GetDistancePenalty() *Decimal {
+	if x != nil {
+		return x.DistancePenalty
+	}
+	return nil
+}
+
+type Quote struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	CantorId    int32                  `protobuf:"varint,1,opt,name=cantorId,json=cantorID,proto3" json:"cantorId,omitempty"`
+	DisplayName string                 `protobuf:"bytes,2,opt,name=displayName,proto3" json:"displayName,omitempty"`
+	Rate        *Decimal               `protobuf:"bytes,3,opt,name=rate,proto3" json:"rate,omitempty"`
+	// PLN received (side "sell") or paid (side "buy").
+	PlnAmount *Decimal `protobuf:"bytes,4,opt,name=plnAmount,proto3" json:"plnAmount,omitempty"`
+	// plnAmount adjusted by the distance penalty; the ranking key.
+	Score *Decimal `protobuf:"bytes,5,opt,name=score,proto3" json:"score,omitempty"`
+	// -1 when no location was given or the cantor has no coordinates.
+	DistanceKm    float64 `protobuf:"fixed64,6,opt,name=distanceKm,proto3" json:"distanceKm,omitempty"`
+	FetchedAt     int64   `protobuf:"varint,7,opt,name=fetchedAt,proto3" json:"fetchedAt,omitempty"`
+	Stale         bool    `protobuf:"varint,8,opt,name=stale,proto3" json:"stale,omitempty"`
+	Units         int32   `protobuf:"varint,9,opt,name=units,proto3" json:"units,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Quote) Reset() {
+	*x = Quote{}
+	mi := &file_api_proto_v1_rates_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Quote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Quote) ProtoMessage() {}
+
+func (x *Quote) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_rates_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Quote.ProtoReflect.Descriptor instead.
+func (*Quote) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_rates_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Quote) GetCantorId() int32 {
+	if x != nil {
+		return x.CantorId
+	}
+	return 0
+}
+
+func (x *Quote) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *Quote) GetRate() *Decimal {
+	if x != nil {
+		return x.Rate
+	}
+	return nil
+}
+
+func (x *Quote) GetPlnAmount() *Decimal {
+	if x != nil {
+		return x.PlnAmount
+	}
+	return nil
+}
+
+func (x *Quote) GetScore() *Decimal {
+	if x != nil {
+		return x.Score
+	}
+	return nil
+}
+
+func (x *Quote) GetDistanceKm() float64 {
+	if x != nil {
+		return x.DistanceKm
+	}
+	return 0
+}
+
+func (x *Quote) GetFetchedAt() int64 {
+	if x != nil {
+		return x.FetchedAt
+	}
+	return 0
+}
+
+func (x *Quote) GetStale() bool {
+	if x != nil {
+		return x.Stale
+	}
+	return false
+}
+
+func (x *Quote) GetUnits() int32 {
+	if x != nil {
+		return x.Units
+	}
+	return 0
+}
+
+type QuoteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Currency      string                 `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`
+	Side          string                 `protobuf:"bytes,2,opt,name=side,proto3" json:"side,omitempty"`
+	Amount        *Decimal               `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Quotes        []*Quote               `protobuf:"bytes,4,rep,name=quotes,proto3" json:"quotes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuoteResponse) Reset() {
+	*x = QuoteResponse{}
+	mi := &file_api_proto_v1_rates_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuoteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuoteResponse) ProtoMessage() {}
+
+func (x *QuoteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_rates_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuoteResponse.ProtoReflect.Descriptor instead.
+func (*QuoteResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_rates_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *QuoteResponse) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *QuoteResponse) GetSide() string {
+	if x != nil {
+		return x.Side
+	}
+	return ""
+}
+
+func (x *QuoteResponse) GetAmount() *Decimal {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *QuoteResponse) GetQuotes() []*Quote {
+	if x != nil {
+		return x.Quotes
+	}
+	return nil
+}
+
+type AlertEvent struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	RuleId   int32                  `protobuf:"varint,2,opt,name=ruleId,json=ruleID,proto3" json:"ruleId,omitempty"`
+	Owner    string                 `protobuf:"bytes,3,opt,name=owner,proto3" json:"owner,omitempty"`
+	CantorId int32                  `protobuf:"varint,4,opt,name=cantorId,json=cantorID,proto3" json:"cantorId,omitempty"`
+	Currency string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	Side     string                 `protobuf:"bytes,6,opt,name=side,proto3" json:"side,omitempty"`
+	Kind     string                 `protobuf:"bytes,7,opt,name=kind,proto3" json:"kind,omitempty"`
+	Rate     *Decimal               `protobuf:"bytes,8,opt,name=rate,proto3" json:"rate,omitempty"`
+	// Threshold, or the value a percent move was measured from.
+	Reference     *Decimal `protobuf:"bytes,9,opt,name=reference,proto3" json:"reference,omitempty"`
+	FiredAt       int64    `protobuf:"varint,10,opt,name=firedAt,proto3" json:"firedAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AlertEvent) Reset() {
+	*x = AlertEvent{}
+	mi := &file_api_proto_v1_rates_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AlertEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlertEvent) ProtoMessage() {}
+
+func (x *AlertEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_rates_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlertEvent.ProtoReflect.Descriptor instead.
+func (*AlertEvent) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_rates_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *AlertEvent) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AlertEvent) GetRuleId() int32 {
+	if x != nil {
+		return x.RuleId
+	}
+	return 0
+}
+
+func (x *AlertEvent) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *AlertEvent) GetCantorId() int32 {
+	if x != nil {
+		return x.CantorId
+	}
+	return 0
+}
+
+func (x *AlertEvent) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *AlertEvent) GetSide() string {
+	if x != nil {
+		return x.Side
+	}
+	return ""
+}
+
+func (x *AlertEvent) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *AlertEvent) GetRate() *Decimal {
+	if x != nil {
+		return x.Rate
+	}
+	return nil
+}
+
+func (x *AlertEvent) GetReference() *Decimal {
+	if x != nil {
+		return x.Reference
+	}
+	return nil
+}
+
+func (x *AlertEvent) GetFiredAt() int64 {
+	if x != nil {
+		return x.FiredAt
+	}
+	return 0
+}
+
+// StreamAlertsRequest subscribes to alerts of the caller, optionally limited to some rules. The owner
+// may be left empty; when set it must be the caller's own.
+type StreamAlertsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Owner         string                 `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	RuleIds       []int32                `protobuf:"varint,2,rep,packed,name=ruleIds,json=ruleIDs,proto3" json:"ruleIds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamAlertsRequest) Reset() {
+	*x = StreamAlertsRequest{}
+	mi := &file_api_proto_v1_rates_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamAlertsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamAlertsRequest) ProtoMessage() {}
+
+func (x *StreamAlertsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_rates_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamAlertsRequest.ProtoReflect.Descriptor instead.
+func (*StreamAlertsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_rates_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *StreamAlertsRequest) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *StreamAlertsRequest) GetRuleIds() []int32 {
+	if x != nil {
+		return x.RuleIds
+	}
+	return nil
+}
+
+// SnapshotRequest asks for the latest rates of several currencies at once. An empty list means
+// every supported currency.
+type SnapshotRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Currencies    []string               `protobuf:"bytes,1,rep,name=currencies,proto3" json:"currencies,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SnapshotRequest) Reset() {
+	*x = SnapshotRequest{}
+	mi := &file_api_proto_v1_rates_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotRequest) ProtoMessage() {}
+
+func (x *SnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_rates_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotRequest.ProtoReflect.Descriptor instead.
+func (*SnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_rates_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SnapshotRequest) GetCurrencies() []string {
+	if x != nil {
+		return x.Currencies
+	}
+	return nil
+}
+
+// SnapshotRow holds the latest rates of one cantor keyed by currency. Currencies the cantor does
+// not quote are absent.
+type SnapshotRow struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	CantorId      int32                    `protobuf:"varint,1,opt,name=cantorId,json=cantorID,proto3" json:"cantorId,omitempty"`
+	DisplayName   string                   `protobuf:"bytes,2,opt,name=displayName,proto3" json:"displayName,omitempty"`
+	Rates         map[string]*RateResponse `protobuf:"bytes,3,rep,name=rates,proto3" json:"rates,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SnapshotRow) Reset() {
+	*x = SnapshotRow{}
+	mi := &file_api_proto_v1_rates_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SnapshotRow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotRow) ProtoMessage() {}
+
+func (x *SnapshotRow) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_rates_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotRow.ProtoReflect.Descriptor instead.
+func (*SnapshotRow) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_rates_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SnapshotRow) GetCantorId() int32 {
+	if x != nil {
+		return x.CantorId
+	}
+	return 0
+}
+
+func (x *SnapshotRow) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *SnapshotRow) GetRates() map[string]*RateResponse {
+	if x != nil {
+		return x.Rates
+	}
+	return nil
+}
+
+// SnapshotResponse is the cantor x currency matrix of latest rates. A rate is marked stale when
+// its last observation is older than the freshness window.
+type SnapshotResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GeneratedAt   int64                  `protobuf:"varint,1,opt,name=generatedAt,proto3" json:"generatedAt,omitempty"`
+	Currencies    []string               `protobuf:"bytes,2,rep,name=currencies,proto3" json:"currencies,omitempty"`
+	Cantors       []*SnapshotRow         `protobuf:"bytes,3,rep,name=cantors,proto3" json:"cantors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SnapshotResponse) Reset() {
+	*x = SnapshotResponse{}
+	mi := &file_api_proto_v1_rates_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SnapshotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotResponse) ProtoMessage() {}
+
+func (x *SnapshotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_rates_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotResponse.ProtoReflect.Descriptor instead.
+func (*SnapshotResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_rates_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SnapshotResponse) GetGeneratedAt() int64 {
+	if x != nil {
+		return x.GeneratedAt
+	}
+	return 0
+}
+
+func (x *SnapshotResponse) GetCurrencies() []string {
+	if x != nil {
+		return x.Currencies
+	}
+	return nil
+}
+
+func (x *SnapshotResponse) GetCantors() []*SnapshotRow {
+	if x != nil {
+		return x.Cantors
+	}
+	return nil
+}
+
+// StreamEvent is a frame of the HTTP rate stream (WebSocket transport). "rate" frames carry an
+// update and the token to resume after it; "heartbeat" frames only carry the server time.
+type StreamEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	Rate          *RateResponse          `protobuf:"bytes,3,opt,name=rate,proto3" json:"rate,omitempty"`
+	Time          int64                  `protobuf:"varint,4,opt,name=time,proto3" json:"time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamEvent) Reset() {
+	*x = StreamEvent{}
+	mi := &file_api_proto_v1_rates_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamEvent) ProtoMessage() {}
+
+func (x *StreamEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_rates_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamEvent.ProtoReflect.Descriptor instead.
+func (*StreamEvent) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_rates_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *StreamEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *StreamEvent) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *StreamEvent) GetRate() *RateResponse {
+	if x != nil {
+		return x.Rate
+	}
+	return nil
+}
+
+func (x *StreamEvent) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+// GeoPoint is a position in degrees.
+type GeoPoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lat           float64                `protobuf:"fixed64,1,opt,name=lat,proto3" json:"lat,omitempty"`
+	Lon           float64                `protobuf:"fixed64,2,opt,name=lon,proto3" json:"lon,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GeoPoint) Reset() {
+	*x = GeoPoint{}
+	mi := &file_api_proto_v1_rates_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeoPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeoPoint) ProtoMessage() {}
+
+func (x *GeoPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_rates_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeoPoint.ProtoReflect.Descriptor instead.
+func (*GeoPoint) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_rates_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GeoPoint) GetLat() float64 {
+	if x != nil {
+		return x.Lat
+	}
+	return 0
+}
+
+func (x *GeoPoint) GetLon() float64 {
+	if x != nil {
+		return x.Lon
+	}
+	return 0
+}
+
+// ListCantorsRequest selects a page of the cantor listing, with the filters of GET /cantors.
+type ListCantorsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Reference point for radius_km and the distance sort order.
+	Near     *GeoPoint    `protobuf:"bytes,1,opt,name=near,proto3" json:"near,omitempty"`
+	RadiusKm float64      `protobuf:"fixed64,2,opt,name=radius_km,json=radiusKm,proto3" json:"radius_km,omitempty"`
+	Bbox     *BoundingBox `protobuf:"bytes,3,opt,name=bbox,proto3" json:"bbox,omitempty"`
+	// Only cantors quoting this currency; their current rates are then returned.
+	Currency string `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	// Text filter on name and address.
+	Q string `protobuf:"bytes,5,opt,name=q,proto3" json:"q,omitempty"`
+	// id (default), name, distance, buy or sell.
+	Sort  string `protobuf:"bytes,6,opt,name=sort,proto3" json:"sort,omitempty"`
+	Limit int32  `protobuf:"varint,7,opt,name=limit,proto3" json:"limit,omitempty"`
+	// next_cursor of the previous page.
+	Cursor string `protobuf:"bytes,8,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// Include disabled cantors; requires the operator role.
+	All           bool `protobuf:"varint,9,opt,name=all,proto3" json:"all,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCantorsRequest) Reset() {
+	*x = ListCantorsRequest{}
+	mi := &file_api_proto_v1_rates_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCantorsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCantorsRequest) ProtoMessage() {}
+
+func (x *ListCantorsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_rates_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCantorsRequest.ProtoReflect.Descriptor instead.
+func (*ListCantorsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_rates_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListCantorsRequest) GetNear() *GeoPoint {
+	if x != nil {
+		return x.Near
+	}
+	return nil
+}
+
+func (x *ListCantorsRequest) GetRadiusKm() float64 {
+	if x != nil {
+		return x.RadiusKm
+	}
+	return 0
+}
+
+func (x *ListCantorsRequest) GetBbox() *BoundingBox {
+	if x != nil {
+		return x.Bbox
+	}
+	return nil
+}
+
+func (x *ListCantorsRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *ListCantorsRequest) GetQ() string {
+	if x != nil {
+		return x.Q
+	}
+	return ""
+}
+
+func (x *ListCantorsRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListCantorsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListCantorsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListCantorsRequest) GetAll() bool {
+	if x != nil {
+		return x.All
+	}
+	return false
+}
+
+type Cantor struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	DisplayName string                 `protobuf:"bytes,2,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	Name        string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Latitude    float64                `protobuf:"fixed64,4,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude   float64                `protobuf:"fixed64,5,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Strategy    string                 `protobuf:"bytes,6,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	Address     string                 `protobuf:"bytes,7,opt,name=address,proto3" json:"address,omitempty"`
+	Origin      string                 `protobuf:"bytes,8,opt,name=origin,proto3" json:"origin,omitempty"`
+	Enabled     bool                   `protobuf:"varint,9,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// -1 when the listing was not queried with near.
+	DistanceKm float64 `protobuf:"fixed64,10,opt,name=distance_km,json=distanceKm,proto3" json:"distance_km,omitempty"`
+	// Current per-unit rates, set when the listing was queried with a currency.
+	Buy           *Decimal `protobuf:"bytes,11,opt,name=buy,proto3" json:"buy,omitempty"`
+	Sell          *Decimal `protobuf:"bytes,12,opt,name=sell,proto3" json:"sell,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Cantor) Reset() {
+	*x = Cantor{}
+	mi := &file_api_proto_v1_rates_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Cantor) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Cantor) ProtoMessage() {}
+
+func (x *Cantor) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_rates_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Cantor.ProtoReflect.Descriptor instead.
+func (*Cantor) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_rates_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Cantor) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Cantor) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *Cantor) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Cantor) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *Cantor) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *Cantor) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+func (x *Cantor) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *Cantor) GetOrigin() string {
+	if x != nil {
+		return x.Origin
+	}
+	return ""
+}
+
+func (x *Cantor) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *Cantor) GetDistanceKm() float64 {
+	if x != nil {
+		return x.DistanceKm
+	}
+	return 0
+}
+
+func (x *Cantor) GetBuy() *Decimal {
+	if x != nil {
+		return x.Buy
+	}
+	return nil
+}
+
+func (x *Cantor) GetSell() *Decimal {
+	if x != nil {
+		return x.Sell
+	}
+	return nil
+}
+
+type ListCantorsResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Cantors []*Cantor              `protobuf:"bytes,1,rep,name=cantors,proto3" json:"cantors,omitempty"`
+	// Empty on the last page.
+	NextCursor    string `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCantorsResponse) Reset() {
+	*x = ListCantorsResponse{}
+	mi := &file_api_proto_v1_rates_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCantorsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCantorsResponse) ProtoMessage() {}
+
+func (x *ListCantorsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_rates_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCantorsResponse.ProtoReflect.Descriptor instead.
+func (*ListCantorsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_rates_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListCantorsResponse) GetCantors() []*Cantor {
+	if x != nil {
+		return x.Cantors
+	}
+	return nil
+}
+
+func (x *ListCantorsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+// HistoryRequest selects the history of a currency, optionally for one cantor. interval (5m, 15m,
+// 1h, 6h, 1d) is chosen from the range when empty; agg is avg, ohlc, min, max or last.
+type HistoryRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Currency string                 `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`
+	CantorId int32                  `protobuf:"varint,2,opt,name=cantor_id,json=cantorID,proto3" json:"cantor_id,omitempty"`
+	// Defaults to 7.
+	Days          int32  `protobuf:"varint,3,opt,name=days,proto3" json:"days,omitempty"`
+	Interval      string `protobuf:"bytes,4,opt,name=interval,proto3" json:"interval,omitempty"`
+	Agg           string `protobuf:"bytes,5,opt,name=agg,proto3" json:"agg,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HistoryRequest) Reset() {
+	*x = HistoryRequest{}
+	mi := &file_api_proto_v1_rates_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoryRequest) ProtoMessage() {}
+
+func (x *HistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_rates_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistoryRequest.ProtoReflect.Descriptor instead.
+func (*HistoryRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_rates_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *HistoryRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *HistoryRequest) GetCantorId() int32 {
+	if x != nil {
+		return x.CantorId
+	}
+	return 0
+}
+
+func (x *HistoryRequest) GetDays() int32 {
+	if x != nil {
+		return x.Days
+	}
+	return 0
+}
+
+func (x *HistoryRequest) GetInterval() string {
+	if x != nil {
+		return x.Interval
+	}
+	return ""
+}
+
+func (x *HistoryRequest) GetAgg() string {
+	if x != nil {
+		return x.Agg
+	}
+	return ""
+}
+
+type FinOpsStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FinOpsStatusRequest) Reset() {
+	*x = FinOpsStatusRequest{}
+	mi := &file_api_proto_v1_rates_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FinOpsStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FinOpsStatusRequest) ProtoMessage() {}
+
+func (x *FinOpsStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_rates_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FinOpsStatusRequest.ProtoReflect.Descriptor instead.
+func (*FinOpsStatusRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_rates_proto_rawDescGZIP(), []int{24}
+}
+
+type FinOpsTip struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Title       string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Description string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// Estimated monthly savings in USD.
+	Potential     float64 `protobuf:"fixed64,3,opt,name=potential,proto3" json:"potential,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FinOpsTip) Reset() {
+	*x = FinOpsTip{}
+	mi := &file_api_proto_v1_rates_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FinOpsTip) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FinOpsTip) ProtoMessage() {}
+
+func (x *FinOpsTip) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_rates_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FinOpsTip.ProtoReflect.Descriptor instead.
+func (*FinOpsTip) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_rates_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *FinOpsTip) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *FinOpsTip) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *FinOpsTip) GetPotential() float64 {
+	if x != nil {
+		return x.Potential
+	}
+	return 0
+}
+
+// FinOpsStatus is the scraping cost summary shown by the client terminal. Amounts are USD decimals
+// formatted with eight fraction digits.
+type FinOpsStatus struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	TotalScrapes       int64                  `protobuf:"varint,1,opt,name=total_scrapes,json=totalScrapes,proto3" json:"total_scrapes,omitempty"`
+	AvgDuration        string                 `protobuf:"bytes,2,opt,name=avg_duration,json=avgDuration,proto3" json:"avg_duration,omitempty"`
+	ExpensiveTasks     []string               `protobuf:"bytes,3,rep,name=expensive_tasks,json=expensiveTasks,proto3" json:"expensive_tasks,omitempty"`
+	RealSpend_24HUsd   string                 `protobuf:"bytes,4,opt,name=real_spend_24h_usd,json=realSpend24hUSD,proto3" json:"real_spend_24h_usd,omitempty"`
+	Infrastructure     string                 `protobuf:"bytes,5,opt,name=infrastructure,proto3" json:"infrastructure,omitempty"`
+	Tips               []*FinOpsTip           `protobuf:"bytes,6,rep,name=tips,proto3" json:"tips,omitempty"`
+	BlockedProviders   []string               `protobuf:"bytes,7,rep,name=blocked_providers,json=blockedProviders,proto3" json:"blocked_providers,omitempty"`
+	IsGovernanceActive bool                   `protobuf:"varint,8,opt,name=is_governance_active,json=isGovernanceActive,proto3" json:"is_governance_active,omitempty"`
+	// RFC 3339 server time.
+	SystemTime string `protobuf:"bytes,9,opt,name=system_time,json=systemTime,proto3" json:"system_time,omitempty"`
+	// Spend over the last 24 hours per FOCUS service category.
+	ServiceBreakdown map[string]string `protobuf:"bytes,10,rep,name=service_breakdown,json=serviceBreakdown,proto3" json:"service_breakdown,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *FinOpsStatus) Reset() {
+	*x = FinOpsStatus{}
+	mi := &file_api_proto_v1_rates_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FinOpsStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FinOpsStatus) ProtoMessage() {}
+
+func (x *FinOpsStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_rates_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FinOpsStatus.ProtoReflect.Descriptor instead.
+func (*FinOpsStatus) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_rates_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *FinOpsStatus) GetTotalScrapes() int64 {
+	if x != nil {
+		return x.TotalScrapes
+	}
+	return 0
+}
+
+func (x *FinOpsStatus) GetAvgDuration() string {
+	if x != nil {
+		return x.AvgDuration
+	}
+	return ""
+}
+
+func (x *FinOpsStatus) GetExpensiveTasks() []string {
+	if x != nil {
+		return x.ExpensiveTasks
+	}
+	return nil
+}
+
+func (x *FinOpsStatus) GetRealSpend_24HUsd() string {
+	if x != nil {
+		return x.RealSpend_24HUsd
+	}
+	return ""
+}
+
+func (x *FinOpsStatus) GetInfrastructure() string {
+	if x != nil {
+		return x.Infrastructure
+	}
+	return ""
+}
+
+func (x *FinOpsStatus) GetTips() []*FinOpsTip {
+	if x != nil {
+		return x.Tips
+	}
+	return nil
+}
+
+func (x *FinOpsStatus) GetBlockedProviders() []string {
+	if x != nil {
+		return x.BlockedProviders
+	}
+	return nil
+}
+
+func (x *FinOpsStatus) GetIsGovernanceActive() bool {
+	if x != nil {
+		return x.IsGovernanceActive
+	}
+	return false
+}
+
+func (x *FinOpsStatus) GetSystemTime() string {
+	if x != nil {
+		return x.SystemTime
+	}
+	return ""
+}
+
+func (x *FinOpsStatus) GetServiceBreakdown() map[string]string {
+	if x != nil {
+		return x.ServiceBreakdown
+	}
+	return nil
+}
+
+// DiscoverRequest adds the cantor behind url. The expected_* fields, e.g. from OpenStreetMap, replace
+// the metadata guessed from the page.
+type DiscoverRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Url             string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	ExpectedName    string                 `protobuf:"bytes,2,opt,name=expected_name,json=expectedName,proto3" json:"expected_name,omitempty"`
+	ExpectedLat     float64                `protobuf:"fixed64,3,opt,name=expected_lat,json=expectedLat,proto3" json:"expected_lat,omitempty"`
+	ExpectedLon     float64                `protobuf:"fixed64,4,opt,name=expected_lon,json=expectedLon,proto3" json:"expected_lon,omitempty"`
+	ExpectedAddress string                 `protobuf:"bytes,5,opt,name=expected_address,json=expectedAddress,proto3" json:"expected_address,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DiscoverRequest) Reset() {
+	*x = DiscoverRequest{}
+	mi := &file_api_proto_v1_rates_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiscoverRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiscoverRequest) ProtoMessage() {}
+
+func (x *DiscoverRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_rates_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiscoverRequest.ProtoReflect.Descriptor instead.
+func (*DiscoverRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_rates_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *DiscoverRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *DiscoverRequest) GetExpectedName() string {
+	if x != nil {
+		return x.ExpectedName
+	}
+	return ""
+}
+
+func (x *DiscoverRequest) GetExpectedLat() float64 {
+	if x != nil {
+		return x.ExpectedLat
+	}
+	return 0
+}
+
+func (x *DiscoverRequest) GetExpectedLon() float64 {
+	if x != nil {
+		return x.ExpectedLon
+	}
+	return 0
+}
+
+func (x *DiscoverRequest) GetExpectedAddress() string {
+	if x != nil {
+		return x.ExpectedAddress
+	}
+	return ""
+}
+
+// DiscoverResponse describes the stored cantor and the EUR rates found on its page, "0.0000" when
+// none were found. The other currencies are harvested in the background.
+type DiscoverResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Address       string                 `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	Lat           float64                `protobuf:"fixed64,4,opt,name=lat,proto3" json:"lat,omitempty"`
+	Lon           float64                `protobuf:"fixed64,5,opt,name=lon,proto3" json:"lon,omitempty"`
+	BuyRate       string                 `protobuf:"bytes,6,opt,name=buy_rate,json=buyRate,proto3" json:"buy_rate,omitempty"`
+	SellRate      string                 `protobuf:"bytes,7,opt,name=sell_rate,json=sellRate,proto3" json:"sell_rate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiscoverResponse) Reset() {
+	*x = DiscoverResponse{}
+	mi := &file_api_proto_v1_rates_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiscoverResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiscoverResponse) ProtoMessage() {}
+
+func (x *DiscoverResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_rates_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiscoverResponse.ProtoReflect.Descriptor instead.
+func (*DiscoverResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_rates_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *DiscoverResponse) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DiscoverResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DiscoverResponse) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *DiscoverResponse) GetLat() float64 {
+	if x != nil {
+		return x.Lat
+	}
+	return 0
+}
+
+func (x *DiscoverResponse) GetLon() float64 {
+	if x != nil {
+		return x.Lon
+	}
+	return 0
+}
+
+func (x *DiscoverResponse) GetBuyRate() string {
+	if x != nil {
+		return x.BuyRate
+	}
+	return ""
+}
+
+func (x *DiscoverResponse) GetSellRate() string {
+	if x != nil {
+		return x.SellRate
+	}
+	return ""
+}
+
+type DeleteCantorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCantorRequest) Reset() {
+	*x = DeleteCantorRequest{}
+	mi := &file_api_proto_v1_rates_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCantorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCantorRequest) ProtoMessage() {}
+
+func (x *DeleteCantorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_rates_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCantorRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCantorRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_rates_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *DeleteCantorRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DeleteCantorResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCantorResponse) Reset() {
+	*x = DeleteCantorResponse{}
+	mi := &file_api_proto_v1_rates_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCantorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCantorResponse) ProtoMessage() {}
+
+func (x *DeleteCantorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_rates_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCantorResponse.ProtoReflect.Descriptor instead.
+func (*DeleteCantorResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_rates_proto_rawDescGZIP(), []int{30}
+}
+
+var File_api_proto_v1_rates_proto protoreflect.FileDescriptor
+
+const file_api_proto_v1_rates_proto_rawDesc = "" +
+	"\n" +
+	"\x18api/proto/v1/rates.proto\x12\x02v1\"5\n" +
+	"\aDecimal\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\x03R\x05value\x12\x14\n" +
+	"\x05scale\x18\x02 \x01(\x05R\x05scale\"\xc6\x02\n" +
+	"\fRateResponse\x12\x18\n" +
+	"\abuyRate\x18\x01 \x01(\tR\abuyRate\x12\x1a\n" +
+	"\bsellRate\x18\x02 \x01(\tR\bsellRate\x12\x1a\n" +
+	"\bcantorId\x18\x03 \x01(\x05R\bcantorID\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\x12\x1c\n" +
+	"\tfetchedAt\x18\x05 \x01(\x03R\tfetchedAt\x12\x1c\n" +
+	"\tchange24h\x18\x06 \x01(\x03R\tchange24h\x12\x1d\n" +
+	"\x03buy\x18\a \x01(\v2\v.v1.DecimalR\x03buy\x12\x1f\n" +
+	"\x04sell\x18\b \x01(\v2\v.v1.DecimalR\x04sell\x12\x14\n" +
+	"\x05stale\x18\t \x01(\bR\x05stale\x12\x1a\n" +
+	"\bsequence\x18\n" +
+	" \x01(\x04R\bsequence\x12\x1a\n" +
+	"\bsnapshot\x18\v \x01(\bR\bsnapshot\"\x8c\x01\n" +
+	"\x06Candle\x12\x1f\n" +
+	"\x04open\x18\x01 \x01(\v2\v.v1.DecimalR\x04open\x12\x1f\n" +
+	"\x04high\x18\x02 \x01(\v2\v.v1.DecimalR\x04high\x12\x1d\n" +
+	"\x03low\x18\x03 \x01(\v2\v.v1.DecimalR\x03low\x12!\n" +
+	"\x05close\x18\x04 \x01(\v2\v.v1.DecimalR\x05close\"\xee\x01\n" +
+	"\fHistoryPoint\x12\x12\n" +
+	"\x04time\x18\x01 \x01(\x03R\x04time\x12\x18\n" +
+	"\abuyRate\x18\x02 \x01(\x03R\abuyRate\x12\x1a\n" +
+	"\bsellRate\x18\x03 \x01(\x03R\bsellRate\x12\x1d\n" +
+	"\x03buy\x18\x04 \x01(\v2\v.v1.DecimalR\x03buy\x12\x1f\n" +
+	"\x04sell\x18\x05 \x01(\v2\v.v1.DecimalR\x04sell\x12(\n" +
+	"\tbuyCandle\x18\x06 \x01(\v2\n" +
+	".v1.CandleR\tbuyCandle\x12*\n" +
+	"\n" +
+	"sellCandle\x18\a \x01(\v2\n" +
+	".v1.CandleR\n" +
+	"sellCandle\"\x85\x01\n" +
+	"\x0fHistoryResponse\x12(\n" +
+	"\x06points\x18\x01 \x03(\v2\x10.v1.HistoryPointR\x06points\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\x12\x1a\n" +
+	"\binterval\x18\x03 \x01(\tR\binterval\x12\x10\n" +
+	"\x03agg\x18\x04 \x01(\tR\x03agg\")\n" +
+	"\vRateRequest\x12\x1a\n" +
+	"\bcurrency\x18\x01 \x01(\tR\bcurrency\"\xb0\x01\n" +
+	"\x14ScrapeCompletedEvent\x12\x1e\n" +
+	"\n" +
+	"providerId\x18\x01 \x01(\tR\n" +
+	"providerID\x12 \n" +
+	"\vscraperType\x18\x02 \x01(\tR\vscraperType\x12\x1e\n" +
+	"\n" +
+	"durationMs\x18\x03 \x01(\x03R\n" +
+	"durationMS\x12\x1c\n" +
+	"\ttimestamp\x18\x04 \x01(\x03R\ttimestamp\x12\x18\n" +
+	"\atraceId\x18\x05 \x01(\tR\atraceID\">\n" +
+	"\x10RateListResponse\x12*\n" +
+	"\aresults\x18\x01 \x03(\v2\x10.v1.RateResponseR\aresults\"\xca\x02\n" +
+	"\x12StreamRatesRequest\x12\x1e\n" +
+	"\n" +
+	"currencies\x18\x01 \x03(\tR\n" +
+	"currencies\x12\x1f\n" +
+	"\vresume_from\x18\x02 \x01(\x04R\n" +
+	"resumeFrom\x12)\n" +
+	"\x10include_snapshot\x18\x03 \x01(\bR\x0fincludeSnapshot\x12\x1d\n" +
+	"\n" +
+	"cantor_ids\x18\x04 \x03(\x05R\tcantorIDs\x12\x10\n" +
+	"\x03lat\x18\x05 \x01(\x01R\x03lat\x12\x10\n" +
+	"\x03lon\x18\x06 \x01(\x01R\x03lon\x12\x1b\n" +
+	"\tradius_km\x18\a \x01(\x01R\bradiusKm\x12#\n" +
+	"\x04bbox\x18\b \x01(\v2\x0f.v1.BoundingBoxR\x04bbox\x12\"\n" +
+	"\rmin_change_bp\x18\t \x01(\x03R\vminChangeBP\x12\x1f\n" +
+	"\vthrottle_ms\x18\n" +
+	" \x01(\x03R\n" +
+	"throttleMS\"q\n" +
+	"\vBoundingBox\x12\x17\n" +
+	"\amin_lat\x18\x01 \x01(\x01R\x06minLat\x12\x17\n" +
+	"\amin_lon\x18\x02 \x01(\x01R\x06minLon\x12\x17\n" +
+	"\amax_lat\x18\x03 \x01(\x01R\x06maxLat\x12\x17\n" +
+	"\amax_lon\x18\x04 \x01(\x01R\x06maxLon\"\xda\x01\n" +
+	"\fQuoteRequest\x12\x1a\n" +
+	"\bcurrency\x18\x01 \x01(\tR\bcurrency\x12#\n" +
+	"\x06amount\x18\x02 \x01(\v2\v.v1.DecimalR\x06amount\x12\x12\n" +
+	"\x04side\x18\x03 \x01(\tR\x04side\x12\x10\n" +
+	"\x03lat\x18\x04 \x01(\x01R\x03lat\x12\x10\n" +
+	"\x03lon\x18\x05 \x01(\x01R\x03lon\x12\x1a\n" +
+	"\bradiusKm\x18\x06 \x01(\x01R\bradiusKm\x125\n" +
+	"\x0fdistancePenalty\x18\a \x01(\v2\v.v1.DecimalR\x0fdistancePenalty\"\x9e\x02\n" +
+	"\x05Quote\x12\x1a\n" +
+	"\bcantorId\x18\x01 \x01(\x05R\bcantorID\x12 \n" +
+	"\vdisplayName\x18\x02 \x01(\tR\vdisplayName\x12\x1f\n" +
+	"\x04rate\x18\x03 \x01(\v2\v.v1.DecimalR\x04rate\x12)\n" +
+	"\tplnAmount\x18\x04 \x01(\v2\v.v1.DecimalR\tplnAmount\x12!\n" +
+	"\x05score\x18\x05 \x01(\v2\v.v1.DecimalR\x05score\x12\x1e\n" +
+	"\n" +
+	"distanceKm\x18\x06 \x01(\x01R\n" +
+	"distanceKm\x12\x1c\n" +
+	"\tfetchedAt\x18\a \x01(\x03R\tfetchedAt\x12\x14\n" +
+	"\x05stale\x18\b \x01(\bR\x05stale\x12\x14\n" +
+	"\x05units\x18\t \x01(\x05R\x05units\"\x87\x01\n" +
+	"\rQuoteResponse\x12\x1a\n" +
+	"\bcurrency\x18\x01 \x01(\tR\bcurrency\x12\x12\n" +
+	"\x04side\x18\x02 \x01(\tR\x04side\x12#\n" +
+	"\x06amount\x18\x03 \x01(\v2\v.v1.DecimalR\x06amount\x12!\n" +
+	"\x06quotes\x18\x04 \x03(\v2\t.v1.QuoteR\x06quotes\"\x90\x02\n" +
+	"\n" +
+	"AlertEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x16\n" +
+	"\x06ruleId\x18\x02 \x01(\x05R\x06ruleID\x12\x14\n" +
+	"\x05owner\x18\x03 \x01(\tR\x05owner\x12\x1a\n" +
+	"\bcantorId\x18\x04 \x01(\x05R\bcantorID\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\x12\x12\n" +
+	"\x04side\x18\x06 \x01(\tR\x04side\x12\x12\n" +
+	"\x04kind\x18\a \x01(\tR\x04kind\x12\x1f\n" +
+	"\x04rate\x18\b \x01(\v2\v.v1.DecimalR\x04rate\x12)\n" +
+	"\treference\x18\t \x01(\v2\v.v1.DecimalR\treference\x12\x18\n" +
+	"\afiredAt\x18\n" +
+	" \x01(\x03R\afiredAt\"E\n" +
+	"\x13StreamAlertsRequest\x12\x14\n" +
+	"\x05owner\x18\x01 \x01(\tR\x05owner\x12\x18\n" +
+	"\aruleIds\x18\x02 \x03(\x05R\aruleIDs\"1\n" +
+	"\x0fSnapshotRequest\x12\x1e\n" +
+	"\n" +
+	"currencies\x18\x01 \x03(\tR\n" +
+	"currencies\"\xc9\x01\n" +
+	"\vSnapshotRow\x12\x1a\n" +
+	"\bcantorId\x18\x01 \x01(\x05R\bcantorID\x12 \n" +
+	"\vdisplayName\x18\x02 \x01(\tR\vdisplayName\x120\n" +
+	"\x05rates\x18\x03 \x03(\v2\x1a.v1.SnapshotRow.RatesEntryR\x05rates\x1aJ\n" +
+	"\n" +
+	"RatesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12&\n" +
+	"\x05value\x18\x02 \x01(\v2\x10.v1.RateResponseR\x05value:\x028\x01\"\x7f\n" +
+	"\x10SnapshotResponse\x12 \n" +
+	"\vgeneratedAt\x18\x01 \x01(\x03R\vgeneratedAt\x12\x1e\n" +
+	"\n" +
+	"currencies\x18\x02 \x03(\tR\n" +
+	"currencies\x12)\n" +
+	"\acantors\x18\x03 \x03(\v2\x0f.v1.SnapshotRowR\acantors\"q\n" +
+	"\vStreamEvent\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\x12$\n" +
+	"\x04rate\x18\x03 \x01(\v2\x10.v1.RateResponseR\x04rate\x12\x12\n" +
+	"\x04time\x18\x04 \x01(\x03R\x04time\".\n" +
+	"\bGeoPoint\x12\x10\n" +
+	"\x03lat\x18\x01 \x01(\x01R\x03lat\x12\x10\n" +
+	"\x03lon\x18\x02 \x01(\x01R\x03lon\"\xf6\x01\n" +
+	"\x12ListCantorsRequest\x12 \n" +
+	"\x04near\x18\x01 \x01(\v2\f.v1.GeoPointR\x04near\x12\x1b\n" +
+	"\tradius_km\x18\x02 \x01(\x01R\bradiusKm\x12#\n" +
+	"\x04bbox\x18\x03 \x01(\v2\x0f.v1.BoundingBoxR\x04bbox\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\x12\f\n" +
+	"\x01q\x18\x05 \x01(\tR\x01q\x12\x12\n" +
+	"\x04sort\x18\x06 \x01(\tR\x04sort\x12\x14\n" +
+	"\x05limit\x18\a \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\b \x01(\tR\x06cursor\x12\x10\n" +
+	"\x03all\x18\t \x01(\bR\x03all\"\xd2\x02\n" +
+	"\x06Cantor\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12!\n" +
+	"\fdisplay_name\x18\x02 \x01(\tR\vdisplayName\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1a\n" +
+	"\blatitude\x18\x04 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x05 \x01(\x01R\tlongitude\x12\x1a\n" +
+	"\bstrategy\x18\x06 \x01(\tR\bstrategy\x12\x18\n" +
+	"\aaddress\x18\a \x01(\tR\aaddress\x12\x16\n" +
+	"\x06origin\x18\b \x01(\tR\x06origin\x12\x18\n" +
+	"\aenabled\x18\t \x01(\bR\aenabled\x12\x1f\n" +
+	"\vdistance_km\x18\n" +
+	" \x01(\x01R\n" +
+	"distanceKm\x12\x1d\n" +
+	"\x03buy\x18\v \x01(\v2\v.v1.DecimalR\x03buy\x12\x1f\n" +
+	"\x04sell\x18\f \x01(\v2\v.v1.DecimalR\x04sell\"\\\n" +
+	"\x13ListCantorsResponse\x12$\n" +
+	"\acantors\x18\x01 \x03(\v2\n" +
+	".v1.CantorR\acantors\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"\x8b\x01\n" +
+	"\x0eHistoryRequest\x12\x1a\n" +
+	"\bcurrency\x18\x01 \x01(\tR\bcurrency\x12\x1b\n" +
+	"\tcantor_id\x18\x02 \x01(\x05R\bcantorID\x12\x12\n" +
+	"\x04days\x18\x03 \x01(\x05R\x04days\x12\x1a\n" +
+	"\binterval\x18\x04 \x01(\tR\binterval\x12\x10\n" +
+	"\x03agg\x18\x05 \x01(\tR\x03agg\"\x15\n" +
+	"\x13FinOpsStatusRequest\"a\n" +
+	"\tFinOpsTip\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x1c\n" +
+	"\tpotential\x18\x03 \x01(\x01R\tpotential\"\x91\x04\n" +
+	"\fFinOpsStatus\x12#\n" +
+	"\rtotal_scrapes\x18\x01 \x01(\x03R\ftotalScrapes\x12!\n" +
+	"\favg_duration\x18\x02 \x01(\tR\vavgDuration\x12'\n" +
+	"\x0fexpensive_tasks\x18\x03 \x03(\tR\x0eexpensiveTasks\x12+\n" +
+	"\x12real_spend_24h_usd\x18\x04 \x01(\tR\x0frealSpend24hUSD\x12&\n" +
+	"\x0einfrastructure\x18\x05 \x01(\tR\x0einfrastructure\x12!\n" +
+	"\x04tips\x18\x06 \x03(\v2\r.v1.FinOpsTipR\x04tips\x12+\n" +
+	"\x11blocked_providers\x18\a \x03(\tR\x10blockedProviders\x120\n" +
+	"\x14is_governance_active\x18\b \x01(\bR\x12isGovernanceActive\x12\x1f\n" +
+	"\vsystem_time\x18\t \x01(\tR\n" +
+	"systemTime\x12S\n" +
+	"\x11service_breakdown\x18\n" +
+	" \x03(\v2&.v1.FinOpsStatus.ServiceBreakdownEntryR\x10serviceBreakdown\x1aC\n" +
+	"\x15ServiceBreakdownEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xb9\x01\n" +
+	"\x0fDiscoverRequest\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12#\n" +
+	"\rexpected_name\x18\x02 \x01(\tR\fexpectedName\x12!\n" +
+	"\fexpected_lat\x18\x03 \x01(\x01R\vexpectedLat\x12!\n" +
+	"\fexpected_lon\x18\x04 \x01(\x01R\vexpectedLon\x12)\n" +
+	"\x10expected_address\x18\x05 \x01(\tR\x0fexpectedAddress\"\xac\x01\n" +
+	"\x10DiscoverResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\aaddress\x18\x03 \x01(\tR\aaddress\x12\x10\n" +
+	"\x03lat\x18\x04 \x01(\x01R\x03lat\x12\x10\n" +
+	"\x03lon\x18\x05 \x01(\x01R\x03lon\x12\x19\n" +
+	"\bbuy_rate\x18\x06 \x01(\tR\abuyRate\x12\x1b\n" +
+	"\tsell_rate\x18\a \x01(\tR\bsellRate\"%\n" +
+	"\x13DeleteCantorRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"\x16\n" +
+	"\x14DeleteCantorResponse2\xd4\x04\n" +
+	"\fRatesService\x129\n" +
+	"\vStreamRates\x12\x16.v1.StreamRatesRequest\x1a\x10.v1.RateResponse0\x01\x124\n" +
+	"\vGetAllRates\x12\x0f.v1.RateRequest\x1a\x14.v1.RateListResponse\x12/\n" +
+	"\bGetQuote\x12\x10.v1.QuoteRequest\x1a\x11.v1.QuoteResponse\x129\n" +
+	"\fStreamAlerts\x12\x17.v1.StreamAlertsRequest\x1a\x0e.v1.AlertEvent0\x01\x128\n" +
+	"\vGetSnapshot\x12\x13.v1.SnapshotRequest\x1a\x14.v1.SnapshotResponse\x12>\n" +
+	"\vListCantors\x12\x16.v1.ListCantorsRequest\x1a\x17.v1.ListCantorsResponse\x125\n" +
+	"\n" +
+	"GetHistory\x12\x12.v1.HistoryRequest\x1a\x13.v1.HistoryResponse\x12<\n" +
+	"\x0fGetFinOpsStatus\x12\x17.v1.FinOpsStatusRequest\x1a\x10.v1.FinOpsStatus\x125\n" +
+	"\bDiscover\x12\x13.v1.DiscoverRequest\x1a\x14.v1.DiscoverResponse\x12A\n" +
+	"\fDeleteCantor\x12\x17.v1.DeleteCantorRequest\x1a\x18.v1.DeleteCantorResponseB$Z\"github.com/Niutaq/Gix/api/proto/v1b\x06proto3"
 
 var (
 	file_api_proto_v1_rates_proto_rawDescOnce sync.Once
@@ -478,28 +2629,101 @@ func file_api_proto_v1_rates_proto_rawDescGZIP() []byte {
 	return file_api_proto_v1_rates_proto_rawDescData
 }
 
-var file_api_proto_v1_rates_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_api_proto_v1_rates_proto_msgTypes = make([]protoimpl.MessageInfo, 33)
 var file_api_proto_v1_rates_proto_goTypes = []any{
-	(*RateResponse)(nil),         // 0: v1.RateResponse
-	(*HistoryPoint)(nil),         // 1: v1.HistoryPoint
-	(*HistoryResponse)(nil),      // 2: v1.HistoryResponse
-	(*RateRequest)(nil),          // 3: v1.RateRequest
-	(*ScrapeCompletedEvent)(nil), // 4: v1.ScrapeCompletedEvent
-	(*RateListResponse)(nil),     // 5: v1.RateListResponse
-	(*StreamRatesRequest)(nil),   // 6: v1.StreamRatesRequest
+	(*Decimal)(nil),              // 0: v1.Decimal
+	(*RateResponse)(nil),         // 1: v1.RateResponse
+	(*Candle)(nil),               // 2: v1.Candle
+	(*HistoryPoint)(nil),         // 3: v1.HistoryPoint
+	(*HistoryResponse)(nil),      // 4: v1.HistoryResponse
+	(*RateRequest)(nil),          // 5: v1.RateRequest
+	(*ScrapeCompletedEvent)(nil), // 6: v1.ScrapeCompletedEvent
+	(*RateListResponse)(nil),     // 7: v1.RateListResponse
+	(*StreamRatesRequest)(nil),   // 8: v1.StreamRatesRequest
+	(*BoundingBox)(nil),          // 9: v1.BoundingBox
+	(*QuoteRequest)(nil),         // 10: v1.QuoteRequest
+	(*Quote)(nil),                // 11: v1.Quote
+	(*QuoteResponse)(nil),        // 12: v1.QuoteResponse
+	(*AlertEvent)(nil),           // 13: v1.AlertEvent
+	(*StreamAlertsRequest)(nil),  // 14: v1.StreamAlertsRequest
+	(*SnapshotRequest)(nil),      // 15: v1.SnapshotRequest
+	(*SnapshotRow)(nil),          // 16: v1.SnapshotRow
+	(*SnapshotResponse)(nil),     // 17: v1.SnapshotResponse
+	(*StreamEvent)(nil),          // 18: v1.StreamEvent
+	(*GeoPoint)(nil),             // 19: v1.GeoPoint
+	(*ListCantorsRequest)(nil),   // 20: v1.ListCantorsRequest
+	(*Cantor)(nil),               // 21: v1.Cantor
+	(*ListCantorsResponse)(nil),  // 22: v1.ListCantorsResponse
+	(*HistoryRequest)(nil),       // 23: v1.HistoryRequest
+	(*FinOpsStatusRequest)(nil),  // 24: v1.FinOpsStatusRequest
+	(*FinOpsTip)(nil),            // 25: v1.FinOpsTip
+	(*FinOpsStatus)(nil),         // 26: v1.FinOpsStatus
+	(*DiscoverRequest)(nil),      // 27: v1.DiscoverRequest
+	(*DiscoverResponse)(nil),     // 28: v1.DiscoverResponse
+	(*DeleteCantorRequest)(nil),  // 29: v1.DeleteCantorRequest
+	(*DeleteCantorResponse)(nil), // 30: v1.DeleteCantorResponse
+	nil,                          // 31: v1.SnapshotRow.RatesEntry
+	nil,                          // 32: v1.FinOpsStatus.ServiceBreakdownEntry
 }
 var file_api_proto_v1_rates_proto_depIdxs = []int32{
-	1, // 0: v1.HistoryResponse.points:type_name -> v1.HistoryPoint
-	0, // 1: v1.RateListResponse.results:type_name -> v1.RateResponse
-	6, // 2: v1.RatesService.StreamRates:input_type -> v1.StreamRatesRequest
-	3, // 3: v1.RatesService.GetAllRates:input_type -> v1.RateRequest
-	0, // 4: v1.RatesService.StreamRates:output_type -> v1.RateResponse
-	5, // 5: v1.RatesService.GetAllRates:output_type -> v1.RateListResponse
-	4, // [4:6] is the sub-list for method output_type
-	2, // [2:4] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	0,  // 0: v1.RateResponse.buy:type_name -> v1.Decimal
+	0,  // 1: v1.RateResponse.sell:type_name -> v1.Decimal
+	0,  // 2: v1.Candle.open:type_name -> v1.Decimal
+	0,  // 3: v1.Candle.high:type_name -> v1.Decimal
+	0,  // 4: v1.Candle.low:type_name -> v1.Decimal
+	0,  // 5: v1.Candle.close:type_name -> v1.Decimal
+	0,  // 6: v1.HistoryPoint.buy:type_name -> v1.Decimal
+	0,  // 7: v1.HistoryPoint.sell:type_name -> v1.Decimal
+	2,  // 8: v1.HistoryPoint.buyCandle:type_name -> v1.Candle
+	2,  // 9: v1.HistoryPoint.sellCandle:type_name -> v1.Candle
+	3,  // 10: v1.HistoryResponse.points:type_name -> v1.HistoryPoint
+	1,  // 11: v1.RateListResponse.results:type_name -> v1.RateResponse
+	9,  // 12: v1.StreamRatesRequest.bbox:type_name -> v1.BoundingBox
+	0,  // 13: v1.QuoteRequest.amount:type_name -> v1.Decimal
+	0,  // 14: v1.QuoteRequest.distancePenalty:type_name -> v1.Decimal
+	0,  // 15: v1.Quote.rate:type_name -> v1.Decimal
+	0,  // 16: v1.Quote.plnAmount:type_name -> v1.Decimal
+	0,  // 17: v1.Quote.score:type_name -> v1.Decimal
+	0,  // 18: v1.QuoteResponse.amount:type_name -> v1.Decimal
+	11, // 19: v1.QuoteResponse.quotes:type_name -> v1.Quote
+	0,  // 20: v1.AlertEvent.rate:type_name -> v1.Decimal
+	0,  // 21: v1.AlertEvent.reference:type_name -> v1.Decimal
+	31, // 22: v1.SnapshotRow.rates:type_name -> v1.SnapshotRow.RatesEntry
+	16, // 23: v1.SnapshotResponse.cantors:type_name -> v1.SnapshotRow
+	1,  // 24: v1.StreamEvent.rate:type_name -> v1.RateResponse
+	19, // 25: v1.ListCantorsRequest.near:type_name -> v1.GeoPoint
+	9,  // 26: v1.ListCantorsRequest.bbox:type_name -> v1.BoundingBox
+	0,  // 27: v1.Cantor.buy:type_name -> v1.Decimal
+	0,  // 28: v1.Cantor.sell:type_name -> v1.Decimal
+	21, // 29: v1.ListCantorsResponse.cantors:type_name -> v1.Cantor
+	25, // 30: v1.FinOpsStatus.tips:type_name -> v1.FinOpsTip
+	32, // 31: v1.FinOpsStatus.service_breakdown:type_name -> v1.FinOpsStatus.ServiceBreakdownEntry
+	1,  // 32: v1.SnapshotRow.RatesEntry.value:type_name -> v1.RateResponse
+	8,  // 33: v1.RatesService.StreamRates:input_type -> v1.StreamRatesRequest
+	5,  // 34: v1.RatesService.GetAllRates:input_type -> v1.RateRequest
+	10, // 35: v1.RatesService.GetQuote:input_type -> v1.QuoteRequest
+	14, // 36: v1.RatesService.StreamAlerts:input_type -> v1.StreamAlertsRequest
+	15, // 37: v1.RatesService.GetSnapshot:input_type -> v1.SnapshotRequest
+	20, // 38: v1.RatesService.ListCantors:input_type -> v1.ListCantorsRequest
+	23, // 39: v1.RatesService.GetHistory:input_type -> v1.HistoryRequest
+	24, // 40: v1.RatesService.GetFinOpsStatus:input_type -> v1.FinOpsStatusRequest
+	27, // 41: v1.RatesService.Discover:input_type -> v1.DiscoverRequest
+	29, // 42: v1.RatesService.DeleteCantor:input_type -> v1.DeleteCantorRequest
+	1,  // 43: v1.RatesService.StreamRates:output_type -> v1.RateResponse
+	7,  // 44: v1.RatesService.GetAllRates:output_type -> v1.RateListResponse
+	12, // 45: v1.RatesService.GetQuote:output_type -> v1.QuoteResponse
+	13, // 46: v1.RatesService.StreamAlerts:output_type -> v1.AlertEvent
+	17, // 47: v1.RatesService.GetSnapshot:output_type -> v1.SnapshotResponse
+	22, // 48: v1.RatesService.ListCantors:output_type -> v1.ListCantorsResponse
+	4,  // 49: v1.RatesService.GetHistory:output_type -> v1.HistoryResponse
+	26, // 50: v1.RatesService.GetFinOpsStatus:output_type -> v1.FinOpsStatus
+	28, // 51: v1.RatesService.Discover:output_type -> v1.DiscoverResponse
+	30, // 52: v1.RatesService.DeleteCantor:output_type -> v1.DeleteCantorResponse
+	43, // [43:53] is the sub-list for method output_type
+	33, // [33:43] is the sub-list for method input_type
+	33, // [33:33] is the sub-list for extension type_name
+	33, // [33:33] is the sub-list for extension extendee
+	0,  // [0:33] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_v1_rates_proto_init() }
@@ -513,7 +2737,7 @@ func file_api_proto_v1_rates_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_v1_rates_proto_rawDesc), len(file_api_proto_v1_rates_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   33,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
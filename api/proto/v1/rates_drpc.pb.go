@@ -40,6 +40,14 @@ type DRPCRatesServiceClient interface {
 
 	StreamRates(ctx context.Context, in *StreamRatesRequest) (DRPCRatesService_StreamRatesClient, error)
 	GetAllRates(ctx context.Context, in *RateRequest) (*RateListResponse, error)
+	GetQuote(ctx context.Context, in *QuoteRequest) (*QuoteResponse, error)
+	StreamAlerts(ctx context.Context, in *StreamAlertsRequest) (DRPCRatesService_StreamAlertsClient, error)
+	GetSnapshot(ctx context.Context, in *SnapshotRequest) (*SnapshotResponse, error)
+	ListCantors(ctx context.Context, in *ListCantorsRequest) (*ListCantorsResponse, error)
+	GetHistory(ctx context.Context, in *HistoryRequest) (*HistoryResponse, error)
+	GetFinOpsStatus(ctx context.Context, in *FinOpsStatusRequest) (*FinOpsStatus, error)
+	Discover(ctx context.Context, in *DiscoverRequest) (*DiscoverResponse, error)
+	DeleteCantor(ctx context.Context, in *DeleteCantorRequest) (*DeleteCantorResponse, error)
 }
 
 type drpcRatesServiceClient struct {
@@ -101,9 +109,120 @@ func (c *drpcRatesServiceClient) GetAllRates(ctx context.Context, in *RateReques
 	return out, nil
 }
 
+func (c *drpcRatesServiceClient) GetQuote(ctx context.Context, in *QuoteRequest) (*QuoteResponse, error) {
+	out := new(QuoteResponse)
+	err := c.cc.Invoke(ctx, "/v1.RatesService/GetQuote", drpcEncoding_File_api_proto_v1_rates_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcRatesServiceClient) StreamAlerts(ctx context.Context, in *StreamAlertsRequest) (DRPCRatesService_StreamAlertsClient, error) {
+	stream, err := c.cc.NewStream(ctx, "/v1.RatesService/StreamAlerts", drpcEncoding_File_api_proto_v1_rates_proto{})
+	if err != nil {
+		return nil, err
+	}
+	x := &drpcRatesService_StreamAlertsClient{stream}
+	if err := x.MsgSend(in, drpcEncoding_File_api_proto_v1_rates_proto{}); err != nil {
+		return nil, err
+	}
+	if err := x.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type DRPCRatesService_StreamAlertsClient interface {
+	drpc.Stream
+	Recv() (*AlertEvent, error)
+}
+
+type drpcRatesService_StreamAlertsClient struct {
+	drpc.Stream
+}
+
+func (x *drpcRatesService_StreamAlertsClient) GetStream() drpc.Stream {
+	return x.Stream
+}
+
+func (x *drpcRatesService_StreamAlertsClient) Recv() (*AlertEvent, error) {
+	m := new(AlertEvent)
+	if err := x.MsgRecv(m, drpcEncoding_File_api_proto_v1_rates_proto{}); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (x *drpcRatesService_StreamAlertsClient) RecvMsg(m *AlertEvent) error {
+	return x.MsgRecv(m, drpcEncoding_File_api_proto_v1_rates_proto{})
+}
+
+func (c *drpcRatesServiceClient) GetSnapshot(ctx context.Context, in *SnapshotRequest) (*SnapshotResponse, error) {
+	out := new(SnapshotResponse)
+	err := c.cc.Invoke(ctx, "/v1.RatesService/GetSnapshot", drpcEncoding_File_api_proto_v1_rates_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcRatesServiceClient) ListCantors(ctx context.Context, in *ListCantorsRequest) (*ListCantorsResponse, error) {
+	out := new(ListCantorsResponse)
+	err := c.cc.Invoke(ctx, "/v1.RatesService/ListCantors", drpcEncoding_File_api_proto_v1_rates_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcRatesServiceClient) GetHistory(ctx context.Context, in *HistoryRequest) (*HistoryResponse, error) {
+	out := new(HistoryResponse)
+	err := c.cc.Invoke(ctx, "/v1.RatesService/GetHistory", drpcEncoding_File_api_proto_v1_rates_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcRatesServiceClient) GetFinOpsStatus(ctx context.Context, in *FinOpsStatusRequest) (*FinOpsStatus, error) {
+	out := new(FinOpsStatus)
+	err := c.cc.Invoke(ctx, "/v1.RatesService/GetFinOpsStatus", drpcEncoding_File_api_proto_v1_rates_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcRatesServiceClient) Discover(ctx context.Context, in *DiscoverRequest) (*DiscoverResponse, error) {
+	out := new(DiscoverResponse)
+	err := c.cc.Invoke(ctx, "/v1.RatesService/Discover", drpcEncoding_File_api_proto_v1_rates_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcRatesServiceClient) DeleteCantor(ctx context.Context, in *DeleteCantorRequest) (*DeleteCantorResponse, error) {
+	out := new(DeleteCantorResponse)
+	err := c.cc.Invoke(ctx, "/v1.RatesService/DeleteCantor", drpcEncoding_File_api_proto_v1_rates_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 type DRPCRatesServiceServer interface {
 	StreamRates(*StreamRatesRequest, DRPCRatesService_StreamRatesStream) error
 	GetAllRates(context.Context, *RateRequest) (*RateListResponse, error)
+	GetQuote(context.Context, *QuoteRequest) (*QuoteResponse, error)
+	StreamAlerts(*StreamAlertsRequest, DRPCRatesService_StreamAlertsStream) error
+	GetSnapshot(context.Context, *SnapshotRequest) (*SnapshotResponse, error)
+	ListCantors(context.Context, *ListCantorsRequest) (*ListCantorsResponse, error)
+	GetHistory(context.Context, *HistoryRequest) (*HistoryResponse, error)
+	GetFinOpsStatus(context.Context, *FinOpsStatusRequest) (*FinOpsStatus, error)
+	Discover(context.Context, *DiscoverRequest) (*DiscoverResponse, error)
+	DeleteCantor(context.Context, *DeleteCantorRequest) (*DeleteCantorResponse, error)
 }
 
 type DRPCRatesServiceUnimplementedServer struct{}
@@ -116,9 +235,41 @@ func (s *DRPCRatesServiceUnimplementedServer) GetAllRates(context.Context, *Rate
 	return nil, drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
 }
 
+func (s *DRPCRatesServiceUnimplementedServer) GetQuote(context.Context, *QuoteRequest) (*QuoteResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
+}
+
+func (s *DRPCRatesServiceUnimplementedServer) StreamAlerts(*StreamAlertsRequest, DRPCRatesService_StreamAlertsStream) error {
+	return drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
+}
+
+func (s *DRPCRatesServiceUnimplementedServer) GetSnapshot(context.Context, *SnapshotRequest) (*SnapshotResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
+}
+
+func (s *DRPCRatesServiceUnimplementedServer) ListCantors(context.Context, *ListCantorsRequest) (*ListCantorsResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
+}
+
+func (s *DRPCRatesServiceUnimplementedServer) GetHistory(context.Context, *HistoryRequest) (*HistoryResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
+}
+
+func (s *DRPCRatesServiceUnimplementedServer) GetFinOpsStatus(context.Context, *FinOpsStatusRequest) (*FinOpsStatus, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
+}
+
+func (s *DRPCRatesServiceUnimplementedServer) Discover(context.Context, *DiscoverRequest) (*DiscoverResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
+}
+
+func (s *DRPCRatesServiceUnimplementedServer) DeleteCantor(context.Context, *DeleteCantorRequest) (*DeleteCantorResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
+}
+
 type DRPCRatesServiceDescription struct{}
 
-func (DRPCRatesServiceDescription) NumMethods() int { return 2 }
+func (DRPCRatesServiceDescription) NumMethods() int { return 10 }
 
 func (DRPCRatesServiceDescription) Method(n int) (string, drpc.Encoding, drpc.Receiver, interface{}, bool) {
 	switch n {
@@ -140,6 +291,78 @@ func (DRPCRatesServiceDescription) Method(n int) (string, drpc.Encoding, drpc.Re
 						in1.(*RateRequest),
 					)
 			}, DRPCRatesServiceServer.GetAllRates, true
+	case 2:
+		return "/v1.RatesService/GetQuote", drpcEncoding_File_api_proto_v1_rates_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCRatesServiceServer).
+					GetQuote(
+						ctx,
+						in1.(*QuoteRequest),
+					)
+			}, DRPCRatesServiceServer.GetQuote, true
+	case 3:
+		return "/v1.RatesService/StreamAlerts", drpcEncoding_File_api_proto_v1_rates_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return nil, srv.(DRPCRatesServiceServer).
+					StreamAlerts(
+						in1.(*StreamAlertsRequest),
+						&drpcRatesService_StreamAlertsStream{in2.(drpc.Stream)},
+					)
+			}, DRPCRatesServiceServer.StreamAlerts, true
+	case 4:
+		return "/v1.RatesService/GetSnapshot", drpcEncoding_File_api_proto_v1_rates_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCRatesServiceServer).
+					GetSnapshot(
+						ctx,
+						in1.(*SnapshotRequest),
+					)
+			}, DRPCRatesServiceServer.GetSnapshot, true
+	case 5:
+		return "/v1.RatesService/ListCantors", drpcEncoding_File_api_proto_v1_rates_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCRatesServiceServer).
+					ListCantors(
+						ctx,
+						in1.(*ListCantorsRequest),
+					)
+			}, DRPCRatesServiceServer.ListCantors, true
+	case 6:
+		return "/v1.RatesService/GetHistory", drpcEncoding_File_api_proto_v1_rates_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCRatesServiceServer).
+					GetHistory(
+						ctx,
+						in1.(*HistoryRequest),
+					)
+			}, DRPCRatesServiceServer.GetHistory, true
+	case 7:
+		return "/v1.RatesService/GetFinOpsStatus", drpcEncoding_File_api_proto_v1_rates_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCRatesServiceServer).
+					GetFinOpsStatus(
+						ctx,
+						in1.(*FinOpsStatusRequest),
+					)
+			}, DRPCRatesServiceServer.GetFinOpsStatus, true
+	case 8:
+		return "/v1.RatesService/Discover", drpcEncoding_File_api_proto_v1_rates_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCRatesServiceServer).
+					Discover(
+						ctx,
+						in1.(*DiscoverRequest),
+					)
+			}, DRPCRatesServiceServer.Discover, true
+	case 9:
+		return "/v1.RatesService/DeleteCantor", drpcEncoding_File_api_proto_v1_rates_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCRatesServiceServer).
+					DeleteCantor(
+						ctx,
+						in1.(*DeleteCantorRequest),
+					)
+			}, DRPCRatesServiceServer.DeleteCantor, true
 	default:
 		return "", nil, nil, nil, false
 	}
@@ -177,3 +400,128 @@ func (x *drpcRatesService_GetAllRatesStream) SendAndClose(m *RateListResponse) e
 	}
 	return x.CloseSend()
 }
+
+type DRPCRatesService_GetQuoteStream interface {
+	drpc.Stream
+	SendAndClose(*QuoteResponse) error
+}
+
+type drpcRatesService_GetQuoteStream struct {
+	drpc.Stream
+}
+
+func (x *drpcRatesService_GetQuoteStream) SendAndClose(m *QuoteResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_api_proto_v1_rates_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCRatesService_StreamAlertsStream interface {
+	drpc.Stream
+	Send(*AlertEvent) error
+}
+
+type drpcRatesService_StreamAlertsStream struct {
+	drpc.Stream
+}
+
+func (x *drpcRatesService_StreamAlertsStream) Send(m *AlertEvent) error {
+	return x.MsgSend(m, drpcEncoding_File_api_proto_v1_rates_proto{})
+}
+
+type DRPCRatesService_GetSnapshotStream interface {
+	drpc.Stream
+	SendAndClose(*SnapshotResponse) error
+}
+
+type drpcRatesService_GetSnapshotStream struct {
+	drpc.Stream
+}
+
+func (x *drpcRatesService_GetSnapshotStream) SendAndClose(m *SnapshotResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_api_proto_v1_rates_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCRatesService_ListCantorsStream interface {
+	drpc.Stream
+	SendAndClose(*ListCantorsResponse) error
+}
+
+type drpcRatesService_ListCantorsStream struct {
+	drpc.Stream
+}
+
+func (x *drpcRatesService_ListCantorsStream) SendAndClose(m *ListCantorsResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_api_proto_v1_rates_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCRatesService_GetHistoryStream interface {
+	drpc.Stream
+	SendAndClose(*HistoryResponse) error
+}
+
+type drpcRatesService_GetHistoryStream struct {
+	drpc.Stream
+}
+
+func (x *drpcRatesService_GetHistoryStream) SendAndClose(m *HistoryResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_api_proto_v1_rates_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCRatesService_GetFinOpsStatusStream interface {
+	drpc.Stream
+	SendAndClose(*FinOpsStatus) error
+}
+
+type drpcRatesService_GetFinOpsStatusStream struct {
+	drpc.Stream
+}
+
+func (x *drpcRatesService_GetFinOpsStatusStream) SendAndClose(m *FinOpsStatus) error {
+	if err := x.MsgSend(m, drpcEncoding_File_api_proto_v1_rates_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCRatesService_DiscoverStream interface {
+	drpc.Stream
+	SendAndClose(*DiscoverResponse) error
+}
+
+type drpcRatesService_DiscoverStream struct {
+	drpc.Stream
+}
+
+func (x *drpcRatesService_DiscoverStream) SendAndClose(m *DiscoverResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_api_proto_v1_rates_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCRatesService_DeleteCantorStream interface {
+	drpc.Stream
+	SendAndClose(*DeleteCantorResponse) error
+}
+
+type drpcRatesService_DeleteCantorStream struct {
+	drpc.Stream
+}
+
+func (x *drpcRatesService_DeleteCantorStream) SendAndClose(m *DeleteCantorResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_api_proto_v1_rates_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
@@ -19,7 +19,16 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	RatesService_StreamRates_FullMethodName = "/v1.RatesService/StreamRates"
+	RatesService_StreamRates_FullMethodName     = "/v1.RatesService/StreamRates"
+	RatesService_GetAllRates_FullMethodName     = "/v1.RatesService/GetAllRates"
+	RatesService_GetQuote_FullMethodName        = "/v1.RatesService/GetQuote"
+	RatesService_StreamAlerts_FullMethodName    = "/v1.RatesService/StreamAlerts"
+	RatesService_GetSnapshot_FullMethodName     = "/v1.RatesService/GetSnapshot"
+	RatesService_ListCantors_FullMethodName     = "/v1.RatesService/ListCantors"
+	RatesService_GetHistory_FullMethodName      = "/v1.RatesService/GetHistory"
+	RatesService_GetFinOpsStatus_FullMethodName = "/v1.RatesService/GetFinOpsStatus"
+	RatesService_Discover_FullMethodName        = "/v1.RatesService/Discover"
+	RatesService_DeleteCantor_FullMethodName    = "/v1.RatesService/DeleteCantor"
 )
 
 // RatesServiceClient is the client API for RatesService service.
@@ -27,6 +36,16 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type RatesServiceClient interface {
 	StreamRates(ctx context.Context, in *StreamRatesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RateResponse], error)
+	GetAllRates(ctx context.Context, in *RateRequest, opts ...grpc.CallOption) (*RateListResponse, error)
+	GetQuote(ctx context.Context, in *QuoteRequest, opts ...grpc.CallOption) (*QuoteResponse, error)
+	StreamAlerts(ctx context.Context, in *StreamAlertsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AlertEvent], error)
+	GetSnapshot(ctx context.Context, in *SnapshotRequest, opts ...grpc.CallOption) (*SnapshotResponse, error)
+	ListCantors(ctx context.Context, in *ListCantorsRequest, opts ...grpc.CallOption) (*ListCantorsResponse, error)
+	GetHistory(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (*HistoryResponse, error)
+	GetFinOpsStatus(ctx context.Context, in *FinOpsStatusRequest, opts ...grpc.CallOption) (*FinOpsStatus, error)
+	// Discover requires the operator role and DeleteCantor the admin role.
+	Discover(ctx context.Context, in *DiscoverRequest, opts ...grpc.CallOption) (*DiscoverResponse, error)
+	DeleteCantor(ctx context.Context, in *DeleteCantorRequest, opts ...grpc.CallOption) (*DeleteCantorResponse, error)
 }
 
 type ratesServiceClient struct {
@@ -56,11 +75,120 @@ func (c *ratesServiceClient) StreamRates(ctx context.Context, in *StreamRatesReq
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type RatesService_StreamRatesClient = grpc.ServerStreamingClient[RateResponse]
 
+func (c *ratesServiceClient) GetAllRates(ctx context.Context, in *RateRequest, opts ...grpc.CallOption) (*RateListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RateListResponse)
+	err := c.cc.Invoke(ctx, RatesService_GetAllRates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ratesServiceClient) GetQuote(ctx context.Context, in *QuoteRequest, opts ...grpc.CallOption) (*QuoteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QuoteResponse)
+	err := c.cc.Invoke(ctx, RatesService_GetQuote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ratesServiceClient) StreamAlerts(ctx context.Context, in *StreamAlertsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AlertEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RatesService_ServiceDesc.Streams[1], RatesService_StreamAlerts_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamAlertsRequest, AlertEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RatesService_StreamAlertsClient = grpc.ServerStreamingClient[AlertEvent]
+
+func (c *ratesServiceClient) GetSnapshot(ctx context.Context, in *SnapshotRequest, opts ...grpc.CallOption) (*SnapshotResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SnapshotResponse)
+	err := c.cc.Invoke(ctx, RatesService_GetSnapshot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ratesServiceClient) ListCantors(ctx context.Context, in *ListCantorsRequest, opts ...grpc.CallOption) (*ListCantorsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCantorsResponse)
+	err := c.cc.Invoke(ctx, RatesService_ListCantors_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ratesServiceClient) GetHistory(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (*HistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HistoryResponse)
+	err := c.cc.Invoke(ctx, RatesService_GetHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ratesServiceClient) GetFinOpsStatus(ctx context.Context, in *FinOpsStatusRequest, opts ...grpc.CallOption) (*FinOpsStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FinOpsStatus)
+	err := c.cc.Invoke(ctx, RatesService_GetFinOpsStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ratesServiceClient) Discover(ctx context.Context, in *DiscoverRequest, opts ...grpc.CallOption) (*DiscoverResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DiscoverResponse)
+	err := c.cc.Invoke(ctx, RatesService_Discover_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ratesServiceClient) DeleteCantor(ctx context.Context, in *DeleteCantorRequest, opts ...grpc.CallOption) (*DeleteCantorResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteCantorResponse)
+	err := c.cc.Invoke(ctx, RatesService_DeleteCantor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RatesServiceServer is the server API for RatesService service.
 // All implementations must embed UnimplementedRatesServiceServer
 // for forward compatibility.
 type RatesServiceServer interface {
 	StreamRates(*StreamRatesRequest, grpc.ServerStreamingServer[RateResponse]) error
+	GetAllRates(context.Context, *RateRequest) (*RateListResponse, error)
+	GetQuote(context.Context, *QuoteRequest) (*QuoteResponse, error)
+	StreamAlerts(*StreamAlertsRequest, grpc.ServerStreamingServer[AlertEvent]) error
+	GetSnapshot(context.Context, *SnapshotRequest) (*SnapshotResponse, error)
+	ListCantors(context.Context, *ListCantorsRequest) (*ListCantorsResponse, error)
+	GetHistory(context.Context, *HistoryRequest) (*HistoryResponse, error)
+	GetFinOpsStatus(context.Context, *FinOpsStatusRequest) (*FinOpsStatus, error)
+	// Discover requires the operator role and DeleteCantor the admin role.
+	Discover(context.Context, *DiscoverRequest) (*DiscoverResponse, error)
+	DeleteCantor(context.Context, *DeleteCantorRequest) (*DeleteCantorResponse, error)
 	mustEmbedUnimplementedRatesServiceServer()
 }
 
@@ -74,6 +202,33 @@ type UnimplementedRatesServiceServer struct{}
 func (UnimplementedRatesServiceServer) StreamRates(*StreamRatesRequest, grpc.ServerStreamingServer[RateResponse]) error {
 	return status.Error(codes.Unimplemented, "method StreamRates not implemented")
 }
+func (UnimplementedRatesServiceServer) GetAllRates(context.Context, *RateRequest) (*RateListResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetAllRates not implemented")
+}
+func (UnimplementedRatesServiceServer) GetQuote(context.Context, *QuoteRequest) (*QuoteResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetQuote not implemented")
+}
+func (UnimplementedRatesServiceServer) StreamAlerts(*StreamAlertsRequest, grpc.ServerStreamingServer[AlertEvent]) error {
+	return status.Error(codes.Unimplemented, "method StreamAlerts not implemented")
+}
+func (UnimplementedRatesServiceServer) GetSnapshot(context.Context, *SnapshotRequest) (*SnapshotResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetSnapshot not implemented")
+}
+func (UnimplementedRatesServiceServer) ListCantors(context.Context, *ListCantorsRequest) (*ListCantorsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListCantors not implemented")
+}
+func (UnimplementedRatesServiceServer) GetHistory(context.Context, *HistoryRequest) (*HistoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetHistory not implemented")
+}
+func (UnimplementedRatesServiceServer) GetFinOpsStatus(context.Context, *FinOpsStatusRequest) (*FinOpsStatus, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetFinOpsStatus not implemented")
+}
+func (UnimplementedRatesServiceServer) Discover(context.Context, *DiscoverRequest) (*DiscoverResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Discover not implemented")
+}
+func (UnimplementedRatesServiceServer) DeleteCantor(context.Context, *DeleteCantorRequest) (*DeleteCantorResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteCantor not implemented")
+}
 func (UnimplementedRatesServiceServer) mustEmbedUnimplementedRatesServiceServer() {}
 func (UnimplementedRatesServiceServer) testEmbeddedByValue()                      {}
 
@@ -106,19 +261,212 @@ func _RatesService_StreamRates_Handler(srv interface{}, stream grpc.ServerStream
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type RatesService_StreamRatesServer = grpc.ServerStreamingServer[RateResponse]
 
+func _RatesService_GetAllRates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RatesServiceServer).GetAllRates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RatesService_GetAllRates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RatesServiceServer).GetAllRates(ctx, req.(*RateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RatesService_GetQuote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RatesServiceServer).GetQuote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RatesService_GetQuote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RatesServiceServer).GetQuote(ctx, req.(*QuoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RatesService_StreamAlerts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamAlertsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RatesServiceServer).StreamAlerts(m, &grpc.GenericServerStream[StreamAlertsRequest, AlertEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RatesService_StreamAlertsServer = grpc.ServerStreamingServer[AlertEvent]
+
+func _RatesService_GetSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RatesServiceServer).GetSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RatesService_GetSnapshot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RatesServiceServer).GetSnapshot(ctx, req.(*SnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RatesService_ListCantors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCantorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RatesServiceServer).ListCantors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RatesService_ListCantors_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RatesServiceServer).ListCantors(ctx, req.(*ListCantorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RatesService_GetHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RatesServiceServer).GetHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RatesService_GetHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RatesServiceServer).GetHistory(ctx, req.(*HistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RatesService_GetFinOpsStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FinOpsStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RatesServiceServer).GetFinOpsStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RatesService_GetFinOpsStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RatesServiceServer).GetFinOpsStatus(ctx, req.(*FinOpsStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RatesService_Discover_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiscoverRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RatesServiceServer).Discover(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RatesService_Discover_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RatesServiceServer).Discover(ctx, req.(*DiscoverRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RatesService_DeleteCantor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCantorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RatesServiceServer).DeleteCantor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RatesService_DeleteCantor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RatesServiceServer).DeleteCantor(ctx, req.(*DeleteCantorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // RatesService_ServiceDesc is the grpc.ServiceDesc for RatesService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
 var RatesService_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "v1.RatesService",
 	HandlerType: (*RatesServiceServer)(nil),
-	Methods:     []grpc.MethodDesc{},
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetAllRates",
+			Handler:    _RatesService_GetAllRates_Handler,
+		},
+		{
+			MethodName: "GetQuote",
+			Handler:    _RatesService_GetQuote_Handler,
+		},
+		{
+			MethodName: "GetSnapshot",
+			Handler:    _RatesService_GetSnapshot_Handler,
+		},
+		{
+			MethodName: "ListCantors",
+			Handler:    _RatesService_ListCantors_Handler,
+		},
+		{
+			MethodName: "GetHistory",
+			Handler:    _RatesService_GetHistory_Handler,
+		},
+		{
+			MethodName: "GetFinOpsStatus",
+			Handler:    _RatesService_GetFinOpsStatus_Handler,
+		},
+		{
+			MethodName: "Discover",
+			Handler:    _RatesService_Discover_Handler,
+		},
+		{
+			MethodName: "DeleteCantor",
+			Handler:    _RatesService_DeleteCantor_Handler,
+		},
+	},
 	Streams: []grpc.StreamDesc{
 		{
 			StreamName:    "StreamRates",
 			Handler:       _RatesService_StreamRates_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "StreamAlerts",
+			Handler:       _RatesService_StreamAlerts_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "api/proto/v1/rates.proto",
 }
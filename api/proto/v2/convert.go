@@ -0,0 +1,125 @@
+package v2
+
+import (
+	// External utilities
+	v1 "github.com/Niutaq/Gix/api/proto/v1"
+	"github.com/Niutaq/Gix/pkg/money"
+)
+
+// changeScale is the number of decimal places of Rate.Change24H (percent with basis-point resolution).
+const changeScale = 2
+
+// NewDecimal converts an exact rate into its v2 wire representation.
+func NewDecimal(r money.Rate) *Decimal {
+	return &Decimal{Value: r.Scaled(money.Scale), Scale: money.Scale}
+}
+
+// Money converts the decimal back into an exact rate. A nil or out-of-range decimal yields money.Zero.
+func (d *Decimal) Money() money.Rate {
+	if d == nil {
+		return money.Zero
+	}
+	r, _ := money.FromScaled(d.Value, d.Scale)
+	return r
+}
+
+// NewRate builds a v2 rate from its buy/sell values and derives mid and spread.
+// change24hBasisPoints uses the same unit as the v1 change24h field.
+func NewRate(cantorID int32, currency string, buy, sell money.Rate, change24hBasisPoints int64) *Rate {
+	return &Rate{
+		CantorId:  cantorID,
+		Currency:  currency,
+		Buy:       NewDecimal(buy),
+		Sell:      NewDecimal(sell),
+		Mid:       NewDecimal(buy.Mid(sell)),
+		Spread:    NewDecimal(sell.Sub(buy)),
+		Change24H: &Decimal{Value: change24hBasisPoints, Scale: changeScale},
+	}
+}
+
+// FromV1Rate converts a v1 rate into v2. Metadata that v1 does not carry (units, scraper type,
+// confidence) is left empty; units defaults to 1.
+func FromV1Rate(r *v1.RateResponse) *Rate {
+	if r == nil {
+		return nil
+	}
+	buy, sell := v1RateValues(r)
+	out := NewRate(r.CantorId, r.Currency, buy, sell, r.Change24H)
+	out.Units = 1
+	out.ObservedAt = r.FetchedAt
+	return out
+}
+
+// ToV1 converts a v2 rate into the v1 representation used by existing clients.
+func (r *Rate) ToV1() *v1.RateResponse {
+	if r == nil {
+		return nil
+	}
+	buy, sell := r.Buy.Money(), r.Sell.Money()
+	return &v1.RateResponse{
+		BuyRate:   buy.String(),
+		SellRate:  sell.String(),
+		CantorId:  r.CantorId,
+		Currency:  r.Currency,
+		FetchedAt: r.ObservedAt,
+		Change24H: r.Change24H.Money().Scaled(changeScale),
+		Buy:       buy.Proto(),
+		Sell:      sell.Proto(),
+	}
+}
+
+// FromV1History converts a v1 history response into v2.
+func FromV1History(h *v1.HistoryResponse) *HistoryResponse {
+	if h == nil {
+		return nil
+	}
+	out := &HistoryResponse{Currency: h.Currency, Points: make([]*HistoryPoint, 0, len(h.Points))}
+	for _, p := range h.Points {
+		buy, sell := v1Decimal(p.Buy), v1Decimal(p.Sell)
+		if p.Buy == nil {
+			buy, _ = money.FromScaled(p.BuyRate, 3)
+		}
+		if p.Sell == nil {
+			sell, _ = money.FromScaled(p.SellRate, 3)
+		}
+		out.Points = append(out.Points, &HistoryPoint{Time: p.Time, Buy: NewDecimal(buy), Sell: NewDecimal(sell)})
+	}
+	return out
+}
+
+// ToV1 converts a v2 history response into the v1 representation, filling the legacy milli-unit fields.
+func (h *HistoryResponse) ToV1() *v1.HistoryResponse {
+	if h == nil {
+		return nil
+	}
+	out := &v1.HistoryResponse{Currency: h.Currency, Points: make([]*v1.HistoryPoint, 0, len(h.Points))}
+	for _, p := range h.Points {
+		buy, sell := p.Buy.Money(), p.Sell.Money()
+		out.Points = append(out.Points, &v1.HistoryPoint{
+			Time:     p.Time,
+			BuyRate:  buy.Scaled(3),
+			SellRate: sell.Scaled(3),
+			Buy:      buy.Proto(),
+			Sell:     sell.Proto(),
+		})
+	}
+	return out
+}
+
+// v1RateValues reads the exact v1 decimal fields, falling back to the legacy strings.
+func v1RateValues(r *v1.RateResponse) (money.Rate, money.Rate) {
+	buy, sell := v1Decimal(r.Buy), v1Decimal(r.Sell)
+	if r.Buy == nil {
+		buy, _ = money.Parse(r.BuyRate)
+	}
+	if r.Sell == nil {
+		sell, _ = money.Parse(r.SellRate)
+	}
+	return buy, sell
+}
+
+// v1Decimal converts a v1 decimal, treating an out-of-range value like a missing one.
+func v1Decimal(d *v1.Decimal) money.Rate {
+	r, _ := money.FromProto(d)
+	return r
+}
@@ -0,0 +1,47 @@
+package v2
+
+import (
+	"testing"
+
+	v1 "github.com/Niutaq/Gix/api/proto/v1"
+	"github.com/Niutaq/Gix/pkg/money"
+)
+
+func TestNewRateDerivesMidAndSpread(t *testing.T) {
+	r := NewRate(7, "EUR", money.MustParse("4.2500"), money.MustParse("4.3100"), 125)
+
+	if got := r.Mid.Money().String(); got != "4.2800" {
+		t.Errorf("mid = %s, want 4.2800", got)
+	}
+	if got := r.Spread.Money().String(); got != "0.0600" {
+		t.Errorf("spread = %s, want 0.0600", got)
+	}
+	if r.Change24H.Value != 125 || r.Change24H.Scale != changeScale {
+		t.Errorf("change24h = %v, want 1.25%%", r.Change24H)
+	}
+}
+
+func TestV1RoundTrip(t *testing.T) {
+	legacy := &v1.RateResponse{BuyRate: "0.0123", SellRate: "0.0131", CantorId: 3, Currency: "HUF", FetchedAt: 1700000000, Change24H: -42}
+
+	r := FromV1Rate(legacy)
+	if r.Buy.Money().String() != "0.0123" || r.Units != 1 || r.ObservedAt != legacy.FetchedAt {
+		t.Fatalf("FromV1Rate = %v", r)
+	}
+
+	back := r.ToV1()
+	if back.BuyRate != legacy.BuyRate || back.SellRate != legacy.SellRate || back.Change24H != legacy.Change24H {
+		t.Errorf("ToV1 = %v, want values of %v", back, legacy)
+	}
+}
+
+func TestFromV1HistoryLegacyMilliUnits(t *testing.T) {
+	h := FromV1History(&v1.HistoryResponse{Currency: "EUR", Points: []*v1.HistoryPoint{{Time: 1, BuyRate: 4255, SellRate: 4301}}})
+
+	if got := h.Points[0].Buy.Money().String(); got != "4.2550" {
+		t.Errorf("buy = %s, want 4.2550", got)
+	}
+	if got := h.ToV1().Points[0].SellRate; got != 4301 {
+		t.Errorf("legacy sell = %d, want 4301", got)
+	}
+}
@@ -0,0 +1,768 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.2
+// source: api/proto/v2/rates.proto
+
+package v2
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Decimal is an exact decimal number equal to value * 10^-scale.
+type Decimal struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         int64                  `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`
+	Scale         int32                  `protobuf:"varint,2,opt,name=scale,proto3" json:"scale,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Decimal) Reset() {
+	*x = Decimal{}
+	mi := &file_api_proto_v2_rates_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Decimal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Decimal) ProtoMessage() {}
+
+func (x *Decimal) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v2_rates_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Decimal.ProtoReflect.Descriptor instead.
+func (*Decimal) Descriptor() ([]byte, []int) {
+	return file_api_proto_v2_rates_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Decimal) GetValue() int64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *Decimal) GetScale() int32 {
+	if x != nil {
+		return x.Scale
+	}
+	return 0
+}
+
+// Rate is the latest observed quote of one cantor for one currency. All prices are PLN per single
+// unit of the currency; units is the quantity the cantor quotes prices for on its site.
+type Rate struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	CantorId int32                  `protobuf:"varint,1,opt,name=cantor_id,json=cantorID,proto3" json:"cantor_id,omitempty"`
+	Currency string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	Buy      *Decimal               `protobuf:"bytes,3,opt,name=buy,proto3" json:"buy,omitempty"`
+	Sell     *Decimal               `protobuf:"bytes,4,opt,name=sell,proto3" json:"sell,omitempty"`
+	Mid      *Decimal               `protobuf:"bytes,5,opt,name=mid,proto3" json:"mid,omitempty"`
+	Spread   *Decimal               `protobuf:"bytes,6,opt,name=spread,proto3" json:"spread,omitempty"`
+	Units    int32                  `protobuf:"varint,7,opt,name=units,proto3" json:"units,omitempty"`
+	// Change of the buy rate over the last 24 hours, in percent.
+	Change24H *Decimal `protobuf:"bytes,8,opt,name=change24h,proto3" json:"change24h,omitempty"`
+	// static, heuristic or llm.
+	ScraperType string `protobuf:"bytes,9,opt,name=scraper_type,json=scraperType,proto3" json:"scraper_type,omitempty"`
+	// Scraper confidence between 0.0 and 1.0.
+	Confidence float64 `protobuf:"fixed64,10,opt,name=confidence,proto3" json:"confidence,omitempty"`
+	// Unix time (seconds) of the last observation of this value.
+	ObservedAt    int64 `protobuf:"varint,11,opt,name=observed_at,json=observedAt,proto3" json:"observed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Rate) Reset() {
+	*x = Rate{}
+	mi := &file_api_proto_v2_rates_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Rate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Rate) ProtoMessage() {}
+
+func (x *Rate) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v2_rates_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Rate.ProtoReflect.Descriptor instead.
+func (*Rate) Descriptor() ([]byte, []int) {
+	return file_api_proto_v2_rates_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Rate) GetCantorId() int32 {
+	if x != nil {
+		return x.CantorId
+	}
+	return 0
+}
+
+func (x *Rate) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Rate) GetBuy() *Decimal {
+	if x != nil {
+		return x.Buy
+	}
+	return nil
+}
+
+func (x *Rate) GetSell() *Decimal {
+	if x != nil {
+		return x.Sell
+	}
+	return nil
+}
+
+func (x *Rate) GetMid() *Decimal {
+	if x != nil {
+		return x.Mid
+	}
+	return nil
+}
+
+func (x *Rate) GetSpread() *Decimal {
+	if x != nil {
+		return x.Spread
+	}
+	return nil
+}
+
+func (x *Rate) GetUnits() int32 {
+	if x != nil {
+		return x.Units
+	}
+	return 0
+}
+
+func (x *Rate) GetChange24H() *Decimal {
+	if x != nil {
+		return x.Change24H
+	}
+	return nil
+}
+
+func (x *Rate) GetScraperType() string {
+	if x != nil {
+		return x.ScraperType
+	}
+	return ""
+}
+
+func (x *Rate) GetConfidence() float64 {
+	if x != nil {
+		return x.Confidence
+	}
+	return 0
+}
+
+func (x *Rate) GetObservedAt() int64 {
+	if x != nil {
+		return x.ObservedAt
+	}
+	return 0
+}
+
+type RateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Currency      string                 `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RateRequest) Reset() {
+	*x = RateRequest{}
+	mi := &file_api_proto_v2_rates_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateRequest) ProtoMessage() {}
+
+func (x *RateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v2_rates_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateRequest.ProtoReflect.Descriptor instead.
+func (*RateRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_v2_rates_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RateRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type RateList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rates         []*Rate                `protobuf:"bytes,1,rep,name=rates,proto3" json:"rates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RateList) Reset() {
+	*x = RateList{}
+	mi := &file_api_proto_v2_rates_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RateList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateList) ProtoMessage() {}
+
+func (x *RateList) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v2_rates_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateList.ProtoReflect.Descriptor instead.
+func (*RateList) Descriptor() ([]byte, []int) {
+	return file_api_proto_v2_rates_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RateList) GetRates() []*Rate {
+	if x != nil {
+		return x.Rates
+	}
+	return nil
+}
+
+type StreamRatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Currencies    []string               `protobuf:"bytes,1,rep,name=currencies,proto3" json:"currencies,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamRatesRequest) Reset() {
+	*x = StreamRatesRequest{}
+	mi := &file_api_proto_v2_rates_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamRatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamRatesRequest) ProtoMessage() {}
+
+func (x *StreamRatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v2_rates_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamRatesRequest.ProtoReflect.Descriptor instead.
+func (*StreamRatesRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_v2_rates_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *StreamRatesRequest) GetCurrencies() []string {
+	if x != nil {
+		return x.Currencies
+	}
+	return nil
+}
+
+type HistoryRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Currency string                 `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`
+	CantorId int32                  `protobuf:"varint,2,opt,name=cantor_id,json=cantorID,proto3" json:"cantor_id,omitempty"`
+	Days     int32                  `protobuf:"varint,3,opt,name=days,proto3" json:"days,omitempty"`
+	// Bucket width: 5m, 15m, 1h, 6h or 1d. Chosen from the range when empty.
+	Interval string `protobuf:"bytes,4,opt,name=interval,proto3" json:"interval,omitempty"`
+	// avg (default), ohlc, min, max or last.
+	Agg           string `protobuf:"bytes,5,opt,name=agg,proto3" json:"agg,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HistoryRequest) Reset() {
+	*x = HistoryRequest{}
+	mi := &file_api_proto_v2_rates_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoryRequest) ProtoMessage() {}
+
+func (x *HistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v2_rates_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistoryRequest.ProtoReflect.Descriptor instead.
+func (*HistoryRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_v2_rates_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *HistoryRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *HistoryRequest) GetCantorId() int32 {
+	if x != nil {
+		return x.CantorId
+	}
+	return 0
+}
+
+func (x *HistoryRequest) GetDays() int32 {
+	if x != nil {
+		return x.Days
+	}
+	return 0
+}
+
+func (x *HistoryRequest) GetInterval() string {
+	if x != nil {
+		return x.Interval
+	}
+	return ""
+}
+
+func (x *HistoryRequest) GetAgg() string {
+	if x != nil {
+		return x.Agg
+	}
+	return ""
+}
+
+// Candle is the open, high, low and close of a rate within one history bucket.
+type Candle struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Open          *Decimal               `protobuf:"bytes,1,opt,name=open,proto3" json:"open,omitempty"`
+	High          *Decimal               `protobuf:"bytes,2,opt,name=high,proto3" json:"high,omitempty"`
+	Low           *Decimal               `protobuf:"bytes,3,opt,name=low,proto3" json:"low,omitempty"`
+	Close         *Decimal               `protobuf:"bytes,4,opt,name=close,proto3" json:"close,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Candle) Reset() {
+	*x = Candle{}
+	mi := &file_api_proto_v2_rates_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Candle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Candle) ProtoMessage() {}
+
+func (x *Candle) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v2_rates_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Candle.ProtoReflect.Descriptor instead.
+func (*Candle) Descriptor() ([]byte, []int) {
+	return file_api_proto_v2_rates_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Candle) GetOpen() *Decimal {
+	if x != nil {
+		return x.Open
+	}
+	return nil
+}
+
+func (x *Candle) GetHigh() *Decimal {
+	if x != nil {
+		return x.High
+	}
+	return nil
+}
+
+func (x *Candle) GetLow() *Decimal {
+	if x != nil {
+		return x.Low
+	}
+	return nil
+}
+
+func (x *Candle) GetClose() *Decimal {
+	if x != nil {
+		return x.Close
+	}
+	return nil
+}
+
+type HistoryPoint struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Time  int64                  `protobuf:"varint,1,opt,name=time,proto3" json:"time,omitempty"`
+	Buy   *Decimal               `protobuf:"bytes,2,opt,name=buy,proto3" json:"buy,omitempty"`
+	Sell  *Decimal               `protobuf:"bytes,3,opt,name=sell,proto3" json:"sell,omitempty"`
+	// Set only for agg=ohlc; buy and sell then hold the close.
+	BuyOhlc       *Candle `protobuf:"bytes,4,opt,name=buy_ohlc,json=buyOHLC,proto3" json:"buy_ohlc,omitempty"`
+	SellOhlc      *Candle `protobuf:"bytes,5,opt,name=sell_ohlc,json=sellOHLC,proto3" json:"sell_ohlc,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HistoryPoint) Reset() {
+	*x = HistoryPoint{}
+	mi := &file_api_proto_v2_rates_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HistoryPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoryPoint) ProtoMessage() {}
+
+func (x *HistoryPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v2_rates_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistoryPoint.ProtoReflect.Descriptor instead.
+func (*HistoryPoint) Descriptor() ([]byte, []int) {
+	return file_api_proto_v2_rates_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *HistoryPoint) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *HistoryPoint) GetBuy() *Decimal {
+	if x != nil {
+		return x.Buy
+	}
+	return nil
+}
+
+func (x *HistoryPoint) GetSell() *Decimal {
+	if x != nil {
+		return x.Sell
+	}
+	return nil
+}
+
+func (x *HistoryPoint) GetBuyOhlc() *Candle {
+	if x != nil {
+		return x.BuyOhlc
+	}
+	return nil
+}
+
+func (x *HistoryPoint) GetSellOhlc() *Candle {
+	if x != nil {
+		return x.SellOhlc
+	}
+	return nil
+}
+
+type HistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Currency      string                 `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`
+	Points        []*HistoryPoint        `protobuf:"bytes,2,rep,name=points,proto3" json:"points,omitempty"`
+	Interval      string                 `protobuf:"bytes,3,opt,name=interval,proto3" json:"interval,omitempty"`
+	Agg           string                 `protobuf:"bytes,4,opt,name=agg,proto3" json:"agg,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HistoryResponse) Reset() {
+	*x = HistoryResponse{}
+	mi := &file_api_proto_v2_rates_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoryResponse) ProtoMessage() {}
+
+func (x *HistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v2_rates_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistoryResponse.ProtoReflect.Descriptor instead.
+func (*HistoryResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_v2_rates_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *HistoryResponse) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *HistoryResponse) GetPoints() []*HistoryPoint {
+	if x != nil {
+		return x.Points
+	}
+	return nil
+}
+
+func (x *HistoryResponse) GetInterval() string {
+	if x != nil {
+		return x.Interval
+	}
+	return ""
+}
+
+func (x *HistoryResponse) GetAgg() string {
+	if x != nil {
+		return x.Agg
+	}
+	return ""
+}
+
+var File_api_proto_v2_rates_proto protoreflect.FileDescriptor
+
+const file_api_proto_v2_rates_proto_rawDesc = "" +
+	"\n" +
+	"\x18api/proto/v2/rates.proto\x12\x02v2\"5\n" +
+	"\aDecimal\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\x03R\x05value\x12\x14\n" +
+	"\x05scale\x18\x02 \x01(\x05R\x05scale\"\xe8\x02\n" +
+	"\x04Rate\x12\x1b\n" +
+	"\tcantor_id\x18\x01 \x01(\x05R\bcantorID\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\x12\x1d\n" +
+	"\x03buy\x18\x03 \x01(\v2\v.v2.DecimalR\x03buy\x12\x1f\n" +
+	"\x04sell\x18\x04 \x01(\v2\v.v2.DecimalR\x04sell\x12\x1d\n" +
+	"\x03mid\x18\x05 \x01(\v2\v.v2.DecimalR\x03mid\x12#\n" +
+	"\x06spread\x18\x06 \x01(\v2\v.v2.DecimalR\x06spread\x12\x14\n" +
+	"\x05units\x18\a \x01(\x05R\x05units\x12)\n" +
+	"\tchange24h\x18\b \x01(\v2\v.v2.DecimalR\tchange24h\x12!\n" +
+	"\fscraper_type\x18\t \x01(\tR\vscraperType\x12\x1e\n" +
+	"\n" +
+	"confidence\x18\n" +
+	" \x01(\x01R\n" +
+	"confidence\x12\x1f\n" +
+	"\vobserved_at\x18\v \x01(\x03R\n" +
+	"observedAt\")\n" +
+	"\vRateRequest\x12\x1a\n" +
+	"\bcurrency\x18\x01 \x01(\tR\bcurrency\"*\n" +
+	"\bRateList\x12\x1e\n" +
+	"\x05rates\x18\x01 \x03(\v2\b.v2.RateR\x05rates\"4\n" +
+	"\x12StreamRatesRequest\x12\x1e\n" +
+	"\n" +
+	"currencies\x18\x01 \x03(\tR\n" +
+	"currencies\"\x8b\x01\n" +
+	"\x0eHistoryRequest\x12\x1a\n" +
+	"\bcurrency\x18\x01 \x01(\tR\bcurrency\x12\x1b\n" +
+	"\tcantor_id\x18\x02 \x01(\x05R\bcantorID\x12\x12\n" +
+	"\x04days\x18\x03 \x01(\x05R\x04days\x12\x1a\n" +
+	"\binterval\x18\x04 \x01(\tR\binterval\x12\x10\n" +
+	"\x03agg\x18\x05 \x01(\tR\x03agg\"\x8c\x01\n" +
+	"\x06Candle\x12\x1f\n" +
+	"\x04open\x18\x01 \x01(\v2\v.v2.DecimalR\x04open\x12\x1f\n" +
+	"\x04high\x18\x02 \x01(\v2\v.v2.DecimalR\x04high\x12\x1d\n" +
+	"\x03low\x18\x03 \x01(\v2\v.v2.DecimalR\x03low\x12!\n" +
+	"\x05close\x18\x04 \x01(\v2\v.v2.DecimalR\x05close\"\xb2\x01\n" +
+	"\fHistoryPoint\x12\x12\n" +
+	"\x04time\x18\x01 \x01(\x03R\x04time\x12\x1d\n" +
+	"\x03buy\x18\x02 \x01(\v2\v.v2.DecimalR\x03buy\x12\x1f\n" +
+	"\x04sell\x18\x03 \x01(\v2\v.v2.DecimalR\x04sell\x12%\n" +
+	"\bbuy_ohlc\x18\x04 \x01(\v2\n" +
+	".v2.CandleR\abuyOHLC\x12'\n" +
+	"\tsell_ohlc\x18\x05 \x01(\v2\n" +
+	".v2.CandleR\bsellOHLC\"\x85\x01\n" +
+	"\x0fHistoryResponse\x12\x1a\n" +
+	"\bcurrency\x18\x01 \x01(\tR\bcurrency\x12(\n" +
+	"\x06points\x18\x02 \x03(\v2\x10.v2.HistoryPointR\x06points\x12\x1a\n" +
+	"\binterval\x18\x03 \x01(\tR\binterval\x12\x10\n" +
+	"\x03agg\x18\x04 \x01(\tR\x03agg2\xa6\x01\n" +
+	"\fRatesService\x121\n" +
+	"\vStreamRates\x12\x16.v2.StreamRatesRequest\x1a\b.v2.Rate0\x01\x12,\n" +
+	"\vGetAllRates\x12\x0f.v2.RateRequest\x1a\f.v2.RateList\x125\n" +
+	"\n" +
+	"GetHistory\x12\x12.v2.HistoryRequest\x1a\x13.v2.HistoryResponseB$Z\"github.com/Niutaq/Gix/api/proto/v2b\x06proto3"
+
+var (
+	file_api_proto_v2_rates_proto_rawDescOnce sync.Once
+	file_api_proto_v2_rates_proto_rawDescData []byte
+)
+
+func file_api_proto_v2_rates_proto_rawDescGZIP() []byte {
+	file_api_proto_v2_rates_proto_rawDescOnce.Do(func() {
+		file_api_proto_v2_rates_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_proto_v2_rates_proto_rawDesc), len(file_api_proto_v2_rates_proto_rawDesc)))
+	})
+	return file_api_proto_v2_rates_proto_rawDescData
+}
+
+var file_api_proto_v2_rates_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_api_proto_v2_rates_proto_goTypes = []any{
+	(*Decimal)(nil),            // 0: v2.Decimal
+	(*Rate)(nil),               // 1: v2.Rate
+	(*RateRequest)(nil),        // 2: v2.RateRequest
+	(*RateList)(nil),           // 3: v2.RateList
+	(*StreamRatesRequest)(nil), // 4: v2.StreamRatesRequest
+	(*HistoryRequest)(nil),     // 5: v2.HistoryRequest
+	(*Candle)(nil),             // 6: v2.Candle
+	(*HistoryPoint)(nil),       // 7: v2.HistoryPoint
+	(*HistoryResponse)(nil),    // 8: v2.HistoryResponse
+}
+var file_api_proto_v2_rates_proto_depIdxs = []int32{
+	0,  // 0: v2.Rate.buy:type_name -> v2.Decimal
+	0,  // 1: v2.Rate.sell:type_name -> v2.Decimal
+	0,  // 2: v2.Rate.mid:type_name -> v2.Decimal
+	0,  // 3: v2.Rate.spread:type_name -> v2.Decimal
+	0,  // 4: v2.Rate.change24h:type_name -> v2.Decimal
+	1,  // 5: v2.RateList.rates:type_name -> v2.Rate
+	0,  // 6: v2.Candle.open:type_name -> v2.Decimal
+	0,  // 7: v2.Candle.high:type_name -> v2.Decimal
+	0,  // 8: v2.Candle.low:type_name -> v2.Decimal
+	0,  // 9: v2.Candle.close:type_name -> v2.Decimal
+	0,  // 10: v2.HistoryPoint.buy:type_name -> v2.Decimal
+	0,  // 11: v2.HistoryPoint.sell:type_name -> v2.Decimal
+	6,  // 12: v2.HistoryPoint.buy_ohlc:type_name -> v2.Candle
+	6,  // 13: v2.HistoryPoint.sell_ohlc:type_name -> v2.Candle
+	7,  // 14: v2.HistoryResponse.points:type_name -> v2.HistoryPoint
+	4,  // 15: v2.RatesService.StreamRates:input_type -> v2.StreamRatesRequest
+	2,  // 16: v2.RatesService.GetAllRates:input_type -> v2.RateRequest
+	5,  // 17: v2.RatesService.GetHistory:input_type -> v2.HistoryRequest
+	1,  // 18: v2.RatesService.StreamRates:output_type -> v2.Rate
+	3,  // 19: v2.RatesService.GetAllRates:output_type -> v2.RateList
+	8,  // 20: v2.RatesService.GetHistory:output_type -> v2.HistoryResponse
+	18, // [18:21] is the sub-list for method output_type
+	15, // [15:18] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
+}
+
+func init() { file_api_proto_v2_rates_proto_init() }
+func file_api_proto_v2_rates_proto_init() {
+	if File_api_proto_v2_rates_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_v2_rates_proto_rawDesc), len(file_api_proto_v2_rates_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_proto_v2_rates_proto_goTypes,
+		DependencyIndexes: file_api_proto_v2_rates_proto_depIdxs,
+		MessageInfos:      file_api_proto_v2_rates_proto_msgTypes,
+	}.Build()
+	File_api_proto_v2_rates_proto = out.File
+	file_api_proto_v2_rates_proto_goTypes = nil
+	file_api_proto_v2_rates_proto_depIdxs = nil
+}
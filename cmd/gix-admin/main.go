@@ -0,0 +1,225 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Niutaq/Gix/internal/infrastructure"
+	"github.com/Niutaq/Gix/internal/services"
+	"github.com/Niutaq/Gix/pkg/auth"
+	"github.com/Niutaq/Gix/pkg/export"
+)
+
+const usage = `Usage: gix-admin <command> [flags]
+
+Commands:
+  keys create -name <name> -role <viewer|operator|admin>   create an API key (printed once)
+  keys list                                               list API keys
+  keys revoke -id <id>                                    revoke an API key
+  token -sub <subject> -role <role> [-ttl 24h]            issue a JWT signed with GIX_JWT_SECRET
+  export [-currencies EUR,USD] [-cantors 1,2] [-from 2026-01-01] [-to ...]
+         [-interval raw|5m|15m|1h|6h|1d] [-agg avg|ohlc|min|max|last]
+         [-format csv|ndjson|parquet] [-o file]            export rate history (stdout by default)
+
+Environment:
+  DATABASE_URL    required by the keys and export commands
+  GIX_JWT_SECRET  required by the token command
+`
+
+// main is the entry point of the admin CLI used to manage API credentials and export rate history.
+func main() {
+	log.SetFlags(0)
+	args := os.Args[1:]
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var err error
+	switch {
+	case args[0] == "token":
+		err = issueToken(args[1:])
+	case args[0] == "keys" && len(args) > 1:
+		err = manageKeys(args[1], args[2:])
+	case args[0] == "export":
+		err = exportHistory(args[1:])
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	if err != nil {
+		log.Fatalf("gix-admin: %v", err)
+	}
+}
+
+// manageKeys runs one of the keys subcommands against DATABASE_URL.
+func manageKeys(cmd string, args []string) error {
+	databaseURL := os.Getenv("DATABASE_URL")
+	if databaseURL == "" {
+		return errors.New("DATABASE_URL environment variable is required")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	db, err := infrastructure.ConnectToDB(ctx, databaseURL)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	switch cmd {
+	case "create":
+		fs := flag.NewFlagSet("keys create", flag.ExitOnError)
+		name := fs.String("name", "", "Key name, e.g. the owner or service using it")
+		roleName := fs.String("role", string(auth.RoleViewer), "Role: viewer, operator or admin")
+		_ = fs.Parse(args)
+		if *name == "" {
+			return errors.New("-name is required")
+		}
+		role, err := auth.ParseRole(*roleName)
+		if err != nil {
+			return err
+		}
+
+		key, rec, err := services.CreateAPIKey(ctx, db, *name, role)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Created key %d (%s, role %s). Store it now, it cannot be shown again:\n%s\n", rec.ID, rec.Name, rec.Role, key)
+		return nil
+
+	case "list":
+		keys, err := services.ListAPIKeys(ctx, db)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tNAME\tPREFIX\tROLE\tCREATED\tLAST USED\tREVOKED")
+		for _, k := range keys {
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%s\n", k.ID, k.Name, k.Prefix, k.Role,
+				k.CreatedAt.Format(time.RFC3339), formatTime(k.LastUsedAt), formatTime(k.RevokedAt))
+		}
+		return w.Flush()
+
+	case "revoke":
+		fs := flag.NewFlagSet("keys revoke", flag.ExitOnError)
+		id := fs.Int("id", 0, "Key ID (see keys list)")
+		_ = fs.Parse(args)
+		if *id <= 0 {
+			return errors.New("-id is required")
+		}
+		if err := services.RevokeAPIKey(ctx, db, *id); err != nil {
+			return err
+		}
+		fmt.Println("Revoked key " + strconv.Itoa(*id))
+		return nil
+	}
+	return fmt.Errorf("unknown keys command %q", cmd)
+}
+
+// issueToken prints a JWT for the given subject and role.
+func issueToken(args []string) error {
+	fs := flag.NewFlagSet("token", flag.ExitOnError)
+	subject := fs.String("sub", "", "Token subject")
+	roleName := fs.String("role", string(auth.RoleViewer), "Role: viewer, operator or admin")
+	ttl := fs.Duration("ttl", 24*time.Hour, "Token lifetime")
+	_ = fs.Parse(args)
+
+	secret := os.Getenv("GIX_JWT_SECRET")
+	if secret == "" {
+		return errors.New("GIX_JWT_SECRET environment variable is required")
+	}
+	if *subject == "" {
+		return errors.New("-sub is required")
+	}
+	role, err := auth.ParseRole(*roleName)
+	if err != nil {
+		return err
+	}
+
+	token, err := auth.IssueToken([]byte(secret), *subject, role, *ttl, time.Now())
+	if err != nil {
+		return err
+	}
+	fmt.Println(token)
+	return nil
+}
+
+// exportHistory streams rate history from DATABASE_URL to a file or stdout, using the same request
+// parsing and writers as GET /api/v1/history/export.
+func exportHistory(args []string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	currencies := fs.String("currencies", "", "Comma-separated currency codes (default: all)")
+	cantors := fs.String("cantors", "", "Comma-separated cantor IDs (default: all)")
+	from := fs.String("from", "", "Start, RFC 3339 or YYYY-MM-DD (default: a week before -to)")
+	to := fs.String("to", "", "End, RFC 3339 or YYYY-MM-DD (default: now)")
+	interval := fs.String("interval", services.IntervalRaw, "raw, 5m, 15m, 1h, 6h or 1d")
+	agg := fs.String("agg", services.AggAvg, "Bucket aggregation: avg, ohlc, min, max or last")
+	format := fs.String("format", export.FormatCSV, "csv, ndjson or parquet")
+	output := fs.String("o", "", "Output file (default: stdout)")
+	_ = fs.Parse(args)
+
+	req, err := services.NewExportRequest(*currencies, *cantors, *from, *to, *interval, *agg, *format, time.Now())
+	if err != nil {
+		return err
+	}
+	databaseURL := os.Getenv("DATABASE_URL")
+	if databaseURL == "" {
+		return errors.New("DATABASE_URL environment variable is required")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	db, err := infrastructure.ConnectToDB(ctx, databaseURL)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	out := os.Stdout
+	if *output != "" {
+		if out, err = os.Create(*output); err != nil {
+			return err
+		}
+		defer out.Close()
+	}
+	buf := bufio.NewWriter(out)
+
+	w, err := export.NewWriter(buf, req.Format, req.Agg == services.AggOHLC)
+	if err != nil {
+		return err
+	}
+	rows, err := services.ExportHistory(ctx, db, req, w)
+	if err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	if err := buf.Flush(); err != nil {
+		return err
+	}
+	if *output == "" {
+		return nil
+	}
+	fmt.Fprintf(os.Stderr, "Exported %d rows to %s\n", rows, *output)
+	return out.Close()
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Format(time.RFC3339)
+}
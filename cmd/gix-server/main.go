@@ -2,10 +2,14 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"log"
+	"maps"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 	"time"
 
@@ -13,9 +17,15 @@ import (
 	"github.com/Niutaq/Gix/internal/api"
 	"github.com/Niutaq/Gix/internal/api/rpc"
 	"github.com/Niutaq/Gix/internal/infrastructure"
+	"github.com/Niutaq/Gix/internal/services"
 	"github.com/Niutaq/Gix/internal/workers"
+	"github.com/Niutaq/Gix/pkg/alerts"
 	"github.com/Niutaq/Gix/pkg/finops"
+	"github.com/Niutaq/Gix/pkg/ratelimit"
 	"github.com/Niutaq/Gix/pkg/search"
+	"github.com/Niutaq/Gix/pkg/streams"
+	"github.com/Niutaq/Gix/pkg/tlsconf"
+	"github.com/Niutaq/Gix/pkg/webhooks"
 	"github.com/nats-io/nats.go"
 	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
 )
@@ -34,6 +44,11 @@ import (
 
 // @host      localhost:8080
 // @BasePath  /api/v1
+
+// @securityDefinitions.apikey  BearerAuth
+// @in                          header
+// @name                        Authorization
+// @description                 "Bearer <api key or JWT>"
 func main() {
 	tracer.Start(tracer.WithService("gix-server"))
 	defer tracer.Stop()
@@ -125,19 +140,92 @@ func main() {
 	govEngine := finops.NewGovernanceEngine(estimator, 0.05)
 	govEngine.StartMonitor(context.Background(), 1*time.Minute)
 
+	jwtSecret := os.Getenv("GIX_JWT_SECRET")
+	if jwtSecret == "" {
+		log.Printf("[Auth] Warning: GIX_JWT_SECRET not set. Only API keys will be accepted.")
+	}
+
+	quotas := ratelimit.DefaultQuotas
+	if spec := os.Getenv("GIX_RATE_LIMITS"); spec != "" {
+		overrides, err := ratelimit.ParseQuotas(spec)
+		if err != nil {
+			log.Fatalf("Invalid GIX_RATE_LIMITS: %v", err)
+		}
+		quotas = maps.Clone(quotas)
+		maps.Copy(quotas, overrides)
+	}
+	limiter := ratelimit.NewLimiter(rdb, quotas)
+	limiter.StartSync(context.Background(), time.Minute)
+
+	streamCfg := streams.DefaultConfig
+	if size := os.Getenv("GIX_STREAM_QUEUE"); size != "" {
+		n, err := strconv.Atoi(size)
+		if err != nil || n < 1 {
+			log.Fatalf("Invalid GIX_STREAM_QUEUE: %q", size)
+		}
+		streamCfg.QueueSize = n
+	}
+	if overflow := os.Getenv("GIX_STREAM_OVERFLOW"); overflow != "" {
+		policy, err := streams.ParsePolicy(overflow)
+		if err != nil {
+			log.Fatalf("Invalid GIX_STREAM_OVERFLOW: %v", err)
+		}
+		streamCfg.Overflow = policy
+	}
+
+	// dRPC runs over TLS when GIX_DRPC_TLS_CERT and GIX_DRPC_TLS_KEY are set; GIX_DRPC_TLS_CLIENT_CA
+	// additionally requires client certificates signed by that CA.
+	var drpcTLS *tls.Config
+	if certFile := os.Getenv("GIX_DRPC_TLS_CERT"); certFile != "" {
+		drpcTLS, err = tlsconf.Server(certFile, os.Getenv("GIX_DRPC_TLS_KEY"), os.Getenv("GIX_DRPC_TLS_CLIENT_CA"))
+		if err != nil {
+			log.Fatalf("Invalid dRPC TLS configuration: %v", err)
+		}
+		log.Printf("[dRPC] TLS enabled (mutual: %v)", drpcTLS.ClientCAs != nil)
+	}
+
 	appState := &infrastructure.AppState{
 		DB:         dbpool,
 		Cache:      rdb,
 		JS:         js,
 		Search:     se,
 		Governance: govEngine,
+		Alerts:     alerts.NewEngine(),
+		JWTSecret:  []byte(jwtSecret),
+		Limiter:    limiter,
+		Streams:    streamCfg,
+		DRPCTLS:    drpcTLS,
 	}
+	services.StartAlertRuleSync(context.Background(), appState, time.Minute)
+
+	govEngine.OnTrip(func(providerID string, spendUSD, limitUSD float64) {
+		services.EmitWebhookEvent(context.Background(), appState, webhooks.EventGovernanceTripped, map[string]any{
+			"providerID": providerID,
+			"spendUSD":   spendUSD,
+			"limitUSD":   limitUSD,
+		})
+	})
+	workers.StartWebhookDispatcher(appState)
 
 	if se != nil {
 		go infrastructure.SyncCantorsToES(appState)
 	}
 
-	go rpc.StartDRPCServer(dbpool, rdb)
+	// dRPC is always served on the HTTP port; the dedicated port is kept for older clients unless
+	// GIX_DRPC_ADDR is "off".
+	drpcAddr := os.Getenv("GIX_DRPC_ADDR")
+	if drpcAddr == "" {
+		drpcAddr = ":8081"
+	}
+	if drpcAddr != "off" {
+		go rpc.StartDRPCServer(appState, drpcAddr)
+	}
+
+	grpcAddr := os.Getenv("GIX_GRPC_ADDR")
+	if grpcAddr == "" {
+		grpcAddr = ":9090"
+	}
+	go rpc.StartGRPCServer(appState, grpcAddr)
 
 	go workers.StartBackgroundHarvester(appState)
 
@@ -148,9 +236,17 @@ func main() {
 		Handler: r,
 	}
 
+	lis, err := net.Listen("tcp", srv.Addr)
+	if err != nil {
+		log.Fatalf("Can't listen on %s: %v", srv.Addr, err)
+	}
+	muxCtx, stopMux := context.WithCancel(ctx)
+	defer stopMux()
+	httpLis := rpc.Multiplex(muxCtx, appState, lis)
+
 	go func() {
-		log.Println("Gin API listens at port :8080...")
-		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		log.Println("Gin API (and dRPC) listens at port :8080...")
+		if err := srv.Serve(httpLis); err != nil && err != http.ErrServerClosed {
 			log.Fatalf("Server error: %v", err)
 		}
 	}()
@@ -166,6 +262,7 @@ func main() {
 	if err := srv.Shutdown(shutdownCtx); err != nil {
 		log.Fatal("Server forced to shutdown: ", err)
 	}
+	stopMux()
 
 	log.Println("Server exiting")
 }
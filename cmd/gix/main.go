@@ -19,16 +19,17 @@ package main
 
 import (
 	// Standard libraries
-	"encoding/json"
+	"context"
+	"crypto/tls"
 	"flag"
 	"fmt"
-	"io"
 	"log"
 	"net/http"
 	_ "net/http/pprof"
 	"os"
 	"runtime"
 	"runtime/trace"
+	"strings"
 	"time"
 
 	// Gio utilities
@@ -41,6 +42,8 @@ import (
 	"gioui.org/widget/material"
 
 	// External utilities
+	pb "github.com/Niutaq/Gix/api/proto/v1"
+	"github.com/Niutaq/Gix/pkg/tlsconf"
 	"github.com/Niutaq/Gix/pkg/types"
 	"github.com/Niutaq/Gix/pkg/utilities"
 )
@@ -74,18 +77,15 @@ func main() {
 		base = base[:len(base)-1]
 	}
 
-	drpcBase := "localhost:8081"
-	if base == "http://"+remoteAPIHost+":8080" {
-		drpcBase = remoteAPIHost + ":8081"
+	drpcTLS, err := loadDRPCTLS()
+	if err != nil {
+		log.Fatalf("invalid dRPC TLS settings: %v", err)
 	}
 
 	config := utilities.AppConfig{
-		APICantorsURL:  base + "/api/v1/cantors",
-		APIRatesURL:    base + "/api/v1/rates",
-		APIHistoryURL:  base + "/api/v1/history",
-		APIFinOpsURL:   base + "/api/v1/finops",
-		APIDiscoverURL: base + "/api/v1/discover",
-		DRPCServerURL:  drpcBase,
+		DRPCServerURL: utilities.DeriveDRPCTarget(base),
+		APIKey:        os.Getenv("GIX_API_KEY"),
+		TLS:           drpcTLS,
 	}
 
 	// Start pprof server for performance analysis
@@ -121,7 +121,7 @@ func run(window *app.Window, config utilities.AppConfig) error {
 	state, theme, cantorChan := setupApplication(window, config)
 
 	// Start background services
-	go utilities.StartDRPCStream(window, state, config.DRPCServerURL)
+	go utilities.StartDRPCStream(window, state, config)
 	go utilities.StartFinOpsMonitoring(window, state, config)
 
 	var ops op.Ops
@@ -187,7 +187,7 @@ func initializeAppState() *utilities.AppState {
 			SortMode:              "NAME",
 			SortButtons:           make([]widget.Clickable, 4),
 			Timeframe:             "7D",
-			TimeframeButtons:      make([]widget.Clickable, 3),
+			TimeframeButtons:      make([]widget.Clickable, 5),
 			IntroAnim: utilities.IntroAnim{
 				Active:    runtime.GOOS != "linux",
 				StartTime: time.Now(),
@@ -207,6 +207,7 @@ func setupUILists(state *utilities.AppState) {
 // handleFrame processes the current frame event by updating state, adjusting scaling, and rendering the UI.
 func handleFrame(window *app.Window, ops *op.Ops, e app.FrameEvent, state *utilities.AppState, config utilities.AppConfig, cantorChan chan []utilities.ApiCantorResponse, theme *material.Theme) {
 	updateCantors(window, state, config, cantorChan)
+	utilities.LoadViewportCantors(window, state, config)
 
 	// Adjust scaling for Windows and Linux if UI is too small
 	if runtime.GOOS == "windows" || runtime.GOOS == "linux" {
@@ -280,27 +281,37 @@ func updateCantors(window *app.Window, state *utilities.AppState, config utiliti
 	}
 }
 
-// loadCantorsAsync fetches cantor data from the API asynchronously, decodes it, and sends it to the provided channel.
+// loadDRPCTLS returns the TLS settings of the dRPC connection, or nil for plaintext. TLS is used when
+// GIX_DRPC_TLS is "on" (system roots) or a CA file, key pins or a client certificate are configured.
+func loadDRPCTLS() (*tls.Config, error) {
+	opts := tlsconf.ClientOptions{
+		CAFile:   os.Getenv("GIX_DRPC_CA"),
+		CertFile: os.Getenv("GIX_DRPC_CLIENT_CERT"),
+		KeyFile:  os.Getenv("GIX_DRPC_CLIENT_KEY"),
+	}
+	if pins := os.Getenv("GIX_DRPC_PINS"); pins != "" {
+		opts.Pins = strings.Split(pins, ",")
+	}
+	if os.Getenv("GIX_DRPC_TLS") != "on" && opts.CAFile == "" && len(opts.Pins) == 0 && opts.CertFile == "" {
+		return nil, nil
+	}
+	return tlsconf.Client(opts)
+}
+
+// cantorPageSize is the page size of the full cantor listing, the largest the server accepts.
+const cantorPageSize = 1000
+
+// loadCantorsAsync fetches every page of the cantor listing over dRPC asynchronously and sends it to the provided channel.
 func loadCantorsAsync(window *app.Window, out chan<- []utilities.ApiCantorResponse, config utilities.AppConfig) {
 	go func() {
-		client := http.Client{Timeout: 5 * time.Second}
-		resp, err := client.Get(config.APICantorsURL)
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+
+		list, err := utilities.ListCantorsRPC(ctx, config, &pb.ListCantorsRequest{Limit: cantorPageSize})
 		if err != nil {
 			log.Println("Error fetching cantors:", err)
 			return
 		}
-		defer func(Body io.ReadCloser) {
-			err := Body.Close()
-			if err != nil {
-				log.Println("Error closing response body:", err)
-			}
-		}(resp.Body)
-
-		var list []utilities.ApiCantorResponse
-		if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
-			log.Println("Error parsing cantors:", err)
-			return
-		}
 
 		out <- list
 		window.Invalidate()
@@ -1,5 +1,8 @@
 -- Enable TimescaleDB extension
 CREATE EXTENSION IF NOT EXISTS timescaledb;
+-- Great-circle distances for the geo-aware cantor listing
+CREATE EXTENSION IF NOT EXISTS cube;
+CREATE EXTENSION IF NOT EXISTS earthdistance;
 
 -- 'cantors' and 'rates' tables
 CREATE TABLE IF NOT EXISTS cantors (
@@ -11,18 +14,123 @@ CREATE TABLE IF NOT EXISTS cantors (
     units INTEGER DEFAULT 1,
     latitude DECIMAL(9,6) DEFAULT 0,
     longitude DECIMAL(9,6) DEFAULT 0,
-    address TEXT
+    address TEXT,
+    origin VARCHAR(12) NOT NULL DEFAULT 'system' CHECK (origin IN ('system', 'discovered', 'partner')),
+    enabled BOOLEAN NOT NULL DEFAULT TRUE
 );
+CREATE INDEX IF NOT EXISTS cantors_earth_idx ON cantors USING gist (ll_to_earth(latitude::float8, longitude::float8));
+CREATE INDEX IF NOT EXISTS cantors_lat_lon_idx ON cantors (latitude, longitude);
 
 CREATE TABLE IF NOT EXISTS rates (
     time TIMESTAMPTZ NOT NULL,
     cantor_id INTEGER NOT NULL REFERENCES cantors(id),
     currency VARCHAR(3) NOT NULL,
-    buy_rate NUMERIC(10, 4) NOT NULL,
-    sell_rate NUMERIC(10, 4) NOT NULL,
+    buy_rate NUMERIC(16, 8) NOT NULL,
+    sell_rate NUMERIC(16, 8) NOT NULL,
     UNIQUE (time, cantor_id, currency)
 );
 
+-- Change-point storage: 'rates' only receives a row when buy/sell differ from the last stored value,
+-- 'rate_heartbeats' keeps the current value and when it was last observed.
+CREATE TABLE IF NOT EXISTS rate_heartbeats (
+    cantor_id INTEGER NOT NULL REFERENCES cantors(id) ON DELETE CASCADE,
+    currency VARCHAR(3) NOT NULL,
+    buy_rate NUMERIC(16, 8) NOT NULL,
+    sell_rate NUMERIC(16, 8) NOT NULL,
+    changed_at TIMESTAMPTZ NOT NULL,
+    observed_at TIMESTAMPTZ NOT NULL,
+    scraper_type VARCHAR(20),
+    confidence REAL,
+    PRIMARY KEY (cantor_id, currency)
+);
+
+-- Anomaly detection: suspicious scraped values wait here for review instead of being published.
+CREATE TABLE IF NOT EXISTS rate_quarantine (
+    id SERIAL PRIMARY KEY,
+    cantor_id INTEGER NOT NULL REFERENCES cantors(id) ON DELETE CASCADE,
+    currency VARCHAR(3) NOT NULL,
+    buy_rate NUMERIC(16, 8) NOT NULL,
+    sell_rate NUMERIC(16, 8) NOT NULL,
+    units INTEGER NOT NULL DEFAULT 1,
+    scraper_type VARCHAR(20),
+    confidence REAL,
+    reason VARCHAR(30) NOT NULL,
+    reference_rate NUMERIC(16, 8),
+    status VARCHAR(10) NOT NULL DEFAULT 'pending',
+    detected_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    resolved_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS rate_quarantine_status_idx ON rate_quarantine (status, detected_at DESC);
+
+-- Alerts: user rules evaluated on every published rate, and the events they fired.
+CREATE TABLE IF NOT EXISTS alert_rules (
+    id SERIAL PRIMARY KEY,
+    owner VARCHAR(100) NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    side VARCHAR(4) NOT NULL,
+    kind VARCHAR(10) NOT NULL,
+    threshold NUMERIC(16, 8),
+    change_bp INTEGER,
+    cantor_id INTEGER REFERENCES cantors(id) ON DELETE CASCADE,
+    latitude DOUBLE PRECISION,
+    longitude DOUBLE PRECISION,
+    radius_km DOUBLE PRECISION,
+    cooldown_seconds INTEGER NOT NULL DEFAULT 3600,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS alert_rules_currency_idx ON alert_rules (currency);
+CREATE INDEX IF NOT EXISTS alert_rules_owner_idx ON alert_rules (owner);
+
+CREATE TABLE IF NOT EXISTS alert_events (
+    id BIGSERIAL PRIMARY KEY,
+    rule_id INTEGER NOT NULL REFERENCES alert_rules(id) ON DELETE CASCADE,
+    owner VARCHAR(100) NOT NULL,
+    cantor_id INTEGER NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    side VARCHAR(4) NOT NULL,
+    kind VARCHAR(10) NOT NULL,
+    rate NUMERIC(16, 8) NOT NULL,
+    reference NUMERIC(16, 8),
+    fired_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS alert_events_owner_time_idx ON alert_events (owner, fired_at DESC);
+
+-- Webhooks: outbound subscriptions and their delivery log.
+CREATE TABLE IF NOT EXISTS webhooks (
+    id SERIAL PRIMARY KEY,
+    url TEXT NOT NULL,
+    secret TEXT NOT NULL,
+    events TEXT[] NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id BIGSERIAL PRIMARY KEY,
+    webhook_id INTEGER NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+    event VARCHAR(30) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(10) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    response_code INTEGER,
+    last_error TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS webhook_deliveries_webhook_idx ON webhook_deliveries (webhook_id, created_at DESC);
+
+-- Auth: hashed API keys and their roles.
+CREATE TABLE IF NOT EXISTS api_keys (
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    prefix VARCHAR(16) NOT NULL,
+    key_hash CHAR(64) NOT NULL UNIQUE,
+    role VARCHAR(10) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_used_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ
+);
+
 -- FinOps: Table for Unit Economics Tracking (FOCUS 1.0 Aligned)
 CREATE TABLE IF NOT EXISTS provider_unit_costs (
     time TIMESTAMPTZ NOT NULL,
@@ -38,10 +146,62 @@ CREATE TABLE IF NOT EXISTS provider_unit_costs (
 -- Convert to hypertables
 SELECT create_hypertable('rates', 'time', if_not_exists => TRUE);
 SELECT create_hypertable('provider_unit_costs', 'time', if_not_exists => TRUE);
+CREATE INDEX IF NOT EXISTS rates_cantor_currency_time_idx ON rates (cantor_id, currency, time DESC);
 
 -- FinOps: Data Retention Policies to control storage costs
 SELECT add_retention_policy('rates', INTERVAL '30 days');
 SELECT add_retention_policy('provider_unit_costs', INTERVAL '60 days');
 
+-- Raw rates older than a week are compressed. Hourly and daily rollups are maintained as
+-- continuous aggregates and kept after the raw data is dropped; their refresh windows stay well
+-- inside the raw retention so a refresh never erases rollups of dropped chunks.
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM timescaledb_information.hypertables
+        WHERE hypertable_name = 'rates' AND compression_enabled) THEN
+        ALTER TABLE rates SET (timescaledb.compress, timescaledb.compress_segmentby = 'cantor_id, currency',
+            timescaledb.compress_orderby = 'time DESC');
+    END IF;
+END $$;
+SELECT add_compression_policy('rates', INTERVAL '7 days', if_not_exists => TRUE);
+CREATE MATERIALIZED VIEW IF NOT EXISTS rates_hourly
+WITH (timescaledb.continuous, timescaledb.materialized_only = false) AS
+SELECT time_bucket('1 hour', time) AS bucket, cantor_id, currency,
+       AVG(buy_rate) AS buy_avg, first(buy_rate, time) AS buy_open, MAX(buy_rate) AS buy_high,
+       MIN(buy_rate) AS buy_low, last(buy_rate, time) AS buy_close,
+       AVG(sell_rate) AS sell_avg, first(sell_rate, time) AS sell_open, MAX(sell_rate) AS sell_high,
+       MIN(sell_rate) AS sell_low, last(sell_rate, time) AS sell_close
+FROM rates
+GROUP BY bucket, cantor_id, currency
+WITH NO DATA;
+SELECT add_continuous_aggregate_policy('rates_hourly', start_offset => INTERVAL '3 days',
+    end_offset => INTERVAL '1 hour', schedule_interval => INTERVAL '30 minutes', if_not_exists => TRUE);
+CREATE MATERIALIZED VIEW IF NOT EXISTS rates_daily
+WITH (timescaledb.continuous, timescaledb.materialized_only = false) AS
+SELECT time_bucket('1 day', time) AS bucket, cantor_id, currency,
+       AVG(buy_rate) AS buy_avg, first(buy_rate, time) AS buy_open, MAX(buy_rate) AS buy_high,
+       MIN(buy_rate) AS buy_low, last(buy_rate, time) AS buy_close,
+       AVG(sell_rate) AS sell_avg, first(sell_rate, time) AS sell_open, MAX(sell_rate) AS sell_high,
+       MIN(sell_rate) AS sell_low, last(sell_rate, time) AS sell_close
+FROM rates
+GROUP BY bucket, cantor_id, currency
+WITH NO DATA;
+SELECT add_continuous_aggregate_policy('rates_daily', start_offset => INTERVAL '7 days',
+    end_offset => INTERVAL '1 hour', schedule_interval => INTERVAL '30 minutes', if_not_exists => TRUE);
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM timescaledb_information.continuous_aggregates
+        WHERE view_name = 'rates_hourly' AND compression_enabled) THEN
+        ALTER MATERIALIZED VIEW rates_hourly SET (timescaledb.compress = true);
+    END IF;
+    IF NOT EXISTS (SELECT 1 FROM timescaledb_information.continuous_aggregates
+        WHERE view_name = 'rates_daily' AND compression_enabled) THEN
+        ALTER MATERIALIZED VIEW rates_daily SET (timescaledb.compress = true);
+    END IF;
+END $$;
+SELECT add_compression_policy('rates_hourly', compress_after => INTERVAL '30 days', if_not_exists => TRUE);
+SELECT add_compression_policy('rates_daily', compress_after => INTERVAL '90 days', if_not_exists => TRUE);
+SELECT add_retention_policy('rates_hourly', INTERVAL '400 days', if_not_exists => TRUE);
+
 -- Clean up data (optional, for development)
-TRUNCATE TABLE rates, cantors RESTART IDENTITY CASCADE;
+TRUNCATE TABLE rates, rate_heartbeats, rate_quarantine, alert_events, alert_rules, webhook_deliveries, webhooks, api_keys, cantors RESTART IDENTITY CASCADE;
@@ -0,0 +1,71 @@
+package api
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/Niutaq/Gix/internal/infrastructure"
+	"github.com/Niutaq/Gix/internal/services"
+	"github.com/Niutaq/Gix/pkg/auth"
+	"github.com/gin-gonic/gin"
+)
+
+// PrincipalKey is the gin context key under which the authenticated auth.Principal is stored.
+const PrincipalKey = "principal"
+
+// CredentialFromRequest returns the API key or JWT sent with a request, either as
+// "Authorization: Bearer <credential>" or in the X-API-Key header.
+func CredentialFromRequest(r *http.Request) string {
+	if token := auth.BearerToken(r.Header.Get("Authorization")); token != "" {
+		return token
+	}
+	return r.Header.Get("X-API-Key")
+}
+
+// Authenticate resolves the request credential, if any, into a principal. Anonymous requests pass
+// through; a credential that fails verification is rejected with 401 rather than silently ignored.
+func Authenticate(app *infrastructure.AppState) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		credential := CredentialFromRequest(c.Request)
+		if credential == "" {
+			c.Next()
+			return
+		}
+
+		principal, err := services.Authenticate(c.Request.Context(), app, credential)
+		if err != nil {
+			if !errors.Is(err, auth.ErrInvalidCredential) && !errors.Is(err, auth.ErrExpiredToken) {
+				log.Printf("Auth Error: %v", err)
+				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+				return
+			}
+			c.Header("WWW-Authenticate", `Bearer realm="gix", error="invalid_token"`)
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.Set(PrincipalKey, principal)
+		c.Request = c.Request.WithContext(auth.NewContext(c.Request.Context(), principal))
+		c.Next()
+	}
+}
+
+// RequireRole rejects callers below the given role with 401 (no credential) or 403 (insufficient
+// role). It runs after Authenticate, which resolves the principal.
+func RequireRole(role auth.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := auth.FromContext(c.Request.Context())
+		if !ok {
+			c.Header("WWW-Authenticate", `Bearer realm="gix"`)
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "authentication required"})
+			return
+		}
+
+		if !principal.Role.Allows(role) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "requires role " + string(role)})
+			return
+		}
+		c.Next()
+	}
+}
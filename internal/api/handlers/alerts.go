@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Niutaq/Gix/internal/infrastructure"
+	"github.com/Niutaq/Gix/internal/services"
+	"github.com/Niutaq/Gix/pkg/alerts"
+	"github.com/gin-gonic/gin"
+)
+
+// HandleCreateAlert godoc
+// @Summary      Create Alert Rule
+// @Description  Registers a rule (threshold above/below or percent move) evaluated on every published rate. The rule belongs to the caller; a given owner must be the caller's own.
+// @Tags         alerts
+// @Accept       json
+// @Produce      json
+// @Param        rule  body      alerts.Rule  true  "Alert rule"
+// @Success      201  {object}  alerts.Rule
+// @Failure      400  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /alerts [post]
+func HandleCreateAlert(app *infrastructure.AppState) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var rule alerts.Rule
+		if err := c.ShouldBindJSON(&rule); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+			return
+		}
+		owner, ok := alertOwner(c, rule.Owner)
+		if !ok {
+			return
+		}
+		rule.Owner = owner
+		if err := rule.Validate(); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		created, err := services.CreateAlertRule(c.Request.Context(), app, rule)
+		if err != nil {
+			log.Printf("Alert Create Error: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": internalServerError})
+			return
+		}
+		c.JSON(http.StatusCreated, created)
+	}
+}
+
+// HandleListAlerts godoc
+// @Summary      List Alert Rules
+// @Description  Returns the alert rules of the caller.
+// @Tags         alerts
+// @Produce      json
+// @Param        owner  query     string  false  "Owner identifier; must be the caller's own"
+// @Success      200  {array}   alerts.Rule
+// @Failure      403  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /alerts [get]
+func HandleListAlerts(app *infrastructure.AppState) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		owner, ok := alertOwner(c, c.Query("owner"))
+		if !ok {
+			return
+		}
+
+		rules, err := services.ListAlertRules(c.Request.Context(), app.DB, owner)
+		if err != nil {
+			log.Printf("Alert List Error: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": internalServerError})
+			return
+		}
+		c.JSON(http.StatusOK, rules)
+	}
+}
+
+// HandleDeleteAlert godoc
+// @Summary      Delete Alert Rule
+// @Description  Deletes an alert rule and its fired events.
+// @Tags         alerts
+// @Produce      json
+// @Param        id     path      int     true   "Rule ID"
+// @Param        owner  query     string  false  "Owner identifier; must be the caller's own"
+// @Success      200  {object}  map[string]string
+// @Failure      400  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /alerts/{id} [delete]
+func HandleDeleteAlert(app *infrastructure.AppState) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid rule ID"})
+			return
+		}
+		owner, ok := alertOwner(c, c.Query("owner"))
+		if !ok {
+			return
+		}
+
+		err = services.DeleteAlertRule(c.Request.Context(), app, id, owner)
+		if errors.Is(err, services.ErrAlertRuleNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err != nil {
+			log.Printf("Alert Delete Error: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": internalServerError})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"status": "deleted"})
+	}
+}
+
+// HandleListAlertEvents godoc
+// @Summary      List Fired Alerts
+// @Description  Returns alert events fired for the caller, newest first.
+// @Tags         alerts
+// @Produce      json
+// @Param        owner  query     string  false  "Owner identifier; must be the caller's own"
+// @Param        since  query     int     false  "Unix time; defaults to 7 days ago"
+// @Param        limit  query     int     false  "Maximum number of events (default 100)"
+// @Success      200  {array}   alerts.Event
+// @Failure      400  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /alerts/events [get]
+func HandleListAlertEvents(app *infrastructure.AppState) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		owner, ok := alertOwner(c, c.Query("owner"))
+		if !ok {
+			return
+		}
+
+		since := time.Now().AddDate(0, 0, -7)
+		if sinceStr := c.Query("since"); sinceStr != "" {
+			unix, err := strconv.ParseInt(sinceStr, 10, 64)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid since"})
+				return
+			}
+			since = time.Unix(unix, 0)
+		}
+
+		limit, err := strconv.Atoi(c.DefaultQuery("limit", "100"))
+		if err != nil || limit <= 0 || limit > 1000 {
+			limit = 100
+		}
+
+		events, err := services.ListAlertEvents(c.Request.Context(), app.DB, owner, since, limit)
+		if err != nil {
+			log.Printf("Alert Events Error: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": internalServerError})
+			return
+		}
+		c.JSON(http.StatusOK, events)
+	}
+}
+
+// alertOwner resolves the caller's alert owner, answering 403 when the requested owner is someone else's.
+func alertOwner(c *gin.Context, requested string) (string, bool) {
+	owner, err := services.AlertOwner(c.Request.Context(), requested)
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return "", false
+	}
+	return owner, true
+}
@@ -5,97 +5,176 @@ import (
 	"log"
 	"net/http"
 	"strconv"
-	"strings"
 
 	"github.com/Niutaq/Gix/internal/infrastructure"
+	"github.com/Niutaq/Gix/internal/services"
 	"github.com/gin-gonic/gin"
-	"github.com/jackc/pgx/v5"
 )
 
 const internalServerError = "Internal server error"
 
 // HandleCantorsList godoc
 // @Summary      List Cantors
-// @Description  Returns a list of all available cantors with their geolocations.
+// @Description  Returns a page of enabled cantors with their geolocations. Results can be narrowed to a radius around a point (near, radius_km), a map viewport (bbox), cantors quoting a currency, or a text match, and sorted by distance or by the best buy/sell rate. When more results exist the X-Next-Cursor header carries the cursor of the next page. Operators can pass all=true to include disabled cantors.
 // @Tags         cantors
 // @Produce      json
+// @Param        near       query     string  false  "Reference point as lat,lon"
+// @Param        radius_km  query     number  false  "Search radius around near, in kilometres"
+// @Param        bbox       query     string  false  "Viewport as minLat,minLon,maxLat,maxLon"
+// @Param        currency   query     string  false  "Only cantors quoting this currency; adds buy and sell to each item"
+// @Param        q          query     string  false  "Text filter on name and address"
+// @Param        sort       query     string  false  "Sort order"  Enums(id, name, distance, buy, sell)
+// @Param        limit      query     int     false  "Page size (default 200, max 1000)"
+// @Param        cursor     query     string  false  "Cursor from a previous X-Next-Cursor header"
+// @Param        all        query     bool    false  "Include disabled cantors (operator role)"
 // @Success      200  {array}   infrastructure.CantorListResponse
+// @Header       200  {string}  X-Next-Cursor  "Cursor of the next page, absent on the last page"
+// @Failure      400  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
 // @Router       /cantors [get]
 func HandleCantorsList(app *infrastructure.AppState) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		rows, err := app.DB.Query(c.Request.Context(), "SELECT id, display_name, name, latitude, longitude, strategy, COALESCE(address, '') FROM cantors")
+		query, err := services.NewCantorQuery(c.Request.URL.Query())
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		query.IncludeDisabled = c.Query("all") == "true"
+
+		cantors, next, err := services.ListCantors(c.Request.Context(), app.DB, query)
+		if errors.Is(err, services.ErrInvalidCantorQuery) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, services.ErrCantorListForbidden) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
 		if err != nil {
 			log.Printf("DB Error: %v", err)
 			c.JSON(http.StatusInternalServerError, gin.H{"error": internalServerError})
 			return
 		}
-		defer rows.Close()
 
-		var cantors []infrastructure.CantorListResponse
-		for rows.Next() {
-			var cr infrastructure.CantorListResponse
-			if err := rows.Scan(&cr.ID, &cr.DisplayName, &cr.Name, &cr.Latitude, &cr.Longitude, &cr.Strategy, &cr.Address); err != nil {
-				log.Printf("Scan Error: %v", err)
-				c.JSON(http.StatusInternalServerError, gin.H{"error": internalServerError})
-				return
-			}
-			cantors = append(cantors, cr)
+		if next != "" {
+			c.Header("X-Next-Cursor", next)
 		}
 		c.JSON(http.StatusOK, cantors)
 	}
 }
 
-// HandleDeleteCantor godoc
-// @Summary      Delete Cantor
-// @Description  Deletes a discovered cantor from the database by ID.
+// HandleCreateCantor godoc
+// @Summary      Create Cantor
+// @Description  Adds a cantor. The base URL must be publicly reachable, the strategy must be a registered scraper and the coordinates must be in range. Origin defaults to partner; system is reserved for curated cantors.
 // @Tags         cantors
-// @Param        id   path      int  true  "Cantor ID"
+// @Accept       json
 // @Produce      json
-// @Success      200  {object}  map[string]string
+// @Param        cantor  body      infrastructure.CantorInput  true  "Cantor"
+// @Success      201  {object}  infrastructure.Cantor
 // @Failure      400  {object}  map[string]string
-// @Failure      403  {object}  map[string]string
-// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
-// @Router       /cantors/{id} [delete]
-func HandleDeleteCantor(app *infrastructure.AppState) gin.HandlerFunc {
+// @Security     BearerAuth
+// @Router       /cantors [post]
+func HandleCreateCantor(app *infrastructure.AppState) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		idStr := c.Param("id")
-		id, err := strconv.Atoi(idStr)
+		var in infrastructure.CantorInput
+		if err := c.ShouldBindJSON(&in); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+			return
+		}
+
+		created, err := services.CreateCantor(c.Request.Context(), app, in)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cantor ID"})
+			respondCantorError(c, err, "Cantor Create Error")
 			return
 		}
+		c.JSON(http.StatusCreated, created)
+	}
+}
 
-		var strategy string
-		err = app.DB.QueryRow(c.Request.Context(), "SELECT strategy FROM cantors WHERE id = $1", id).Scan(&strategy)
+// HandleUpdateCantor godoc
+// @Summary      Update Cantor
+// @Description  Partially updates a cantor; omitted fields keep their value. The origin cannot be set to system. Setting enabled=false stops harvesting and hides the cantor from listings and search.
+// @Tags         cantors
+// @Accept       json
+// @Produce      json
+// @Param        id      path      int                         true  "Cantor ID"
+// @Param        cantor  body      infrastructure.CantorInput  true  "Fields to change"
+// @Success      200  {object}  infrastructure.Cantor
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /cantors/{id} [patch]
+func HandleUpdateCantor(app *infrastructure.AppState) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
 		if err != nil {
-			if errors.Is(err, pgx.ErrNoRows) {
-				c.JSON(http.StatusNotFound, gin.H{"error": "cantor not found"})
-				return
-			}
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "database error"})
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cantor ID"})
 			return
 		}
 
-		if !strings.HasPrefix(strategy, "H") && strategy != "HEURISTIC" {
-			c.JSON(http.StatusForbidden, gin.H{"error": "cannot delete default system cantors"})
+		var in infrastructure.CantorInput
+		if err := c.ShouldBindJSON(&in); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
 			return
 		}
 
-		_, _ = app.DB.Exec(c.Request.Context(), "DELETE FROM rates WHERE cantor_id = $1", id)
-		res, err := app.DB.Exec(c.Request.Context(), "DELETE FROM cantors WHERE id = $1", id)
+		updated, err := services.UpdateCantor(c.Request.Context(), app, id, in)
 		if err != nil {
-			log.Printf("DB Delete Cantor Error: %v", err)
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete cantor"})
+			respondCantorError(c, err, "Cantor Update Error")
 			return
 		}
+		c.JSON(http.StatusOK, updated)
+	}
+}
 
-		if res.RowsAffected() == 0 {
-			c.JSON(http.StatusNotFound, gin.H{"error": "cantor not found"})
+// HandleDeleteCantor godoc
+// @Summary      Delete Cantor
+// @Description  Deletes a discovered or partner cantor from the database by ID. System cantors cannot be deleted.
+// @Tags         cantors
+// @Param        id   path      int  true  "Cantor ID"
+// @Produce      json
+// @Success      200  {object}  map[string]string
+// @Failure      400  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /cantors/{id} [delete]
+func HandleDeleteCantor(app *infrastructure.AppState) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cantor ID"})
 			return
 		}
 
+		if err := services.DeleteCantor(c.Request.Context(), app, id); err != nil {
+			respondCantorError(c, err, "DB Delete Cantor Error")
+			return
+		}
 		c.JSON(http.StatusOK, gin.H{"status": "deleted"})
 	}
 }
+
+// respondCantorError maps cantor service errors to HTTP statuses.
+func respondCantorError(c *gin.Context, err error, logPrefix string) {
+	switch {
+	case errors.Is(err, services.ErrInvalidCantor):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrCantorNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrCantorProtected):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrCantorConflict):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		log.Printf("%s: %v", logPrefix, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": internalServerError})
+	}
+}
@@ -1,9 +1,11 @@
 package handlers
 
 import (
+	"log"
 	"net/http"
 
 	"github.com/Niutaq/Gix/internal/infrastructure"
+	"github.com/Niutaq/Gix/pkg/streams"
 	"github.com/gin-gonic/gin"
 )
 
@@ -12,7 +14,7 @@ import (
 // @Description  Checks if the server, database, and Redis are running correctly.
 // @Tags         health
 // @Produce      json
-// @Success      200  {object}  map[string]string
+// @Success      200  {object}  map[string]any
 // @Failure      503  {object}  map[string]string
 // @Router       /healthz [get]
 func HandleHealthCheck(app *infrastructure.AppState) gin.HandlerFunc {
@@ -25,6 +27,23 @@ func HandleHealthCheck(app *infrastructure.AppState) gin.HandlerFunc {
 			c.JSON(http.StatusServiceUnavailable, gin.H{"status": "error", "service": "cache"})
 			return
 		}
-		c.JSON(http.StatusOK, gin.H{"status": "ok", "message": "Gix is alive."})
+		c.JSON(http.StatusOK, gin.H{"status": "ok", "message": "Gix is alive.", "streams": streams.Stats.GetSummary()})
+	}
+}
+
+// HandleMetrics godoc
+// @Summary      Metrics
+// @Description  Returns the rate stream metrics (connected clients, send latency, coalesced and dropped messages and slow disconnects) in the Prometheus text format.
+// @Tags         health
+// @Produce      plain
+// @Success      200  {string}  string
+// @Router       /metrics [get]
+func HandleMetrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		c.Status(http.StatusOK)
+		if err := streams.Stats.WritePrometheus(c.Writer); err != nil {
+			log.Printf("Metrics Error: %v", err)
+		}
 	}
 }
@@ -1,16 +1,16 @@
 package handlers
 
 import (
+	"fmt"
 	"log"
-	"math"
 	"net/http"
 	"strconv"
 	"time"
 
-	pb "github.com/Niutaq/Gix/api/proto/v1"
 	"github.com/Niutaq/Gix/internal/infrastructure"
+	"github.com/Niutaq/Gix/internal/services"
+	"github.com/Niutaq/Gix/pkg/export"
 	"github.com/gin-gonic/gin"
-	"github.com/jackc/pgx/v5"
 )
 
 func HandleGetHistory(app *infrastructure.AppState) gin.HandlerFunc {
@@ -21,96 +21,77 @@ func HandleGetHistory(app *infrastructure.AppState) gin.HandlerFunc {
 			return
 		}
 
-		params := parseHistoryParams(c)
-		query, args := buildHistoryQuery(currency, params)
-		rows, err := app.DB.Query(c.Request.Context(), query, args...)
-
+		params, err := parseHistoryParams(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		buckets, err := services.FetchHistory(c.Request.Context(), app.DB, currency, params)
 		if err != nil {
 			log.Printf("History DB Error: %v", err)
 			c.JSON(http.StatusInternalServerError, gin.H{"error": internalServerError})
 			return
 		}
 
-		defer rows.Close()
-		points := scanHistoryPoints(rows)
-		sendHistoryResponse(c, currency, points)
+		sendProtoOrJSON(c, services.HistoryToV1(currency, params, buckets))
 	}
 }
 
-func parseHistoryParams(c *gin.Context) infrastructure.HistoryParams {
-	cantorIDStr := c.Query("cantor_id")
-	daysStr := c.DefaultQuery("days", "7")
-	days, _ := strconv.Atoi(daysStr)
-
-	if days <= 0 {
-		days = 7
+// parseHistoryParams reads days (1 to services.MaxHistoryDays), cantor_id, interval (5m, 15m, 1h, 6h,
+// 1d; chosen from the range when omitted) and agg (avg, ohlc, min, max, last).
+func parseHistoryParams(c *gin.Context) (infrastructure.HistoryParams, error) {
+	days, err := strconv.Atoi(c.DefaultQuery("days", "7"))
+	if err != nil || days < 1 {
+		return infrastructure.HistoryParams{}, fmt.Errorf("days must be between 1 and %d", services.MaxHistoryDays)
 	}
-
-	cantorID, _ := strconv.Atoi(cantorIDStr)
-
-	return infrastructure.HistoryParams{
-		CantorID: cantorID,
-		Days:     days,
-		Cutoff:   time.Now().AddDate(0, 0, -days),
-	}
-}
-
-func buildHistoryQuery(currency string, params infrastructure.HistoryParams) (string, []interface{}) {
-	var query string
-	var args []interface{}
-	args = append(args, currency, params.Cutoff)
-	if params.CantorID > 0 {
-		query = `
-				SELECT time_bucket('1 hour', time) AS bucket,
-					   AVG(buy_rate)::FLOAT,
-					   AVG(sell_rate)::FLOAT
-				FROM rates
-				WHERE currency = $1 AND time > $2 AND cantor_id = $3
-				GROUP BY bucket
-				ORDER BY bucket ASC`
-		args = append(args, params.CantorID)
-	} else {
-		query = `
-				SELECT time_bucket('1 hour', time) AS bucket,
-					   AVG(buy_rate)::FLOAT,
-					   AVG(sell_rate)::FLOAT
-				FROM rates
-				WHERE currency = $1 AND time > $2
-				GROUP BY bucket
-				ORDER BY bucket ASC`
-	}
-	return query, args
+	cantorID, _ := strconv.Atoi(c.Query("cantor_id"))
+	params := services.NewHistoryParams(cantorID, days)
+	err = services.SetHistoryResolution(&params, c.Query("interval"), c.Query("agg"))
+	return params, err
 }
 
-func scanHistoryPoints(rows pgx.Rows) []*pb.HistoryPoint {
-	var points []*pb.HistoryPoint
-	for rows.Next() {
-		var t time.Time
-		var buy, sell float64
-
-		if err := rows.Scan(&t, &buy, &sell); err != nil {
-			log.Printf("History Scan Error: %v", err)
-			continue
+// HandleExportHistory godoc
+// @Summary      Export History
+// @Description  Streams raw change points or per-cantor buckets for a set of currencies and cantors over a time range as CSV, NDJSON or Parquet. Ranges are limited to 400 days and exports have their own hourly quota. Raw change points are kept for 30 days, so older ranges need an interval; they are read from the hourly rollup, or the daily one beyond 400 days.
+// @Tags         history
+// @Produce      text/csv
+// @Param        currencies  query     string  false  "Comma-separated currency codes; all when omitted"
+// @Param        cantor_ids  query     string  false  "Comma-separated cantor IDs; all when omitted"
+// @Param        from        query     string  false  "Start (RFC 3339 or YYYY-MM-DD); defaults to a week before to"
+// @Param        to          query     string  false  "End (RFC 3339 or YYYY-MM-DD); defaults to now"
+// @Param        interval    query     string  false  "raw (default), 5m, 15m, 1h, 6h or 1d"
+// @Param        agg         query     string  false  "Bucket aggregation: avg, ohlc, min, max or last"
+// @Param        format      query     string  false  "csv (default), ndjson or parquet"
+// @Success      200  {file}    file
+// @Failure      400  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /history/export [get]
+func HandleExportHistory(app *infrastructure.AppState) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		req, err := services.NewExportRequest(c.Query("currencies"), c.Query("cantor_ids"), c.Query("from"), c.Query("to"),
+			c.Query("interval"), c.Query("agg"), c.Query("format"), time.Now())
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
 		}
 
-		points = append(points, &pb.HistoryPoint{
-			Time:     t.Unix(),
-			BuyRate:  int64(math.Round(buy * infrastructure.MoneyMultiplier)),
-			SellRate: int64(math.Round(sell * infrastructure.MoneyMultiplier)),
-		})
-	}
-	return points
-}
-
-func sendHistoryResponse(c *gin.Context, currency string, points []*pb.HistoryPoint) {
-	resp := &pb.HistoryResponse{
-		Points:   points,
-		Currency: currency,
-	}
+		filename := fmt.Sprintf("gix-history-%s-%s.%s", req.From.Format("20060102"), req.To.Format("20060102"), req.Format)
+		c.Header("Content-Type", export.ContentType(req.Format))
+		c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+		c.Status(http.StatusOK)
 
-	if c.GetHeader("Accept") == contentTypeProtoBuf {
-		c.ProtoBuf(http.StatusOK, resp)
-	} else {
-		c.JSON(http.StatusOK, resp)
+		w, err := export.NewWriter(c.Writer, req.Format, req.Agg == services.AggOHLC)
+		if err != nil {
+			log.Printf("Export Writer Error: %v", err)
+			return
+		}
+		// Headers are already sent, so a failure midway can only cut the stream short.
+		rows, err := services.ExportHistory(c.Request.Context(), app.DB, req, w)
+		if err == nil {
+			err = w.Close()
+		}
+		if err != nil {
+			log.Printf("Export Error after %d rows: %v", rows, err)
+		}
 	}
 }
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/Niutaq/Gix/internal/infrastructure"
+	"github.com/Niutaq/Gix/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+// HandleListQuarantine godoc
+// @Summary      List Quarantined Rates
+// @Description  Returns scraped rates held back by anomaly detection, newest first.
+// @Tags         admin
+// @Produce      json
+// @Param        status  query     string  false  "pending (default), released or rejected"
+// @Param        limit   query     int     false  "Maximum number of entries (default 100)"
+// @Success      200  {array}   infrastructure.QuarantinedRate
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /admin/quarantine [get]
+func HandleListQuarantine(app *infrastructure.AppState) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		status := c.DefaultQuery("status", services.QuarantinePending)
+		switch status {
+		case services.QuarantinePending, services.QuarantineReleased, services.QuarantineRejected:
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid status"})
+			return
+		}
+
+		limit, err := strconv.Atoi(c.DefaultQuery("limit", "100"))
+		if err != nil || limit <= 0 || limit > 1000 {
+			limit = 100
+		}
+
+		entries, err := services.ListQuarantine(c.Request.Context(), app.DB, status, limit)
+		if err != nil {
+			log.Printf("Quarantine DB Error: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": internalServerError})
+			return
+		}
+		c.JSON(http.StatusOK, entries)
+	}
+}
+
+// HandleReleaseQuarantine godoc
+// @Summary      Release Quarantined Rate
+// @Description  Accepts a quarantined rate, archiving and publishing it as a regular update.
+// @Tags         admin
+// @Produce      json
+// @Param        id   path      int  true  "Quarantine entry ID"
+// @Success      200  {object}  infrastructure.QuarantinedRate
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /admin/quarantine/{id}/release [post]
+func HandleReleaseQuarantine(app *infrastructure.AppState) gin.HandlerFunc {
+	return resolveQuarantine(app, true)
+}
+
+// HandleRejectQuarantine godoc
+// @Summary      Reject Quarantined Rate
+// @Description  Discards a quarantined rate. The entry is kept for auditing.
+// @Tags         admin
+// @Produce      json
+// @Param        id   path      int  true  "Quarantine entry ID"
+// @Success      200  {object}  infrastructure.QuarantinedRate
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /admin/quarantine/{id}/reject [post]
+func HandleRejectQuarantine(app *infrastructure.AppState) gin.HandlerFunc {
+	return resolveQuarantine(app, false)
+}
+
+func resolveQuarantine(app *infrastructure.AppState, release bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid quarantine ID"})
+			return
+		}
+
+		entry, err := services.ResolveQuarantine(c.Request.Context(), app, id, release)
+		if errors.Is(err, services.ErrQuarantineNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err != nil {
+			log.Printf("Quarantine DB Error: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": internalServerError})
+			return
+		}
+		c.JSON(http.StatusOK, entry)
+	}
+}
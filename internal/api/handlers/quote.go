@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/Niutaq/Gix/internal/infrastructure"
+	"github.com/Niutaq/Gix/internal/services"
+	"github.com/Niutaq/Gix/pkg/money"
+	"github.com/gin-gonic/gin"
+)
+
+// HandleGetQuote godoc
+// @Summary      Best-Deal Quote
+// @Description  Ranks cantors by the PLN amount of exchanging the given amount, best first. Stale rates rank last; an optional per-km penalty favours nearby cantors.
+// @Tags         rates
+// @Produce      json
+// @Param        currency  query     string  true   "Currency Code (e.g., EUR, USD)"
+// @Param        amount    query     string  true   "Amount of currency to exchange, at most 10000000"
+// @Param        side      query     string  false  "sell (default): you sell the currency for PLN; buy: you buy it with PLN"
+// @Param        lat       query     number  false  "Latitude of the user"
+// @Param        lon       query     number  false  "Longitude of the user"
+// @Param        radius    query     number  false  "Maximum distance in km (requires lat/lon)"
+// @Param        penalty   query     string  false  "PLN per km subtracted from the result (added to the cost when buying)"
+// @Success      200  {object}  pb.QuoteResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /quote [get]
+func HandleGetQuote(app *infrastructure.AppState) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		req, err := parseQuoteParams(c)
+		if err == nil {
+			err = services.ValidateQuoteRequest(&req)
+		}
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		quotes, err := services.GetQuotes(c.Request.Context(), app.DB, req)
+		if err != nil {
+			log.Printf("Quote DB Error: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": internalServerError})
+			return
+		}
+
+		sendProtoOrJSON(c, services.QuotesToProto(req, quotes))
+	}
+}
+
+func parseQuoteParams(c *gin.Context) (infrastructure.QuoteRequest, error) {
+	req := infrastructure.QuoteRequest{
+		Currency: c.Query("currency"),
+		Side:     c.Query("side"),
+	}
+
+	amount, err := money.Parse(c.Query("amount"))
+	if err != nil {
+		return req, fmt.Errorf("invalid amount")
+	}
+	req.Amount = amount
+
+	if latStr, lonStr := c.Query("lat"), c.Query("lon"); latStr != "" || lonStr != "" {
+		lat, errLat := strconv.ParseFloat(latStr, 64)
+		lon, errLon := strconv.ParseFloat(lonStr, 64)
+		if errLat != nil || errLon != nil {
+			return req, fmt.Errorf("invalid lat/lon")
+		}
+		req.Lat, req.Lon, req.HasLocation = lat, lon, true
+	}
+
+	if radiusStr := c.Query("radius"); radiusStr != "" {
+		if req.RadiusKm, err = strconv.ParseFloat(radiusStr, 64); err != nil {
+			return req, fmt.Errorf("invalid radius")
+		}
+	}
+
+	if penaltyStr := c.Query("penalty"); penaltyStr != "" {
+		if req.DistancePenalty, err = money.Parse(penaltyStr); err != nil {
+			return req, fmt.Errorf("invalid penalty")
+		}
+	}
+	return req, nil
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/Niutaq/Gix/internal/infrastructure"
+	"github.com/Niutaq/Gix/pkg/ratelimit"
+	"github.com/gin-gonic/gin"
+)
+
+// rateLimitQuota is the JSON form of a ratelimit.Quota.
+type rateLimitQuota struct {
+	Limit  int    `json:"limit"`
+	Window string `json:"window"`
+}
+
+// HandleGetRateLimits godoc
+// @Summary      List Rate Limits
+// @Description  Returns the effective quota of every route class (read, rates, discover, write, stream).
+// @Tags         admin
+// @Produce      json
+// @Success      200  {object}  map[string]rateLimitQuota
+// @Failure      503  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /admin/ratelimits [get]
+func HandleGetRateLimits(app *infrastructure.AppState) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if app.Limiter == nil {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "rate limiting is disabled"})
+			return
+		}
+
+		quotas := make(map[string]rateLimitQuota)
+		for class, q := range app.Limiter.Quotas() {
+			quotas[class] = rateLimitQuota{Limit: q.Limit, Window: q.Window.String()}
+		}
+		c.JSON(http.StatusOK, quotas)
+	}
+}
+
+// HandleSetRateLimit godoc
+// @Summary      Set Rate Limit
+// @Description  Overrides the quota of a route class. The override is stored in Redis and picked up by every replica within a minute. A limit of 0 disables limiting for the class.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        class  path      string          true  "Route class"
+// @Param        quota  body      rateLimitQuota  true  "Quota, e.g. {\"limit\": 60, \"window\": \"1m\"}"
+// @Success      200  {object}  rateLimitQuota
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /admin/ratelimits/{class} [put]
+func HandleSetRateLimit(app *infrastructure.AppState) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if app.Limiter == nil {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "rate limiting is disabled"})
+			return
+		}
+
+		class := c.Param("class")
+		if _, ok := ratelimit.DefaultQuotas[class]; !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "unknown route class"})
+			return
+		}
+
+		var req rateLimitQuota
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+			return
+		}
+		window, err := time.ParseDuration(req.Window)
+		if err != nil || window < time.Second || req.Limit < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be >= 0 and window a duration of at least 1s"})
+			return
+		}
+
+		if err := app.Limiter.SetQuota(c.Request.Context(), class, ratelimit.Quota{Limit: req.Limit, Window: window}); err != nil {
+			log.Printf("Rate Limit Update Error: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": internalServerError})
+			return
+		}
+		c.JSON(http.StatusOK, rateLimitQuota{Limit: req.Limit, Window: window.String()})
+	}
+}
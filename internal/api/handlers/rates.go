@@ -6,13 +6,13 @@ import (
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
-	pb "github.com/Niutaq/Gix/api/proto/v1"
 	"github.com/Niutaq/Gix/internal/infrastructure"
 	"github.com/Niutaq/Gix/internal/services"
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5"
-	"github.com/redis/go-redis/v9"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -20,7 +20,7 @@ const contentTypeProtoBuf = "application/x-protobuf"
 
 // HandleGetRates godoc
 // @Summary      Get Rates
-// @Description  Returns the buy and sell rates for a specific cantor and currency. Scrapes data in real-time if not cached.
+// @Description  Returns the buy and sell rates for a specific cantor and currency. On a cache miss the last known value is served with stale=true while a background refresh runs; concurrent misses share a single scrape. If the scrape fails the latest archived value is returned with stale=true.
 // @Tags         rates
 // @Produce      json
 // @Param        cantor_id  query     int     true  "Cantor ID"
@@ -29,6 +29,7 @@ const contentTypeProtoBuf = "application/x-protobuf"
 // @Failure      400  {object}  map[string]string
 // @Failure      404  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
+// @Failure      502  {object}  map[string]string
 // @Router       /rates [get]
 func HandleGetRates(app *infrastructure.AppState) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -38,33 +39,49 @@ func HandleGetRates(app *infrastructure.AppState) gin.HandlerFunc {
 			return
 		}
 
-		ctx := c.Request.Context()
-		cacheKey := fmt.Sprintf("rates:proto%d:%s", cantorID, currency)
-
-		if respondFromCache(c, app.Cache, cacheKey) {
+		response, err := services.GetRate(c.Request.Context(), app, cantorID, currency)
+		switch {
+		case errors.Is(err, services.ErrRateQuarantined):
+			c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+			return
+		case errors.Is(err, services.ErrRateUnavailable):
+			c.JSON(http.StatusBadGateway, gin.H{"error": services.ErrRateUnavailable.Error()})
+			return
+		case err != nil:
+			handleDBError(c, err)
 			return
 		}
 
-		cantorInfo, err := services.FetchCantorInfo(ctx, app.DB, cantorID)
+		sendProtoOrJSON(c, response)
+	}
+}
+
+// HandleGetLatestRates godoc
+// @Summary      Latest Rates Snapshot
+// @Description  Returns the latest rates of every cantor for several currencies at once, as a cantor x currency matrix with fetch time, 24h change and a stale flag for observations older than an hour. Omitting currencies selects every supported currency.
+// @Tags         rates
+// @Produce      json
+// @Param        currencies  query     string  false  "Comma-separated currency codes (e.g., EUR,USD)"
+// @Success      200  {object}  pb.SnapshotResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /rates/latest [get]
+func HandleGetLatestRates(app *infrastructure.AppState) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currencies, err := services.ParseCurrencies(strings.Split(c.Query("currencies"), ","))
 		if err != nil {
-			handleDBError(c, err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
 
-		response, rates, err := services.ScrapeAndProcess(ctx, app, cantorInfo, cantorID, currency)
+		snapshot, err := services.FetchLatestSnapshot(c.Request.Context(), app.DB, currencies)
 		if err != nil {
-			log.Printf("Processing Error (%s): %v", cantorInfo.Strategy, err)
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			log.Printf("Snapshot DB Error: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": internalServerError})
 			return
 		}
 
-		services.CacheAndArchive(ctx, app, cacheKey, cantorID, currency, response, rates)
-
-		if c.GetHeader("Accept") == contentTypeProtoBuf {
-			c.ProtoBuf(http.StatusOK, response)
-		} else {
-			c.JSON(http.StatusOK, response)
-		}
+		sendProtoOrJSON(c, services.SnapshotToV1(currencies, snapshot, time.Now()))
 	}
 }
 
@@ -84,20 +101,13 @@ func parseRateParams(c *gin.Context) (int, string, error) {
 	return cantorID, currency, nil
 }
 
-func respondFromCache(c *gin.Context, cache redis.UniversalClient, key string) bool {
-	if cachedBytes, err := cache.Get(c.Request.Context(), key).Bytes(); err == nil {
-		var cachedRate pb.RateResponse
-		if err := proto.Unmarshal(cachedBytes, &cachedRate); err == nil {
-			if c.GetHeader("Accept") == contentTypeProtoBuf {
-				c.ProtoBuf(http.StatusOK, &cachedRate)
-			} else {
-				c.JSON(http.StatusOK, &cachedRate)
-			}
-			return true
-		}
-		log.Printf("Unmarshal Error: %v", err)
+// sendProtoOrJSON writes resp as protobuf when the client asks for it and as JSON otherwise.
+func sendProtoOrJSON(c *gin.Context, resp proto.Message) {
+	if c.GetHeader("Accept") == contentTypeProtoBuf {
+		c.ProtoBuf(http.StatusOK, resp)
+	} else {
+		c.JSON(http.StatusOK, resp)
 	}
-	return false
 }
 
 func handleDBError(c *gin.Context, err error) {
@@ -0,0 +1,217 @@
+package handlers
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	pb "github.com/Niutaq/Gix/api/proto/v1"
+	"github.com/Niutaq/Gix/internal/infrastructure"
+	"github.com/Niutaq/Gix/internal/services"
+	"github.com/coder/websocket"
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/protobuf/proto"
+)
+
+// streamWriteTimeout bounds a single event or frame write, so a stalled client is dropped instead
+// of holding its subscription open.
+const streamWriteTimeout = 10 * time.Second
+
+// rateStreamWriter encodes stream frames for one HTTP transport.
+type rateStreamWriter interface {
+	Rate(ctx context.Context, rate *pb.RateResponse) error
+	Heartbeat(ctx context.Context, now time.Time) error
+}
+
+// HandleStream godoc
+// @Summary      Stream Rates
+// @Description  Streams rate updates over Server-Sent Events, or over WebSocket when the request is a WebSocket upgrade. Updates are filtered by currency like the StreamRates RPC. SSE events are "rate" (JSON, or base64 protobuf with format=protobuf) with the resume token as event ID, and "heartbeat" every 15 seconds. WebSocket frames are StreamEvent messages, as JSON text or protobuf binary frames. Reconnecting with Last-Event-ID or resume replays the latest rates observed since that token.
+// @Tags         rates
+// @Produce      text/event-stream
+// @Param        currencies  query     string  false  "Comma-separated currency codes; all when omitted"
+// @Param        format      query     string  false  "json (default) or protobuf"
+// @Param        resume      query     string  false  "Resume token of the last received update (SSE clients may send Last-Event-ID instead)"
+// @Success      200  {object}  pb.StreamEvent
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /stream [get]
+func HandleStream(app *infrastructure.AppState) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currencies, err := services.ParseCurrencies(strings.Split(c.Query("currencies"), ","))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		var since time.Time
+		token := c.Query("resume")
+		if token == "" {
+			token = c.GetHeader("Last-Event-ID")
+		}
+		if token != "" {
+			if since, err = services.ParseResumeToken(token); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+		}
+		binary := c.Query("format") == "protobuf" || c.GetHeader("Accept") == contentTypeProtoBuf
+
+		// Subscribe before reading the backlog so no update falls between the two; a rate sent twice
+		// is harmless to a client keyed by cantor and currency.
+		ctx := c.Request.Context()
+		pubsub := app.Cache.Subscribe(ctx, services.RatesUpdatesChannel)
+		defer func() { _ = pubsub.Close() }()
+		if _, err := pubsub.Receive(ctx); err != nil {
+			log.Printf("Stream Subscribe Error: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": internalServerError})
+			return
+		}
+		var backlog []*pb.RateResponse
+		if !since.IsZero() {
+			if backlog, err = services.RatesSince(ctx, app.DB, currencies, since); err != nil {
+				log.Printf("Stream Resume DB Error: %v", err)
+				c.JSON(http.StatusInternalServerError, gin.H{"error": internalServerError})
+				return
+			}
+		}
+
+		var w rateStreamWriter
+		if strings.EqualFold(c.GetHeader("Upgrade"), "websocket") {
+			// Credentials travel in headers, never cookies, so any origin may subscribe.
+			conn, err := websocket.Accept(c.Writer, c.Request, &websocket.AcceptOptions{InsecureSkipVerify: true})
+			if err != nil {
+				log.Printf("WebSocket Accept Error: %v", err)
+				return
+			}
+			defer func() { _ = conn.CloseNow() }()
+			// Client frames are not expected; reading them handles pings and cancels ctx on close.
+			ctx = conn.CloseRead(ctx)
+			w = &wsStreamWriter{conn: conn, binary: binary}
+		} else {
+			c.Header("Content-Type", "text/event-stream")
+			c.Header("Cache-Control", "no-cache")
+			c.Header("X-Accel-Buffering", "no")
+			c.Status(http.StatusOK)
+			w = &sseStreamWriter{w: c.Writer, rc: http.NewResponseController(c.Writer), binary: binary}
+		}
+
+		if err := relayRates(ctx, pubsub.Channel(), currencies, backlog, w); err != nil && ctx.Err() == nil {
+			log.Printf("Stream Error: %v", err)
+		}
+	}
+}
+
+// relayRates writes the backlog and then every update of the requested currencies until the client
+// goes away, with a heartbeat whenever the stream was idle for StreamHeartbeat.
+func relayRates(ctx context.Context, ch <-chan *redis.Message, currencies []string, backlog []*pb.RateResponse, w rateStreamWriter) error {
+	for _, rate := range backlog {
+		if err := w.Rate(ctx, rate); err != nil {
+			return err
+		}
+	}
+
+	heartbeat := time.NewTicker(services.StreamHeartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case now := <-heartbeat.C:
+			if err := w.Heartbeat(ctx, now); err != nil {
+				return err
+			}
+		case msg, ok := <-ch:
+			if !ok {
+				return fmt.Errorf("subscription closed")
+			}
+			rate := &pb.RateResponse{}
+			if err := proto.Unmarshal([]byte(msg.Payload), rate); err != nil {
+				log.Printf("Failed to unmarshal update: %v", err)
+				continue
+			}
+			if !slices.Contains(currencies, rate.Currency) {
+				continue
+			}
+			if err := w.Rate(ctx, rate); err != nil {
+				return err
+			}
+			heartbeat.Reset(services.StreamHeartbeat)
+		}
+	}
+}
+
+// sseStreamWriter writes Server-Sent Events. The event ID is the resume token, so a reconnecting
+// EventSource sends it back as Last-Event-ID.
+type sseStreamWriter struct {
+	w      gin.ResponseWriter
+	rc     *http.ResponseController
+	binary bool
+}
+
+func (s *sseStreamWriter) Rate(_ context.Context, rate *pb.RateResponse) error {
+	var data string
+	if s.binary {
+		raw, err := proto.Marshal(rate)
+		if err != nil {
+			return err
+		}
+		data = base64.StdEncoding.EncodeToString(raw)
+	} else {
+		raw, err := json.Marshal(rate)
+		if err != nil {
+			return err
+		}
+		data = string(raw)
+	}
+	return s.event(fmt.Sprintf("id: %s\nevent: rate\ndata: %s\n\n", services.RateResumeToken(rate), data))
+}
+
+func (s *sseStreamWriter) Heartbeat(_ context.Context, now time.Time) error {
+	return s.event(fmt.Sprintf("event: heartbeat\ndata: {\"time\":%d}\n\n", now.Unix()))
+}
+
+func (s *sseStreamWriter) event(ev string) error {
+	_ = s.rc.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+	if _, err := s.w.WriteString(ev); err != nil {
+		return err
+	}
+	s.w.Flush()
+	return nil
+}
+
+// wsStreamWriter writes StreamEvent frames, as JSON text or protobuf binary messages.
+type wsStreamWriter struct {
+	conn   *websocket.Conn
+	binary bool
+}
+
+func (s *wsStreamWriter) Rate(ctx context.Context, rate *pb.RateResponse) error {
+	return s.send(ctx, &pb.StreamEvent{Type: "rate", Token: services.RateResumeToken(rate), Rate: rate})
+}
+
+func (s *wsStreamWriter) Heartbeat(ctx context.Context, now time.Time) error {
+	return s.send(ctx, &pb.StreamEvent{Type: "heartbeat", Time: now.Unix()})
+}
+
+func (s *wsStreamWriter) send(ctx context.Context, ev *pb.StreamEvent) error {
+	ctx, cancel := context.WithTimeout(ctx, streamWriteTimeout)
+	defer cancel()
+	if s.binary {
+		raw, err := proto.Marshal(ev)
+		if err != nil {
+			return err
+		}
+		return s.conn.Write(ctx, websocket.MessageBinary, raw)
+	}
+	raw, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	return s.conn.Write(ctx, websocket.MessageText, raw)
+}
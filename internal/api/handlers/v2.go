@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	pbv2 "github.com/Niutaq/Gix/api/proto/v2"
+	"github.com/Niutaq/Gix/internal/infrastructure"
+	"github.com/Niutaq/Gix/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+// HandleGetRatesV2 returns the latest rate of every cantor for a currency in the v2 representation:
+// typed decimal values, mid, spread, units and scraper metadata. Served under /api/v2, outside the v1 swagger spec.
+func HandleGetRatesV2(app *infrastructure.AppState) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currency := c.Query("currency")
+		if currency == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "missing currency"})
+			return
+		}
+
+		latest, err := services.FetchLatestRates(c.Request.Context(), app.DB, currency)
+		if err != nil {
+			log.Printf("Latest Rates DB Error: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": internalServerError})
+			return
+		}
+
+		resp := &pbv2.RateList{Rates: make([]*pbv2.Rate, 0, len(latest))}
+		for _, r := range latest {
+			resp.Rates = append(resp.Rates, services.LatestRateToV2(r))
+		}
+		sendProtoOrJSON(c, resp)
+	}
+}
+
+// HandleGetHistoryV2 returns the same history as HandleGetHistory with typed decimal values.
+func HandleGetHistoryV2(app *infrastructure.AppState) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currency := c.Query("currency")
+		if currency == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "missing currency"})
+			return
+		}
+
+		params, err := parseHistoryParams(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		buckets, err := services.FetchHistory(c.Request.Context(), app.DB, currency, params)
+		if err != nil {
+			log.Printf("History DB Error: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": internalServerError})
+			return
+		}
+
+		sendProtoOrJSON(c, services.HistoryToV2(currency, params, buckets))
+	}
+}
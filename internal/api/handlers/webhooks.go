@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/Niutaq/Gix/internal/infrastructure"
+	"github.com/Niutaq/Gix/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+// HandleCreateWebhook godoc
+// @Summary      Register Webhook
+// @Description  Registers an HTTPS endpoint for rate.updated, cantor.discovered and/or governance.tripped events. Payloads are signed with HMAC-SHA256 in the X-Gix-Signature header; the secret is only returned by this call.
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Param        webhook  body      infrastructure.Webhook  true  "Webhook (url, events, optional secret)"
+// @Success      201  {object}  infrastructure.Webhook
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /webhooks [post]
+func HandleCreateWebhook(app *infrastructure.AppState) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var hook infrastructure.Webhook
+		if err := c.ShouldBindJSON(&hook); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+			return
+		}
+		if err := services.ValidateWebhook(&hook); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		created, err := services.CreateWebhook(c.Request.Context(), app.DB, hook)
+		if err != nil {
+			log.Printf("Webhook Create Error: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": internalServerError})
+			return
+		}
+		c.JSON(http.StatusCreated, created)
+	}
+}
+
+// HandleListWebhooks godoc
+// @Summary      List Webhooks
+// @Description  Returns every registered webhook (without secrets).
+// @Tags         webhooks
+// @Produce      json
+// @Success      200  {array}   infrastructure.Webhook
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /webhooks [get]
+func HandleListWebhooks(app *infrastructure.AppState) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		hooks, err := services.ListWebhooks(c.Request.Context(), app.DB)
+		if err != nil {
+			log.Printf("Webhook List Error: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": internalServerError})
+			return
+		}
+		c.JSON(http.StatusOK, hooks)
+	}
+}
+
+// HandleDeleteWebhook godoc
+// @Summary      Delete Webhook
+// @Description  Deletes a webhook and its delivery log. Queued retries are dropped.
+// @Tags         webhooks
+// @Produce      json
+// @Param        id   path      int  true  "Webhook ID"
+// @Success      200  {object}  map[string]string
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /webhooks/{id} [delete]
+func HandleDeleteWebhook(app *infrastructure.AppState) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid webhook ID"})
+			return
+		}
+
+		err = services.DeleteWebhook(c.Request.Context(), app.DB, id)
+		if errors.Is(err, services.ErrWebhookNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err != nil {
+			log.Printf("Webhook Delete Error: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": internalServerError})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"status": "deleted"})
+	}
+}
+
+// HandleListWebhookDeliveries godoc
+// @Summary      List Webhook Deliveries
+// @Description  Returns the delivery log of a webhook, newest first.
+// @Tags         webhooks
+// @Produce      json
+// @Param        id      path      int     true   "Webhook ID"
+// @Param        status  query     string  false  "Filter by status (pending, retrying, delivered, failed)"
+// @Param        limit   query     int     false  "Maximum number of deliveries (default 100)"
+// @Success      200  {array}   infrastructure.WebhookDelivery
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /webhooks/{id}/deliveries [get]
+func HandleListWebhookDeliveries(app *infrastructure.AppState) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid webhook ID"})
+			return
+		}
+
+		status := c.Query("status")
+		switch status {
+		case "", services.DeliveryPending, services.DeliveryRetrying, services.DeliveryDelivered, services.DeliveryFailed:
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid status"})
+			return
+		}
+
+		limit, err := strconv.Atoi(c.DefaultQuery("limit", "100"))
+		if err != nil || limit <= 0 || limit > 1000 {
+			limit = 100
+		}
+
+		deliveries, err := services.ListWebhookDeliveries(c.Request.Context(), app.DB, id, status, limit)
+		if err != nil {
+			log.Printf("Webhook Deliveries Error: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": internalServerError})
+			return
+		}
+		c.JSON(http.StatusOK, deliveries)
+	}
+}
@@ -0,0 +1,31 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/Niutaq/Gix/internal/infrastructure"
+	"github.com/Niutaq/Gix/pkg/auth"
+	"github.com/Niutaq/Gix/pkg/ratelimit"
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimit counts the request against the quota of the route class and rejects it with 429 once
+// the quota is exhausted. It runs after Authenticate so authenticated callers get their own bucket.
+func RateLimit(app *infrastructure.AppState, class string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if app.Limiter == nil {
+			c.Next()
+			return
+		}
+
+		principal, ok := auth.FromContext(c.Request.Context())
+		decision := app.Limiter.Allow(c.Request.Context(), class, ratelimit.ClientKey(principal, ok, c.ClientIP()))
+		decision.SetHeaders(c.Writer.Header())
+
+		if !decision.Allowed {
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "rate limit exceeded"})
+			return
+		}
+		c.Next()
+	}
+}
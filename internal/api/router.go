@@ -2,7 +2,10 @@ package api
 
 import (
 	"github.com/Niutaq/Gix/internal/api/handlers"
+	"github.com/Niutaq/Gix/internal/api/rpc"
 	"github.com/Niutaq/Gix/internal/infrastructure"
+	"github.com/Niutaq/Gix/pkg/auth"
+	"github.com/Niutaq/Gix/pkg/ratelimit"
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
@@ -17,20 +20,60 @@ func SetupRouter(app *infrastructure.AppState) *gin.Engine {
 
 	r.Use(func(c *gin.Context) {
 		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
-		c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+		c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type, X-API-Key, X-Drpc-Metadata")
+		c.Writer.Header().Set("Access-Control-Expose-Headers", "RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset, RateLimit-Policy, Retry-After, X-Next-Cursor")
 		c.Next()
 	})
 
 	r.GET("/healthz", handlers.HandleHealthCheck(app))
+	r.GET("/metrics", handlers.HandleMetrics())
+	r.POST(rpc.HTTPPrefix+"/*rpc", gin.WrapH(rpc.NewHTTPHandler(app)))
 
-	v1 := r.Group("/api/v1")
+	v1 := r.Group("/api/v1", Authenticate(app))
 	{
-		v1.GET("/cantors", handlers.HandleCantorsList(app))
-		v1.DELETE("/cantors/:id", handlers.HandleDeleteCantor(app))
-		v1.GET("/rates", handlers.HandleGetRates(app))
-		v1.GET("/history", handlers.HandleGetHistory(app))
-		v1.GET("/finops", handlers.HandleFinOps(app))
-		v1.POST("/discover", handlers.HandleDiscover(app))
+		v1.GET("/rates", RateLimit(app, ratelimit.ClassRates), handlers.HandleGetRates(app))
+
+		public := v1.Group("", RateLimit(app, ratelimit.ClassRead))
+		public.GET("/rates/latest", handlers.HandleGetLatestRates(app))
+		public.GET("/cantors", handlers.HandleCantorsList(app))
+		public.GET("/history", handlers.HandleGetHistory(app))
+		public.GET("/quote", handlers.HandleGetQuote(app))
+		public.GET("/finops", handlers.HandleFinOps(app))
+
+		v1.GET("/stream", RateLimit(app, ratelimit.ClassStream), handlers.HandleStream(app))
+
+		viewer := v1.Group("", RateLimit(app, ratelimit.ClassWrite), RequireRole(auth.RoleViewer))
+		viewer.POST("/alerts", handlers.HandleCreateAlert(app))
+		viewer.GET("/alerts", handlers.HandleListAlerts(app))
+		viewer.GET("/alerts/events", handlers.HandleListAlertEvents(app))
+		viewer.DELETE("/alerts/:id", handlers.HandleDeleteAlert(app))
+
+		v1.GET("/history/export", RateLimit(app, ratelimit.ClassExport), RequireRole(auth.RoleViewer), handlers.HandleExportHistory(app))
+
+		v1.POST("/discover", RateLimit(app, ratelimit.ClassDiscover), RequireRole(auth.RoleOperator), handlers.HandleDiscover(app))
+
+		operator := v1.Group("", RateLimit(app, ratelimit.ClassWrite), RequireRole(auth.RoleOperator))
+		operator.POST("/cantors", handlers.HandleCreateCantor(app))
+		operator.PATCH("/cantors/:id", handlers.HandleUpdateCantor(app))
+		operator.POST("/webhooks", handlers.HandleCreateWebhook(app))
+		operator.GET("/webhooks", handlers.HandleListWebhooks(app))
+		operator.DELETE("/webhooks/:id", handlers.HandleDeleteWebhook(app))
+		operator.GET("/webhooks/:id/deliveries", handlers.HandleListWebhookDeliveries(app))
+
+		admin := v1.Group("", RateLimit(app, ratelimit.ClassWrite), RequireRole(auth.RoleAdmin))
+		admin.DELETE("/cantors/:id", handlers.HandleDeleteCantor(app))
+		admin.GET("/admin/quarantine", handlers.HandleListQuarantine(app))
+		admin.POST("/admin/quarantine/:id/release", handlers.HandleReleaseQuarantine(app))
+		admin.POST("/admin/quarantine/:id/reject", handlers.HandleRejectQuarantine(app))
+		admin.GET("/admin/ratelimits", handlers.HandleGetRateLimits(app))
+		admin.PUT("/admin/ratelimits/:class", handlers.HandleSetRateLimit(app))
+	}
+
+	v2 := r.Group("/api/v2", Authenticate(app))
+	{
+		v2.GET("/rates", RateLimit(app, ratelimit.ClassRates), handlers.HandleGetRatesV2(app))
+		v2.GET("/history", RateLimit(app, ratelimit.ClassRead), handlers.HandleGetHistoryV2(app))
 	}
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
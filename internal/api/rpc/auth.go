@@ -0,0 +1,74 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/Niutaq/Gix/internal/infrastructure"
+	"github.com/Niutaq/Gix/pkg/auth"
+	"storj.io/drpc"
+	"storj.io/drpc/drpcmetadata"
+)
+
+// Error codes attached to auth, rate limit, cantor management and handler failures, matching the gRPC status codes of the same name.
+const (
+	CodeInvalidArgument   uint64 = 3
+	CodeNotFound          uint64 = 5
+	CodeAlreadyExists     uint64 = 6
+	CodePermissionDenied  uint64 = 7
+	CodeResourceExhausted uint64 = 8
+	CodeInternal          uint64 = 13
+	CodeUnauthenticated   uint64 = 16
+)
+
+// rolePublic marks methods callable without credentials.
+const rolePublic auth.Role = ""
+
+// methodRoles is the minimum role per RPC method, shared by dRPC and gRPC. Methods missing from the map require admin,
+// so new RPCs are locked down until they are classified here.
+var methodRoles = map[string]auth.Role{
+	"/v1.RatesService/StreamRates":     rolePublic,
+	"/v1.RatesService/GetAllRates":     rolePublic,
+	"/v1.RatesService/GetQuote":        rolePublic,
+	"/v1.RatesService/StreamAlerts":    auth.RoleViewer,
+	"/v1.RatesService/GetSnapshot":     rolePublic,
+	"/v1.RatesService/ListCantors":     rolePublic,
+	"/v1.RatesService/GetHistory":      rolePublic,
+	"/v1.RatesService/GetFinOpsStatus": rolePublic,
+	"/v1.RatesService/Discover":        auth.RoleOperator,
+	"/v2.RatesService/StreamRates":     rolePublic,
+	"/v2.RatesService/GetAllRates":     rolePublic,
+	"/v2.RatesService/GetHistory":      rolePublic,
+}
+
+// RequiredRole returns the minimum role for an RPC method.
+func RequiredRole(rpc string) auth.Role {
+	if role, ok := methodRoles[rpc]; ok {
+		return role
+	}
+	return auth.RoleAdmin
+}
+
+// authHandler authenticates the caller and enforces methodRoles in front of the mux. Clients send
+// credentials as "authorization: Bearer <credential>" or "x-api-key" dRPC metadata.
+type authHandler struct {
+	app  *infrastructure.AppState
+	next drpc.Handler
+}
+
+// contextStream overrides the stream context so handlers see the span and principal attached by
+// the handlers in front of them.
+type contextStream struct {
+	drpc.Stream
+	ctx context.Context
+}
+
+func (s contextStream) Context() context.Context { return s.ctx }
+
+func (h authHandler) HandleRPC(stream drpc.Stream, rpc string) error {
+	md, _ := drpcmetadata.Get(stream.Context())
+	ctx, err := authorizeCall(stream.Context(), h.app, rpc, md)
+	if err != nil {
+		return err
+	}
+	return h.next.HandleRPC(contextStream{Stream: stream, ctx: ctx}, rpc)
+}
@@ -0,0 +1,94 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	pb "github.com/Niutaq/Gix/api/proto/v1"
+	"github.com/Niutaq/Gix/internal/services"
+	"github.com/Niutaq/Gix/internal/workers"
+	"storj.io/drpc/drpcerr"
+)
+
+// ListCantors returns one page of the cantor listing; pass next_cursor back to get the next one.
+func (s *RatesDRPCServer) ListCantors(ctx context.Context, req *pb.ListCantorsRequest) (*pb.ListCantorsResponse, error) {
+	query, err := services.CantorQueryFromV1(req)
+	if err != nil {
+		return nil, drpcerr.WithCode(err, CodeInvalidArgument)
+	}
+	cantors, next, err := services.ListCantors(ctx, s.DB, query)
+	if err != nil {
+		return nil, cantorError(err, "ListCantors DB Error")
+	}
+	return services.CantorsToV1(cantors, next), nil
+}
+
+// GetHistory returns the history of the given currency and optional cantor at the requested resolution.
+func (s *RatesDRPCServer) GetHistory(ctx context.Context, req *pb.HistoryRequest) (*pb.HistoryResponse, error) {
+	if req.Currency == "" {
+		return nil, drpcerr.WithCode(fmt.Errorf("currency is required"), CodeInvalidArgument)
+	}
+
+	params := services.NewHistoryParams(int(req.CantorId), int(req.Days))
+	if err := services.SetHistoryResolution(&params, req.Interval, req.Agg); err != nil {
+		return nil, drpcerr.WithCode(err, CodeInvalidArgument)
+	}
+	buckets, err := services.FetchHistory(ctx, s.DB, req.Currency, params)
+	if err != nil {
+		return nil, cantorError(err, "GetHistory DB Error")
+	}
+	return services.HistoryToV1(req.Currency, params, buckets), nil
+}
+
+// GetFinOpsStatus returns the scraping cost summary.
+func (s *RatesDRPCServer) GetFinOpsStatus(ctx context.Context, _ *pb.FinOpsStatusRequest) (*pb.FinOpsStatus, error) {
+	return services.FinOpsToV1(services.GetFinOpsStatus(ctx, s.App)), nil
+}
+
+// Discover adds the cantor behind a URL and starts harvesting its rates in the background.
+func (s *RatesDRPCServer) Discover(ctx context.Context, req *pb.DiscoverRequest) (*pb.DiscoverResponse, error) {
+	found, err := services.DiscoverCantor(ctx, s.App, services.DiscoverRequest{
+		URL:             req.Url,
+		ExpectedName:    req.ExpectedName,
+		ExpectedLat:     req.ExpectedLat,
+		ExpectedLon:     req.ExpectedLon,
+		ExpectedAddress: req.ExpectedAddress,
+	})
+	if errors.Is(err, services.ErrInvalidDiscovery) {
+		return nil, drpcerr.WithCode(err, CodeInvalidArgument)
+	}
+	if err != nil {
+		log.Printf("Discovery DB Error: %v", err)
+		return nil, fmt.Errorf("failed to save to database")
+	}
+	workers.HarvestNewCantor(s.App, found.Cantor)
+	return services.DiscoveryToV1(found), nil
+}
+
+// DeleteCantor deletes a discovered or partner cantor and its rate history.
+func (s *RatesDRPCServer) DeleteCantor(ctx context.Context, req *pb.DeleteCantorRequest) (*pb.DeleteCantorResponse, error) {
+	if err := services.DeleteCantor(ctx, s.App, int(req.Id)); err != nil {
+		return nil, cantorError(err, "DB Delete Cantor Error")
+	}
+	return &pb.DeleteCantorResponse{}, nil
+}
+
+// cantorError attaches the status code of a cantor service error, like respondCantorError does for
+// REST. Unexpected errors are logged and hidden from the caller.
+func cantorError(err error, logPrefix string) error {
+	switch {
+	case errors.Is(err, services.ErrInvalidCantor), errors.Is(err, services.ErrInvalidCantorQuery):
+		return drpcerr.WithCode(err, CodeInvalidArgument)
+	case errors.Is(err, services.ErrCantorNotFound):
+		return drpcerr.WithCode(err, CodeNotFound)
+	case errors.Is(err, services.ErrCantorProtected), errors.Is(err, services.ErrCantorListForbidden):
+		return drpcerr.WithCode(err, CodePermissionDenied)
+	case errors.Is(err, services.ErrCantorConflict):
+		return drpcerr.WithCode(err, CodeAlreadyExists)
+	default:
+		log.Printf("%s: %v", logPrefix, err)
+		return fmt.Errorf("internal server error")
+	}
+}
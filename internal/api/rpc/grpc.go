@@ -0,0 +1,196 @@
+package rpc
+
+import (
+	"context"
+	"log"
+	"net"
+
+	pb "github.com/Niutaq/Gix/api/proto/v1"
+	"github.com/Niutaq/Gix/internal/infrastructure"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"storj.io/drpc"
+	"storj.io/drpc/drpcerr"
+)
+
+// RatesGRPCServer serves the v1 RatesService over gRPC by delegating to the dRPC implementation.
+type RatesGRPCServer struct {
+	pb.UnimplementedRatesServiceServer
+	impl *RatesDRPCServer
+}
+
+func (s *RatesGRPCServer) GetAllRates(ctx context.Context, req *pb.RateRequest) (*pb.RateListResponse, error) {
+	return s.impl.GetAllRates(ctx, req)
+}
+
+func (s *RatesGRPCServer) GetQuote(ctx context.Context, req *pb.QuoteRequest) (*pb.QuoteResponse, error) {
+	return s.impl.GetQuote(ctx, req)
+}
+
+func (s *RatesGRPCServer) GetSnapshot(ctx context.Context, req *pb.SnapshotRequest) (*pb.SnapshotResponse, error) {
+	return s.impl.GetSnapshot(ctx, req)
+}
+
+func (s *RatesGRPCServer) ListCantors(ctx context.Context, req *pb.ListCantorsRequest) (*pb.ListCantorsResponse, error) {
+	return s.impl.ListCantors(ctx, req)
+}
+
+func (s *RatesGRPCServer) GetHistory(ctx context.Context, req *pb.HistoryRequest) (*pb.HistoryResponse, error) {
+	return s.impl.GetHistory(ctx, req)
+}
+
+func (s *RatesGRPCServer) GetFinOpsStatus(ctx context.Context, req *pb.FinOpsStatusRequest) (*pb.FinOpsStatus, error) {
+	return s.impl.GetFinOpsStatus(ctx, req)
+}
+
+func (s *RatesGRPCServer) Discover(ctx context.Context, req *pb.DiscoverRequest) (*pb.DiscoverResponse, error) {
+	return s.impl.Discover(ctx, req)
+}
+
+func (s *RatesGRPCServer) DeleteCantor(ctx context.Context, req *pb.DeleteCantorRequest) (*pb.DeleteCantorResponse, error) {
+	return s.impl.DeleteCantor(ctx, req)
+}
+
+func (s *RatesGRPCServer) StreamRates(req *pb.StreamRatesRequest, stream grpc.ServerStreamingServer[pb.RateResponse]) error {
+	return s.impl.StreamRates(req, grpcStream[pb.RateResponse]{stream})
+}
+
+func (s *RatesGRPCServer) StreamAlerts(req *pb.StreamAlertsRequest, stream grpc.ServerStreamingServer[pb.AlertEvent]) error {
+	return s.impl.StreamAlerts(req, grpcStream[pb.AlertEvent]{stream})
+}
+
+// grpcStream adapts a gRPC server stream to the dRPC stream interface, so the streaming methods of
+// RatesDRPCServer serve both transports.
+type grpcStream[T any] struct {
+	grpc.ServerStreamingServer[T]
+}
+
+func (s grpcStream[T]) MsgSend(msg drpc.Message, _ drpc.Encoding) error { return s.SendMsg(msg) }
+func (s grpcStream[T]) MsgRecv(msg drpc.Message, _ drpc.Encoding) error { return s.RecvMsg(msg) }
+func (s grpcStream[T]) CloseSend() error                                { return nil }
+func (s grpcStream[T]) Close() error                                    { return nil }
+
+// NewGRPCServer returns a gRPC server with RatesService, the health service and reflection
+// registered, running calls through the same auth, rate limit and tracing steps as dRPC.
+func NewGRPCServer(app *infrastructure.AppState) *grpc.Server {
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unaryInterceptor(app)),
+		grpc.ChainStreamInterceptor(streamInterceptor(app)),
+	)
+	pb.RegisterRatesServiceServer(srv, &RatesGRPCServer{impl: newRatesServer(app)})
+
+	healthSrv := health.NewServer()
+	healthSrv.SetServingStatus(pb.RatesService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(srv, healthSrv)
+	reflection.Register(srv)
+	return srv
+}
+
+// StartGRPCServer serves gRPC on addr, e.g. ":9090".
+func StartGRPCServer(app *infrastructure.AppState, addr string) {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		log.Fatalf("failed to listen for gRPC: %v", err)
+	}
+	log.Printf("gRPC server listening on %s", addr)
+	if err := NewGRPCServer(app).Serve(lis); err != nil {
+		log.Fatalf("gRPC serve error: %v", err)
+	}
+}
+
+// grpcPublic lists infrastructure services that skip auth and rate limiting.
+var grpcPublic = map[string]bool{
+	healthpb.Health_Check_FullMethodName:                             true,
+	healthpb.Health_Watch_FullMethodName:                             true,
+	"/grpc.reflection.v1.ServerReflection/ServerReflectionInfo":      true,
+	"/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo": true,
+}
+
+// admitGRPC runs a gRPC call through authorizeCall and limitCall.
+func admitGRPC(ctx context.Context, app *infrastructure.AppState, method string) (context.Context, error) {
+	if grpcPublic[method] {
+		return ctx, nil
+	}
+
+	md := make(map[string]string)
+	if in, ok := metadata.FromIncomingContext(ctx); ok {
+		for key, values := range in {
+			if len(values) > 0 {
+				md[key] = values[0]
+			}
+		}
+	}
+	ctx, err := authorizeCall(ctx, app, method, md)
+	if err != nil {
+		return ctx, err
+	}
+	return ctx, limitCall(ctx, app, method, grpcPeerIP(ctx))
+}
+
+func unaryInterceptor(app *infrastructure.AppState) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		ctx, finish := observeCall(ctx, "grpc", info.FullMethod)
+		defer func() { finish(err) }()
+		defer func() { err = grpcError(err) }()
+		defer recoverCall(info.FullMethod, &err)
+
+		if ctx, err = admitGRPC(ctx, app, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func streamInterceptor(app *infrastructure.AppState) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		ctx, finish := observeCall(ss.Context(), "grpc", info.FullMethod)
+		defer func() { finish(err) }()
+		defer func() { err = grpcError(err) }()
+		defer recoverCall(info.FullMethod, &err)
+
+		if ctx, err = admitGRPC(ctx, app, info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, grpcContextStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// grpcContextStream overrides the stream context, like contextStream does for dRPC.
+type grpcContextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s grpcContextStream) Context() context.Context { return s.ctx }
+
+// grpcError converts dRPC error codes, which use the gRPC numbering, into gRPC statuses.
+func grpcError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	if code := drpcerr.Code(err); code != 0 {
+		return status.Error(codes.Code(code), err.Error())
+	}
+	return err
+}
+
+func grpcPeerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return "unknown"
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
@@ -0,0 +1,116 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"runtime/debug"
+	"time"
+
+	"github.com/Niutaq/Gix/internal/infrastructure"
+	"github.com/Niutaq/Gix/internal/services"
+	"github.com/Niutaq/Gix/pkg/auth"
+	"github.com/Niutaq/Gix/pkg/ratelimit"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/ext"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
+	"storj.io/drpc"
+	"storj.io/drpc/drpcerr"
+)
+
+// Both transports run every call through the same steps: observeCall, recoverCall, authorizeCall and limitCall.
+// The dRPC handlers and gRPC interceptors only extract metadata and the peer address; gRPC also
+// turns the coded errors into statuses.
+
+// authorizeCall authenticates the credential from the call metadata ("authorization: Bearer
+// <credential>" or "x-api-key") and enforces methodRoles. The returned context carries the principal.
+func authorizeCall(ctx context.Context, app *infrastructure.AppState, rpc string, md map[string]string) (context.Context, error) {
+	credential := auth.BearerToken(md["authorization"])
+	if credential == "" {
+		credential = md["x-api-key"]
+	}
+
+	if credential != "" {
+		principal, err := services.Authenticate(ctx, app, credential)
+		if err != nil {
+			if !errors.Is(err, auth.ErrInvalidCredential) && !errors.Is(err, auth.ErrExpiredToken) {
+				log.Printf("RPC Auth Error: %v", err)
+			}
+			return ctx, drpcerr.WithCode(errors.New("invalid credential"), CodeUnauthenticated)
+		}
+		ctx = auth.NewContext(ctx, principal)
+	}
+
+	if role := RequiredRole(rpc); role != rolePublic {
+		principal, ok := auth.FromContext(ctx)
+		if !ok {
+			return ctx, drpcerr.WithCode(errors.New("authentication required"), CodeUnauthenticated)
+		}
+		if !principal.Role.Allows(role) {
+			return ctx, drpcerr.WithCode(errors.New("requires role "+string(role)), CodePermissionDenied)
+		}
+	}
+	return ctx, nil
+}
+
+// limitCall applies the same per-client quotas as the REST API. It runs after authorizeCall so
+// authenticated callers are counted by key rather than by address.
+func limitCall(ctx context.Context, app *infrastructure.AppState, rpc, ip string) error {
+	if app.Limiter == nil {
+		return nil
+	}
+
+	class, ok := methodClasses[rpc]
+	if !ok {
+		class = ratelimit.ClassRead
+	}
+
+	principal, authenticated := auth.FromContext(ctx)
+	decision := app.Limiter.Allow(ctx, class, ratelimit.ClientKey(principal, authenticated, ip))
+	if !decision.Allowed {
+		err := fmt.Errorf("rate limit exceeded, retry after %s", decision.Reset.Round(time.Second))
+		return drpcerr.WithCode(err, CodeResourceExhausted)
+	}
+	return nil
+}
+
+// observeCall starts a trace span for the call and returns a function that finishes it and logs the
+// outcome with the call duration.
+func observeCall(ctx context.Context, system, rpc string) (context.Context, func(error)) {
+	span, ctx := tracer.StartSpanFromContext(ctx, system+".server.request",
+		tracer.ResourceName(rpc),
+		tracer.SpanType(ext.AppTypeRPC),
+		tracer.Tag(ext.RPCSystem, system),
+		tracer.Tag(ext.Component, "gix/rpc"))
+	start := time.Now()
+
+	return ctx, func(err error) {
+		span.Finish(tracer.WithError(err))
+		if err != nil && !errors.Is(err, context.Canceled) {
+			log.Printf("[RPC] %s %s %v error: %v", system, rpc, time.Since(start).Round(time.Millisecond), err)
+			return
+		}
+		log.Printf("[RPC] %s %s %v", system, rpc, time.Since(start).Round(time.Millisecond))
+	}
+}
+
+// recoverCall turns a panic of the call into an internal error, logging the stack, so one failing
+// handler does not take the whole server down. It must be deferred directly.
+func recoverCall(rpc string, err *error) {
+	if r := recover(); r != nil {
+		log.Printf("[RPC] panic in %s: %v\n%s", rpc, r, debug.Stack())
+		*err = drpcerr.WithCode(errors.New("internal server error"), CodeInternal)
+	}
+}
+
+// observeHandler traces and logs every dRPC call. It runs first so rejected calls are recorded too.
+type observeHandler struct {
+	next drpc.Handler
+}
+
+func (h observeHandler) HandleRPC(stream drpc.Stream, rpc string) (err error) {
+	ctx, finish := observeCall(stream.Context(), "drpc", rpc)
+	defer func() { finish(err) }()
+	defer recoverCall(rpc, &err)
+	return h.next.HandleRPC(contextStream{Stream: stream, ctx: ctx}, rpc)
+}
@@ -0,0 +1,54 @@
+package rpc
+
+import (
+	"net"
+
+	"github.com/Niutaq/Gix/internal/infrastructure"
+	"github.com/Niutaq/Gix/pkg/ratelimit"
+	"storj.io/drpc"
+	"storj.io/drpc/drpcctx"
+)
+
+// methodClasses maps RPC methods to rate limit classes; unlisted methods count as reads.
+var methodClasses = map[string]string{
+	"/v1.RatesService/StreamRates":  ratelimit.ClassStream,
+	"/v1.RatesService/StreamAlerts": ratelimit.ClassStream,
+	"/v2.RatesService/StreamRates":  ratelimit.ClassStream,
+	"/v1.RatesService/Discover":     ratelimit.ClassDiscover,
+	"/v1.RatesService/DeleteCantor": ratelimit.ClassWrite,
+}
+
+// limitHandler applies the same per-client quotas as the REST API to dRPC calls. It runs after
+// authHandler so authenticated callers are counted by key rather than by address.
+type limitHandler struct {
+	app  *infrastructure.AppState
+	next drpc.Handler
+}
+
+func (h limitHandler) HandleRPC(stream drpc.Stream, rpc string) error {
+	if err := limitCall(stream.Context(), h.app, rpc, remoteIP(stream)); err != nil {
+		return err
+	}
+	return h.next.HandleRPC(stream, rpc)
+}
+
+// remoteIP returns the peer address of the connection carrying the stream, as attached by serveDRPC,
+// or of the HTTP request for calls made through NewHTTPHandler.
+func remoteIP(stream drpc.Stream) string {
+	if ip, ok := stream.Context().Value(peerIPKey{}).(string); ok {
+		return ip
+	}
+	tr, ok := drpcctx.Transport(stream.Context())
+	if !ok {
+		return "unknown"
+	}
+	conn, ok := tr.(net.Conn)
+	if !ok {
+		return "unknown"
+	}
+	host, _, err := net.SplitHostPort(conn.RemoteAddr().String())
+	if err != nil {
+		return conn.RemoteAddr().String()
+	}
+	return host
+}
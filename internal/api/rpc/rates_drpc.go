@@ -7,15 +7,30 @@ import (
 	"time"
 
 	pb "github.com/Niutaq/Gix/api/proto/v1"
+	"github.com/Niutaq/Gix/internal/infrastructure"
+	"github.com/Niutaq/Gix/internal/services"
+	"github.com/Niutaq/Gix/pkg/streams"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/nats-io/nats.go"
 	"github.com/redis/go-redis/v9"
 	"google.golang.org/protobuf/proto"
+	"storj.io/drpc/drpcerr"
 )
 
 type RatesDRPCServer struct {
 	pb.DRPCRatesServiceServer
 	Cache redis.UniversalClient
 	DB    *pgxpool.Pool
+	JS    nats.JetStreamContext
+	// Streams sizes the send queue of every StreamRates client.
+	Streams streams.Config
+	// App serves the methods that change cantors or report process-wide state.
+	App *infrastructure.AppState
+}
+
+// newRatesServer returns the v1 service backed by app.
+func newRatesServer(app *infrastructure.AppState) *RatesDRPCServer {
+	return &RatesDRPCServer{Cache: app.Cache, DB: app.DB, JS: app.JS, Streams: app.Streams, App: app}
 }
 
 // GetAllRates returns all rates for the given currency.
@@ -25,64 +40,272 @@ func (s *RatesDRPCServer) GetAllRates(ctx context.Context, req *pb.RateRequest)
 		return nil, fmt.Errorf("currency is required")
 	}
 
-	query := `
-		WITH latest AS (
-			SELECT DISTINCT ON (cantor_id) cantor_id, buy_rate, sell_rate, time
-			FROM rates WHERE currency = $1 ORDER BY cantor_id, time DESC
-		),
-		past AS (
-			SELECT DISTINCT ON (cantor_id) cantor_id, buy_rate
-			FROM rates
-			WHERE currency = $1 AND time <= NOW() - INTERVAL '24 hours'
-			ORDER BY cantor_id, time DESC
-		)
-		SELECT l.cantor_id, l.buy_rate, l.sell_rate, l.time, COALESCE(p.buy_rate, 0)
-		FROM latest l
-		LEFT JOIN past p ON l.cantor_id = p.cantor_id;
-	`
-
-	rows, err := s.DB.Query(ctx, query, currency)
+	latest, err := services.FetchLatestRates(ctx, s.DB, currency)
 	if err != nil {
 		log.Printf("GetAllRates DB Error: %v", err)
 		return nil, err
 	}
-	defer rows.Close()
 
-	var results []*pb.RateResponse
+	results := make([]*pb.RateResponse, 0, len(latest))
+	for _, r := range latest {
+		results = append(results, services.LatestRateToV1(r))
+	}
+
+	return &pb.RateListResponse{Results: results}, nil
+}
+
+// GetSnapshot returns the latest rates of every cantor for several currencies at once.
+func (s *RatesDRPCServer) GetSnapshot(ctx context.Context, req *pb.SnapshotRequest) (*pb.SnapshotResponse, error) {
+	currencies, err := services.ParseCurrencies(req.Currencies)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, err := services.FetchLatestSnapshot(ctx, s.DB, currencies)
+	if err != nil {
+		log.Printf("GetSnapshot DB Error: %v", err)
+		return nil, err
+	}
+	return services.SnapshotToV1(currencies, snapshot, time.Now()), nil
+}
+
+// StreamRates streams the real-time rate updates matching the request filters, optionally preceded by
+// a snapshot of the current rates. With JetStream every update carries its sequence in the RATES
+// stream and clients can resume after the last one they saw; without it updates are relayed from
+// Redis pub/sub and missed ones are lost.
+func (s *RatesDRPCServer) StreamRates(req *pb.StreamRatesRequest, stream pb.DRPCRatesService_StreamRatesStream) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	filter, err := s.streamFilter(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer streams.Stats.Connect()()
+	out := newRateSender(s.Streams, filter, stream.Send)
+	if s.JS != nil {
+		return s.streamFromJetStream(ctx, req, out)
+	}
+	return s.streamFromRedis(ctx, req, out)
+}
+
+// streamFilter builds the filter of a subscriber, loading the cantor locations only when it selects an area.
+func (s *RatesDRPCServer) streamFilter(ctx context.Context, req *pb.StreamRatesRequest) (*services.StreamFilter, error) {
+	var locations map[int]infrastructure.GeoPoint
+	if services.StreamNeedsLocations(req) {
+		var err error
+		if locations, err = services.FetchCantorLocations(ctx, s.DB); err != nil {
+			log.Printf("StreamRates DB Error: %v", err)
+			return nil, err
+		}
+	}
+	return services.NewStreamFilter(req, locations)
+}
+
+// sendSnapshot sends the current rates in the requested currencies, stamped with seq.
+func (s *RatesDRPCServer) sendSnapshot(ctx context.Context, req *pb.StreamRatesRequest, out *rateSender, seq uint64) error {
+	currencies, err := services.ParseCurrencies(req.Currencies)
+	if err != nil {
+		return err
+	}
+	rates, err := services.SnapshotRates(ctx, s.DB, currencies)
+	if err != nil {
+		log.Printf("StreamRates DB Error: %v", err)
+		return err
+	}
+	return out.snapshot(rates, seq)
+}
+
+// streamFromJetStream replays the RATES stream after req.ResumeFrom and then follows it. The current
+// rates are sent first when requested or when the missed updates are no longer retained, stamped with
+// the sequence the live updates continue after. Rates are archived before they are published, so the
+// snapshot already holds every update up to that sequence.
+func (s *RatesDRPCServer) streamFromJetStream(ctx context.Context, req *pb.StreamRatesRequest, out *rateSender) error {
+	info, err := s.JS.StreamInfo(infrastructure.RatesStreamName, nats.Context(ctx))
+	if err != nil {
+		log.Printf("StreamRates JetStream Error: %v", err)
+		return fmt.Errorf("rate stream unavailable")
+	}
+	start, gap := services.ReplayStart(req.ResumeFrom, info.State.FirstSeq, info.State.LastSeq)
+
+	// The callback blocks instead of dropping while the client is slow; JetStream keeps the backlog.
+	updates := make(chan *pb.RateResponse)
+	sub, err := s.JS.Subscribe("rates.*", func(msg *nats.Msg) {
+		meta, err := msg.Metadata()
+		if err != nil {
+			log.Printf("Failed to read update metadata: %v", err)
+			return
+		}
+		rate := &pb.RateResponse{}
+		if err := proto.Unmarshal(msg.Data, rate); err != nil {
+			log.Printf("Failed to unmarshal update: %v", err)
+			return
+		}
+		rate.Sequence = meta.Sequence.Stream
+		select {
+		case updates <- rate:
+		case <-ctx.Done():
+		}
+	}, nats.BindStream(infrastructure.RatesStreamName), nats.OrderedConsumer(), nats.StartSequence(start))
+	if err != nil {
+		log.Printf("StreamRates JetStream Error: %v", err)
+		return fmt.Errorf("rate stream unavailable")
+	}
+	defer func() { _ = sub.Unsubscribe() }()
+	log.Printf("New dRPC stream client connected at sequence %d", start)
+
+	if gap || req.IncludeSnapshot {
+		if err := s.sendSnapshot(ctx, req, out, start-1); err != nil {
+			return err
+		}
+	}
+	return out.relay(ctx, updates)
+}
+
+// streamFromRedis relays the updates published on Redis. When a snapshot is requested it is sent once
+// the subscription is active, so no update falls in between.
+func (s *RatesDRPCServer) streamFromRedis(ctx context.Context, req *pb.StreamRatesRequest, out *rateSender) error {
+	log.Println("New dRPC stream client connected")
+	pubsub := s.Cache.Subscribe(ctx, services.RatesUpdatesChannel)
+	defer func() { _ = pubsub.Close() }()
+
+	if req.IncludeSnapshot {
+		if _, err := pubsub.Receive(ctx); err != nil {
+			return err
+		}
+		if err := s.sendSnapshot(ctx, req, out, 0); err != nil {
+			return err
+		}
+	}
+
+	updates := make(chan *pb.RateResponse)
+	go func() {
+		for msg := range pubsub.Channel() {
+			rate := &pb.RateResponse{}
+			if err := proto.Unmarshal([]byte(msg.Payload), rate); err != nil {
+				log.Printf("Failed to unmarshal update: %v", err)
+				continue
+			}
+			select {
+			case updates <- rate:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out.relay(ctx, updates)
+}
+
+// rateSender applies the filter of a subscriber to the rates of its stream and hands them to the
+// client through a bounded send queue, so a slow client does not hold up the updates.
+type rateSender struct {
+	filter *services.StreamFilter
+	queue  *streams.Queue[*pb.RateResponse, rateKey]
+	send   func(*pb.RateResponse) error
+}
+
+// rateKey identifies rates superseding each other in a send queue.
+type rateKey struct {
+	cantorID int32
+	currency string
+}
 
-	for rows.Next() {
-		var cantorID int
-		var buy, sell, pastBuy float64
-		var t time.Time
+func newRateSender(cfg streams.Config, filter *services.StreamFilter, send func(*pb.RateResponse) error) *rateSender {
+	key := func(rate *pb.RateResponse) rateKey { return rateKey{rate.CantorId, rate.Currency} }
+	return &rateSender{filter: filter, queue: streams.NewQueue(cfg, key, streams.Stats), send: send}
+}
 
-		if err := rows.Scan(&cantorID, &buy, &sell, &t, &pastBuy); err != nil {
-			log.Printf("GetAllRates Scan Error: %v", err)
+// sendNow sends a rate to the client, recording the send latency.
+func (o *rateSender) sendNow(rate *pb.RateResponse) error {
+	start := time.Now()
+	if err := o.send(rate); err != nil {
+		return err
+	}
+	streams.Stats.ObserveSend(time.Since(start))
+	return nil
+}
+
+// snapshot sends the matching rates stamped with seq; they become the reference for later updates.
+// It bypasses the queue, as a snapshot may be larger than the queue and must not be coalesced.
+func (o *rateSender) snapshot(rates []*pb.RateResponse, seq uint64) error {
+	now := time.Now()
+	for _, rate := range rates {
+		if !o.filter.Match(rate) {
 			continue
 		}
+		rate.Sequence = seq
+		o.filter.Sent(rate, now)
+		if err := o.sendNow(rate); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// relay sends the admitted live updates until ctx ends. Updates are filtered and queued on another
+// goroutine, while sends stay on the handler goroutine as gRPC requires.
+func (o *rateSender) relay(ctx context.Context, updates <-chan *pb.RateResponse) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	overflow := make(chan error, 1)
+	go func() { overflow <- o.intake(ctx, updates) }()
 
-		change := int64(0)
-		if pastBuy > 0 {
-			change = int64(((buy - pastBuy) / pastBuy) * 10000)
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("dRPC client disconnected")
+			return ctx.Err()
+		case err := <-overflow:
+			log.Printf("dRPC stream client dropped: %v", err)
+			return drpcerr.WithCode(err, CodeResourceExhausted)
+		case <-o.queue.Ready():
+			for _, rate := range o.queue.Drain() {
+				if err := o.sendNow(rate); err != nil {
+					return err
+				}
+			}
 		}
+	}
+}
 
-		results = append(results, &pb.RateResponse{
-			BuyRate:   fmt.Sprintf("%.3f", buy),
-			SellRate:  fmt.Sprintf("%.3f", sell),
-			CantorId:  int32(cantorID),
-			Currency:  currency,
-			FetchedAt: t.Unix(),
-			Change24H: change,
-		})
+// intake queues the admitted updates, releasing throttled ones as their interval passes. It only
+// returns with ctx ending or the queue rejecting a slow client.
+func (o *rateSender) intake(ctx context.Context, updates <-chan *pb.RateResponse) error {
+	var tick <-chan time.Time
+	if throttle := o.filter.Throttle(); throttle > 0 {
+		ticker := time.NewTicker(max(throttle/4, 10*time.Millisecond))
+		defer ticker.Stop()
+		tick = ticker.C
 	}
 
-	return &pb.RateListResponse{Results: results}, nil
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case rate := <-updates:
+			if !o.filter.Match(rate) || !o.filter.Admit(rate, time.Now()) {
+				continue
+			}
+			if err := o.queue.Push(rate); err != nil {
+				return err
+			}
+		case now := <-tick:
+			for _, rate := range o.filter.Due(now) {
+				if err := o.queue.Push(rate); err != nil {
+					return err
+				}
+			}
+		}
+	}
 }
 
-// StreamRates streams real-time rate updates for the requested currencies.
-func (s *RatesDRPCServer) StreamRates(req *pb.StreamRatesRequest, stream pb.DRPCRatesService_StreamRatesStream) error {
-	ctx := stream.Context()
+// streamUpdates relays messages published on a Redis channel to a stream client until its context ends.
+// The v2 rate stream and the alert stream share this loop; they differ only in the channel, message
+// type and filter.
+func streamUpdates[T proto.Message](ctx context.Context, cache redis.UniversalClient, channel string,
+	newMsg func() T, accept func(T) bool, send func(T) error) error {
 	log.Println("New dRPC stream client connected")
-	pubsub := s.Cache.Subscribe(ctx, "rates_updates")
+	pubsub := cache.Subscribe(ctx, channel)
 	defer func() { _ = pubsub.Close() }()
 
 	ch := pubsub.Channel()
@@ -93,30 +316,76 @@ func (s *RatesDRPCServer) StreamRates(req *pb.StreamRatesRequest, stream pb.DRPC
 			log.Println("dRPC client disconnected")
 			return ctx.Err()
 		case msg := <-ch:
-			var rate pb.RateResponse
-			if err := proto.Unmarshal([]byte(msg.Payload), &rate); err != nil {
+			rate := newMsg()
+			if err := proto.Unmarshal([]byte(msg.Payload), rate); err != nil {
 				log.Printf("Failed to unmarshal update: %v", err)
 				continue
 			}
 
-			if !s.shouldSendRate(req, &rate) {
+			if !accept(rate) {
 				continue
 			}
 
-			if err := stream.Send(&rate); err != nil {
+			if err := send(rate); err != nil {
 				return err
 			}
 		}
 	}
 }
 
-// shouldSendRate determines if a rate update should be sent to the client based on the request currencies.
-func (s *RatesDRPCServer) shouldSendRate(req *pb.StreamRatesRequest, rate *pb.RateResponse) bool {
-	if len(req.Currencies) == 0 {
+// shouldSendCurrency determines if a rate update should be sent to the client based on the requested currencies.
+func shouldSendCurrency(currencies []string, currency string) bool {
+	if len(currencies) == 0 {
+		return true
+	}
+	for _, c := range currencies {
+		if c == currency {
+			return true
+		}
+	}
+	return false
+}
+
+// GetQuote ranks cantors by the PLN amount of exchanging the requested amount.
+func (s *RatesDRPCServer) GetQuote(ctx context.Context, req *pb.QuoteRequest) (*pb.QuoteResponse, error) {
+	quoteReq, err := services.QuoteRequestFromProto(req)
+	if err == nil {
+		err = services.ValidateQuoteRequest(&quoteReq)
+	}
+	if err != nil {
+		return nil, drpcerr.WithCode(err, CodeInvalidArgument)
+	}
+
+	quotes, err := services.GetQuotes(ctx, s.DB, quoteReq)
+	if err != nil {
+		log.Printf("GetQuote DB Error: %v", err)
+		return nil, err
+	}
+	return services.QuotesToProto(quoteReq, quotes), nil
+}
+
+// StreamAlerts streams alert events of the caller as they fire. A requested owner must be the caller's own.
+func (s *RatesDRPCServer) StreamAlerts(req *pb.StreamAlertsRequest, stream pb.DRPCRatesService_StreamAlertsStream) error {
+	owner, err := services.AlertOwner(stream.Context(), req.Owner)
+	if err != nil {
+		return drpcerr.WithCode(err, CodePermissionDenied)
+	}
+	return streamUpdates(stream.Context(), s.Cache, services.AlertEventsChannel,
+		func() *pb.AlertEvent { return &pb.AlertEvent{} },
+		func(ev *pb.AlertEvent) bool { return shouldSendAlert(owner, req.RuleIds, ev) },
+		stream.Send)
+}
+
+// shouldSendAlert determines if an alert event belongs to the subscriber.
+func shouldSendAlert(owner string, ruleIDs []int32, ev *pb.AlertEvent) bool {
+	if ev.Owner != owner {
+		return false
+	}
+	if len(ruleIDs) == 0 {
 		return true
 	}
-	for _, c := range req.Currencies {
-		if c == rate.Currency {
+	for _, id := range ruleIDs {
+		if id == ev.RuleId {
 			return true
 		}
 	}
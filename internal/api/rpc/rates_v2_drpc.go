@@ -0,0 +1,65 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	pbv2 "github.com/Niutaq/Gix/api/proto/v2"
+	"github.com/Niutaq/Gix/internal/services"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+	"storj.io/drpc/drpcerr"
+)
+
+// RatesV2DRPCServer serves the v2 RatesService next to the v1 one, backed by the same queries.
+type RatesV2DRPCServer struct {
+	pbv2.DRPCRatesServiceServer
+	Cache redis.UniversalClient
+	DB    *pgxpool.Pool
+}
+
+// GetAllRates returns all rates for the given currency.
+func (s *RatesV2DRPCServer) GetAllRates(ctx context.Context, req *pbv2.RateRequest) (*pbv2.RateList, error) {
+	if req.Currency == "" {
+		return nil, fmt.Errorf("currency is required")
+	}
+
+	latest, err := services.FetchLatestRates(ctx, s.DB, req.Currency)
+	if err != nil {
+		log.Printf("GetAllRates v2 DB Error: %v", err)
+		return nil, err
+	}
+
+	rates := make([]*pbv2.Rate, 0, len(latest))
+	for _, r := range latest {
+		rates = append(rates, services.LatestRateToV2(r))
+	}
+	return &pbv2.RateList{Rates: rates}, nil
+}
+
+// GetHistory returns the history of the given currency and optional cantor at the requested resolution.
+func (s *RatesV2DRPCServer) GetHistory(ctx context.Context, req *pbv2.HistoryRequest) (*pbv2.HistoryResponse, error) {
+	if req.Currency == "" {
+		return nil, drpcerr.WithCode(fmt.Errorf("currency is required"), CodeInvalidArgument)
+	}
+
+	params := services.NewHistoryParams(int(req.CantorId), int(req.Days))
+	if err := services.SetHistoryResolution(&params, req.Interval, req.Agg); err != nil {
+		return nil, drpcerr.WithCode(err, CodeInvalidArgument)
+	}
+	buckets, err := services.FetchHistory(ctx, s.DB, req.Currency, params)
+	if err != nil {
+		log.Printf("GetHistory v2 DB Error: %v", err)
+		return nil, err
+	}
+	return services.HistoryToV2(req.Currency, params, buckets), nil
+}
+
+// StreamRates streams real-time rate updates for the requested currencies.
+func (s *RatesV2DRPCServer) StreamRates(req *pbv2.StreamRatesRequest, stream pbv2.DRPCRatesService_StreamRatesStream) error {
+	return streamUpdates(stream.Context(), s.Cache, services.RatesUpdatesV2Channel,
+		func() *pbv2.Rate { return &pbv2.Rate{} },
+		func(rate *pbv2.Rate) bool { return shouldSendCurrency(req.Currencies, rate.Currency) },
+		stream.Send)
+}
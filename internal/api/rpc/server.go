@@ -2,29 +2,155 @@ package rpc
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"log"
 	"net"
+	"net/http"
+	"strings"
+	"time"
 
 	pb "github.com/Niutaq/Gix/api/proto/v1"
-	"github.com/jackc/pgx/v5/pgxpool"
-	"github.com/redis/go-redis/v9"
+	pbv2 "github.com/Niutaq/Gix/api/proto/v2"
+	"github.com/Niutaq/Gix/internal/infrastructure"
+	"storj.io/drpc"
+	"storj.io/drpc/drpcctx"
+	"storj.io/drpc/drpchttp"
+	"storj.io/drpc/drpcmetadata"
+	"storj.io/drpc/drpcmigrate"
 	"storj.io/drpc/drpcmux"
 	"storj.io/drpc/drpcserver"
 )
 
-func StartDRPCServer(db *pgxpool.Pool, cache redis.UniversalClient) {
-	lis, err := net.Listen("tcp", ":8081")
-	if err != nil {
-		log.Fatalf("failed to listen for dRPC: %v", err)
-	}
+// HTTPPrefix is the path under which unary dRPC methods are served as JSON or protobuf over HTTP,
+// e.g. POST /drpc/v1.RatesService/GetAllRates.
+const HTTPPrefix = "/drpc"
+
+// NewDRPCHandler registers both API versions on a mux behind the observe, auth and rate limit handlers.
+func NewDRPCHandler(app *infrastructure.AppState) drpc.Handler {
 	mux := drpcmux.New()
-	err = pb.DRPCRegisterRatesService(mux, &RatesDRPCServer{Cache: cache, DB: db})
+	err := pb.DRPCRegisterRatesService(mux, newRatesServer(app))
 	if err != nil {
 		log.Fatalf("failed to register dRPC service: %v", err)
 	}
-	srv := drpcserver.New(mux)
-	log.Println("dRPC server listening on :8081")
-	if err := srv.Serve(context.Background(), lis); err != nil {
+	err = pbv2.DRPCRegisterRatesService(mux, &RatesV2DRPCServer{Cache: app.Cache, DB: app.DB})
+	if err != nil {
+		log.Fatalf("failed to register dRPC v2 service: %v", err)
+	}
+	return observeHandler{next: authHandler{app: app, next: limitHandler{app: app, next: mux}}}
+}
+
+// StartDRPCServer serves dRPC on a dedicated port, e.g. ":8081". Connections may open with the
+// drpcmigrate header, as clients of the shared HTTP port do, or without it, as older clients do.
+func StartDRPCServer(app *infrastructure.AppState, addr string) {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		log.Fatalf("failed to listen for dRPC: %v", err)
+	}
+	ctx := context.Background()
+	legacy := secure(app, Multiplex(ctx, app, lis))
+	log.Printf("dRPC server listening on %s", addr)
+	if err := serveDRPC(ctx, drpcserver.New(NewDRPCHandler(app)), legacy); err != nil {
 		log.Fatalf("dRPC serve error: %v", err)
 	}
 }
+
+// Multiplex serves dRPC on connections of lis that open with the drpcmigrate header and returns
+// the listener receiving every other connection, to be served by the HTTP server. With TLS
+// configured, the handshake follows the header, so HTTP on the same port is unaffected. The split
+// stops when ctx ends.
+func Multiplex(ctx context.Context, app *infrastructure.AppState, lis net.Listener) net.Listener {
+	lmux := drpcmigrate.NewListenMux(lis, len(drpcmigrate.DRPCHeader))
+	srv := drpcserver.New(NewDRPCHandler(app))
+	go func() {
+		if err := serveDRPC(ctx, srv, secure(app, lmux.Route(drpcmigrate.DRPCHeader))); err != nil {
+			log.Printf("dRPC serve error: %v", err)
+		}
+	}()
+	go func() {
+		if err := lmux.Run(ctx); err != nil {
+			log.Printf("Listener mux error: %v", err)
+		}
+	}()
+	return lmux.Default()
+}
+
+// tlsHandshakeTimeout bounds the TLS handshake of a dRPC connection, so a client that connects and
+// stalls does not hold the connection open.
+var tlsHandshakeTimeout = 10 * time.Second
+
+// secure returns lis wrapped in TLS when app configures it for dRPC, and lis otherwise.
+func secure(app *infrastructure.AppState, lis net.Listener) net.Listener {
+	if app.DRPCTLS == nil {
+		return lis
+	}
+	return tls.NewListener(lis, app.DRPCTLS)
+}
+
+// NewHTTPHandler serves the unary dRPC methods under HTTPPrefix with drpchttp, using the request
+// Content-Type (application/json or application/protobuf) for both directions. Credentials are
+// read from the usual Authorization and X-API-Key headers.
+func NewHTTPHandler(app *infrastructure.AppState) http.Handler {
+	h := drpchttp.New(NewDRPCHandler(app))
+	return http.StripPrefix(HTTPPrefix, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := withPeerIP(r.Context(), r.RemoteAddr)
+		if v := r.Header.Get("Authorization"); v != "" {
+			ctx = drpcmetadata.Add(ctx, "authorization", v)
+		}
+		if v := r.Header.Get("X-API-Key"); v != "" {
+			ctx = drpcmetadata.Add(ctx, "x-api-key", v)
+		}
+		// drpchttp adds X-Drpc-Metadata on top of the request context, so explicit metadata wins.
+		h.ServeHTTP(w, r.WithContext(ctx))
+	}))
+}
+
+type peerIPKey struct{}
+
+// withPeerIP records the client address of an HTTP request for remoteIP, since such calls have no
+// transport in their context.
+func withPeerIP(ctx context.Context, remoteAddr string) context.Context {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	return context.WithValue(ctx, peerIPKey{}, strings.TrimSpace(host))
+}
+
+// serveDRPC is drpcserver.Server.Serve, except that every connection is attached to its context
+// so handlers can see the peer address, and TLS connections finish their handshake within
+// tlsHandshakeTimeout before they are served.
+func serveDRPC(ctx context.Context, srv *drpcserver.Server, lis net.Listener) error {
+	go func() {
+		<-ctx.Done()
+		_ = lis.Close()
+	}()
+
+	for {
+		conn, err := lis.Accept()
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, net.ErrClosed) || errors.Is(err, drpcmigrate.Closed) {
+				return nil
+			}
+			log.Printf("dRPC accept error: %v", err)
+			time.Sleep(500 * time.Millisecond)
+			continue
+		}
+
+		go func() {
+			if tlsConn, ok := conn.(*tls.Conn); ok {
+				hctx, cancel := context.WithTimeout(ctx, tlsHandshakeTimeout)
+				err := tlsConn.HandshakeContext(hctx)
+				cancel()
+				if err != nil {
+					log.Printf("dRPC TLS handshake error: %v", err)
+					_ = conn.Close()
+					return
+				}
+			}
+			if err := srv.ServeOne(drpcctx.WithTransport(ctx, conn), conn); err != nil {
+				log.Printf("dRPC connection error: %v", err)
+			}
+		}()
+	}
+}
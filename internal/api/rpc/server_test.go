@@ -0,0 +1,175 @@
+package rpc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/Niutaq/Gix/api/proto/v1"
+	"github.com/Niutaq/Gix/internal/infrastructure"
+	"github.com/Niutaq/Gix/pkg/tlsconf"
+	"storj.io/drpc/drpcconn"
+	"storj.io/drpc/drpcerr"
+	"storj.io/drpc/drpcmigrate"
+)
+
+// TestMultiplex checks that HTTP requests, dRPC-over-HTTP calls and headered dRPC connections are
+// all served from one listener.
+func TestMultiplex(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	app := &infrastructure.AppState{}
+	mux := http.NewServeMux()
+	mux.Handle(HTTPPrefix+"/", NewHTTPHandler(app))
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) { _, _ = io.WriteString(w, "ok") })
+	srv := &http.Server{Handler: mux}
+	go func() { _ = srv.Serve(Multiplex(ctx, app, lis)) }()
+	defer srv.Close()
+	base := "http://" + lis.Addr().String()
+
+	resp, err := http.Get(base + "/healthz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != "ok" {
+		t.Errorf("GET /healthz = %q, want ok", body)
+	}
+
+	resp, err = http.Post(base+HTTPPrefix+"/v1.RatesService/GetQuote", "application/json", strings.NewReader(`{}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ = io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusOK || !strings.Contains(string(body), "currency is required") {
+		t.Errorf("invalid GetQuote over HTTP = %d %s, want a dRPC error", resp.StatusCode, body)
+	}
+
+	raw, err := drpcmigrate.DialWithHeader(ctx, "tcp", lis.Addr().String(), drpcmigrate.DRPCHeader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn := drpcconn.New(raw)
+	defer conn.Close()
+
+	stream, err := pb.NewDRPCRatesServiceClient(conn).StreamAlerts(ctx, &pb.StreamAlertsRequest{Owner: "me"})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if drpcerr.Code(err) != CodeUnauthenticated {
+		t.Errorf("StreamAlerts without credentials: %v, want Unauthenticated", err)
+	}
+}
+
+// TestMultiplexTLS checks that with TLS configured, dRPC connections handshake after the header and
+// are verified by a pinning client, while HTTP on the same port stays plaintext.
+func TestMultiplexTLS(t *testing.T) {
+	defer func(d time.Duration) { tlsHandshakeTimeout = d }(tlsHandshakeTimeout)
+	tlsHandshakeTimeout = time.Second
+	cert := selfSigned(t)
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	app := &infrastructure.AppState{DRPCTLS: &tls.Config{Certificates: []tls.Certificate{cert}}}
+	srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { _, _ = io.WriteString(w, "ok") })}
+	go func() { _ = srv.Serve(Multiplex(ctx, app, lis)) }()
+	defer srv.Close()
+
+	resp, err := http.Get("http://" + lis.Addr().String() + "/healthz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	call := func(clientTLS *tls.Config) error {
+		raw, err := drpcmigrate.DialWithHeader(ctx, "tcp", lis.Addr().String(), drpcmigrate.DRPCHeader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if clientTLS != nil {
+			raw = tls.Client(raw, clientTLS)
+		}
+		conn := drpcconn.New(raw)
+		defer conn.Close()
+		_, err = pb.NewDRPCRatesServiceClient(conn).GetQuote(ctx, &pb.QuoteRequest{})
+		return err
+	}
+
+	pinned, err := tlsconf.Client(tlsconf.ClientOptions{ServerName: "localhost", Pins: []string{tlsconf.Fingerprint(cert.Leaf)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := call(pinned); err == nil || !strings.Contains(err.Error(), "currency is required") {
+		t.Errorf("GetQuote over TLS = %v, want the validation error", err)
+	}
+
+	if err := call(nil); err == nil || strings.Contains(err.Error(), "currency is required") {
+		t.Errorf("plaintext GetQuote = %v, want a transport error", err)
+	}
+
+	other := selfSigned(t)
+	wrongPin, _ := tlsconf.Client(tlsconf.ClientOptions{ServerName: "localhost", Pins: []string{tlsconf.Fingerprint(other.Leaf)}})
+	if err := call(wrongPin); err == nil || strings.Contains(err.Error(), "currency is required") {
+		t.Errorf("GetQuote with a wrong pin = %v, want a handshake error", err)
+	}
+
+	// A client that sends the header but never starts the handshake is disconnected.
+	stalled, err := drpcmigrate.DialWithHeader(ctx, "tcp", lis.Addr().String(), drpcmigrate.DRPCHeader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stalled.Close()
+	if _, err := stalled.Write(nil); err != nil { // sends just the header
+		t.Fatal(err)
+	}
+	_ = stalled.SetReadDeadline(time.Now().Add(5 * time.Second))
+	if _, err := stalled.Read(make([]byte, 1)); !errors.Is(err, io.EOF) {
+		t.Errorf("stalled handshake read = %v, want EOF", err)
+	}
+}
+
+// selfSigned generates a certificate for localhost.
+func selfSigned(t *testing.T) tls.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, _ := x509.ParseCertificate(der)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}
+}
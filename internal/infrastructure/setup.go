@@ -28,6 +28,8 @@ func ConnectToDB(ctx context.Context, databaseURL string) (*pgxpool.Pool, error)
 func InitSchema(ctx context.Context, db *pgxpool.Pool) error {
 	const schema = `
     CREATE EXTENSION IF NOT EXISTS timescaledb;
+    CREATE EXTENSION IF NOT EXISTS cube;
+    CREATE EXTENSION IF NOT EXISTS earthdistance;
     CREATE TABLE IF NOT EXISTS cantors (
         id SERIAL PRIMARY KEY,
         name VARCHAR(50) NOT NULL UNIQUE,
@@ -37,18 +39,195 @@ func InitSchema(ctx context.Context, db *pgxpool.Pool) error {
         units INTEGER DEFAULT 1,
         latitude DECIMAL(9,6) DEFAULT 0,
         longitude DECIMAL(9,6) DEFAULT 0,
-        address TEXT
+        address TEXT,
+        origin VARCHAR(12) NOT NULL DEFAULT 'system' CHECK (origin IN ('system', 'discovered', 'partner')),
+        enabled BOOLEAN NOT NULL DEFAULT TRUE
     );
+    -- 'origin' replaces recognising discovered cantors by their HEURISTIC strategy.
+    DO $$
+    BEGIN
+        IF NOT EXISTS (SELECT 1 FROM information_schema.columns
+            WHERE table_name = 'cantors' AND column_name = 'origin') THEN
+            ALTER TABLE cantors ADD COLUMN origin VARCHAR(12) NOT NULL DEFAULT 'system'
+                CHECK (origin IN ('system', 'discovered', 'partner'));
+            UPDATE cantors SET origin = 'discovered' WHERE strategy LIKE 'H%';
+        END IF;
+    END $$;
+    ALTER TABLE cantors ADD COLUMN IF NOT EXISTS enabled BOOLEAN NOT NULL DEFAULT TRUE;
+    CREATE INDEX IF NOT EXISTS cantors_earth_idx ON cantors USING gist (ll_to_earth(latitude::float8, longitude::float8));
+    CREATE INDEX IF NOT EXISTS cantors_lat_lon_idx ON cantors (latitude, longitude);
     CREATE TABLE IF NOT EXISTS rates (
         time TIMESTAMPTZ NOT NULL,
         cantor_id INTEGER NOT NULL REFERENCES cantors(id),
         currency VARCHAR(3) NOT NULL,
-        buy_rate NUMERIC(10, 4) NOT NULL,
-        sell_rate NUMERIC(10, 4) NOT NULL,
+        buy_rate NUMERIC(16, 8) NOT NULL,
+        sell_rate NUMERIC(16, 8) NOT NULL,
         UNIQUE (time, cantor_id, currency)
     );
     SELECT create_hypertable('rates', 'time', if_not_exists => TRUE);
-    SELECT add_retention_policy('rates', INTERVAL '30 days');
+    CREATE INDEX IF NOT EXISTS rates_cantor_currency_time_idx ON rates (cantor_id, currency, time DESC);
+
+    CREATE TABLE IF NOT EXISTS rate_heartbeats (
+        cantor_id INTEGER NOT NULL REFERENCES cantors(id) ON DELETE CASCADE,
+        currency VARCHAR(3) NOT NULL,
+        buy_rate NUMERIC(16, 8) NOT NULL,
+        sell_rate NUMERIC(16, 8) NOT NULL,
+        changed_at TIMESTAMPTZ NOT NULL,
+        observed_at TIMESTAMPTZ NOT NULL,
+        scraper_type VARCHAR(20),
+        confidence REAL,
+        PRIMARY KEY (cantor_id, currency)
+    );
+    ALTER TABLE rate_heartbeats ADD COLUMN IF NOT EXISTS scraper_type VARCHAR(20);
+    ALTER TABLE rate_heartbeats ADD COLUMN IF NOT EXISTS confidence REAL;
+    -- Widen legacy NUMERIC(10, 4) columns so per-unit rates keep every digit. This runs before
+    -- compression and the rollups below, which both block altering the columns of rates.
+    DO $$
+    BEGIN
+        IF (SELECT numeric_scale FROM information_schema.columns
+            WHERE table_name = 'rates' AND column_name = 'buy_rate') < 8 THEN
+            ALTER TABLE rates ALTER COLUMN buy_rate TYPE NUMERIC(16, 8), ALTER COLUMN sell_rate TYPE NUMERIC(16, 8);
+        END IF;
+        IF (SELECT numeric_scale FROM information_schema.columns
+            WHERE table_name = 'rate_heartbeats' AND column_name = 'buy_rate') < 8 THEN
+            ALTER TABLE rate_heartbeats ALTER COLUMN buy_rate TYPE NUMERIC(16, 8), ALTER COLUMN sell_rate TYPE NUMERIC(16, 8);
+        END IF;
+    END $$;
+
+    -- Raw rates older than a week are compressed. Hourly and daily rollups are maintained as
+    -- continuous aggregates and kept after the raw data is dropped; their refresh windows stay well
+    -- inside the raw retention so a refresh never erases rollups of dropped chunks. The raw
+    -- retention itself is set up by backfillRollups.
+    DO $$
+    BEGIN
+        IF NOT EXISTS (SELECT 1 FROM timescaledb_information.hypertables
+            WHERE hypertable_name = 'rates' AND compression_enabled) THEN
+            ALTER TABLE rates SET (timescaledb.compress, timescaledb.compress_segmentby = 'cantor_id, currency',
+                timescaledb.compress_orderby = 'time DESC');
+        END IF;
+    END $$;
+    SELECT add_compression_policy('rates', INTERVAL '7 days', if_not_exists => TRUE);
+    CREATE MATERIALIZED VIEW IF NOT EXISTS rates_hourly
+    WITH (timescaledb.continuous, timescaledb.materialized_only = false) AS
+    SELECT time_bucket('1 hour', time) AS bucket, cantor_id, currency,
+           AVG(buy_rate) AS buy_avg, first(buy_rate, time) AS buy_open, MAX(buy_rate) AS buy_high,
+           MIN(buy_rate) AS buy_low, last(buy_rate, time) AS buy_close,
+           AVG(sell_rate) AS sell_avg, first(sell_rate, time) AS sell_open, MAX(sell_rate) AS sell_high,
+           MIN(sell_rate) AS sell_low, last(sell_rate, time) AS sell_close
+    FROM rates
+    GROUP BY bucket, cantor_id, currency
+    WITH NO DATA;
+    SELECT add_continuous_aggregate_policy('rates_hourly', start_offset => INTERVAL '3 days',
+        end_offset => INTERVAL '1 hour', schedule_interval => INTERVAL '30 minutes', if_not_exists => TRUE);
+    CREATE MATERIALIZED VIEW IF NOT EXISTS rates_daily
+    WITH (timescaledb.continuous, timescaledb.materialized_only = false) AS
+    SELECT time_bucket('1 day', time) AS bucket, cantor_id, currency,
+           AVG(buy_rate) AS buy_avg, first(buy_rate, time) AS buy_open, MAX(buy_rate) AS buy_high,
+           MIN(buy_rate) AS buy_low, last(buy_rate, time) AS buy_close,
+           AVG(sell_rate) AS sell_avg, first(sell_rate, time) AS sell_open, MAX(sell_rate) AS sell_high,
+           MIN(sell_rate) AS sell_low, last(sell_rate, time) AS sell_close
+    FROM rates
+    GROUP BY bucket, cantor_id, currency
+    WITH NO DATA;
+    SELECT add_continuous_aggregate_policy('rates_daily', start_offset => INTERVAL '7 days',
+        end_offset => INTERVAL '1 hour', schedule_interval => INTERVAL '30 minutes', if_not_exists => TRUE);
+    DO $$
+    BEGIN
+        IF NOT EXISTS (SELECT 1 FROM timescaledb_information.continuous_aggregates
+            WHERE view_name = 'rates_hourly' AND compression_enabled) THEN
+            ALTER MATERIALIZED VIEW rates_hourly SET (timescaledb.compress = true);
+        END IF;
+        IF NOT EXISTS (SELECT 1 FROM timescaledb_information.continuous_aggregates
+            WHERE view_name = 'rates_daily' AND compression_enabled) THEN
+            ALTER MATERIALIZED VIEW rates_daily SET (timescaledb.compress = true);
+        END IF;
+    END $$;
+    SELECT add_compression_policy('rates_hourly', compress_after => INTERVAL '30 days', if_not_exists => TRUE);
+    SELECT add_compression_policy('rates_daily', compress_after => INTERVAL '90 days', if_not_exists => TRUE);
+    SELECT add_retention_policy('rates_hourly', INTERVAL '400 days', if_not_exists => TRUE);
+
+    CREATE TABLE IF NOT EXISTS rate_quarantine (
+        id SERIAL PRIMARY KEY,
+        cantor_id INTEGER NOT NULL REFERENCES cantors(id) ON DELETE CASCADE,
+        currency VARCHAR(3) NOT NULL,
+        buy_rate NUMERIC(16, 8) NOT NULL,
+        sell_rate NUMERIC(16, 8) NOT NULL,
+        units INTEGER NOT NULL DEFAULT 1,
+        scraper_type VARCHAR(20),
+        confidence REAL,
+        reason VARCHAR(30) NOT NULL,
+        reference_rate NUMERIC(16, 8),
+        status VARCHAR(10) NOT NULL DEFAULT 'pending',
+        detected_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+        resolved_at TIMESTAMPTZ
+    );
+    CREATE INDEX IF NOT EXISTS rate_quarantine_status_idx ON rate_quarantine (status, detected_at DESC);
+
+    CREATE TABLE IF NOT EXISTS alert_rules (
+        id SERIAL PRIMARY KEY,
+        owner VARCHAR(100) NOT NULL,
+        currency VARCHAR(3) NOT NULL,
+        side VARCHAR(4) NOT NULL,
+        kind VARCHAR(10) NOT NULL,
+        threshold NUMERIC(16, 8),
+        change_bp INTEGER,
+        cantor_id INTEGER REFERENCES cantors(id) ON DELETE CASCADE,
+        latitude DOUBLE PRECISION,
+        longitude DOUBLE PRECISION,
+        radius_km DOUBLE PRECISION,
+        cooldown_seconds INTEGER NOT NULL DEFAULT 3600,
+        created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+    );
+    CREATE INDEX IF NOT EXISTS alert_rules_currency_idx ON alert_rules (currency);
+    CREATE INDEX IF NOT EXISTS alert_rules_owner_idx ON alert_rules (owner);
+
+    CREATE TABLE IF NOT EXISTS alert_events (
+        id BIGSERIAL PRIMARY KEY,
+        rule_id INTEGER NOT NULL REFERENCES alert_rules(id) ON DELETE CASCADE,
+        owner VARCHAR(100) NOT NULL,
+        cantor_id INTEGER NOT NULL,
+        currency VARCHAR(3) NOT NULL,
+        side VARCHAR(4) NOT NULL,
+        kind VARCHAR(10) NOT NULL,
+        rate NUMERIC(16, 8) NOT NULL,
+        reference NUMERIC(16, 8),
+        fired_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+    );
+    CREATE INDEX IF NOT EXISTS alert_events_owner_time_idx ON alert_events (owner, fired_at DESC);
+
+    CREATE TABLE IF NOT EXISTS webhooks (
+        id SERIAL PRIMARY KEY,
+        url TEXT NOT NULL,
+        secret TEXT NOT NULL,
+        events TEXT[] NOT NULL,
+        enabled BOOLEAN NOT NULL DEFAULT TRUE,
+        created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+    );
+
+    CREATE TABLE IF NOT EXISTS webhook_deliveries (
+        id BIGSERIAL PRIMARY KEY,
+        webhook_id INTEGER NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+        event VARCHAR(30) NOT NULL,
+        payload JSONB NOT NULL,
+        status VARCHAR(10) NOT NULL DEFAULT 'pending',
+        attempts INTEGER NOT NULL DEFAULT 0,
+        response_code INTEGER,
+        last_error TEXT,
+        created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+        updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+    );
+    CREATE INDEX IF NOT EXISTS webhook_deliveries_webhook_idx ON webhook_deliveries (webhook_id, created_at DESC);
+
+    CREATE TABLE IF NOT EXISTS api_keys (
+        id SERIAL PRIMARY KEY,
+        name TEXT NOT NULL,
+        prefix VARCHAR(16) NOT NULL,
+        key_hash CHAR(64) NOT NULL UNIQUE,
+        role VARCHAR(10) NOT NULL,
+        created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+        last_used_at TIMESTAMPTZ,
+        revoked_at TIMESTAMPTZ
+    );
 
     CREATE TABLE IF NOT EXISTS provider_unit_costs (
         time        TIMESTAMPTZ       NOT NULL,
@@ -61,9 +240,62 @@ func InitSchema(ctx context.Context, db *pgxpool.Pool) error {
         resource_name VARCHAR(100)
     );
     SELECT create_hypertable('provider_unit_costs', 'time', if_not_exists => TRUE);
-    SELECT add_retention_policy('provider_unit_costs', INTERVAL '60 days');
+    SELECT add_retention_policy('provider_unit_costs', INTERVAL '60 days', if_not_exists => TRUE);
     `
-	_, err := db.Exec(ctx, schema)
+	if _, err := db.Exec(ctx, schema); err != nil {
+		return err
+	}
+	return backfillRollups(ctx, db)
+}
+
+// rateRollups are the continuous aggregates of rates with the start offset of their refresh
+// policy, up to which backfillRollups materializes them.
+var rateRollups = []struct {
+	view string
+	lag  time.Duration
+}{
+	{"rates_hourly", 3 * 24 * time.Hour},
+	{"rates_daily", 7 * 24 * time.Hour},
+}
+
+// backfillRollups rolls up the raw rates older than the refresh policies cover, which the
+// aggregates created WITH NO DATA would otherwise never hold, and only then lets the raw retention
+// drop chunks. The retention is paused meanwhile, so on an upgraded database no chunk is dropped
+// before it is rolled up; if the backfill fails, it stays paused until the next start.
+//
+// The refreshes start at the oldest raw rate rather than at the beginning of time: refreshing a
+// range whose raw chunks are gone would erase their rollups. Ranges already materialized are
+// skipped by TimescaleDB, so this is cheap on every start after the first. CALL cannot run inside
+// the transaction of a multi-statement Exec, hence the separate statements.
+func backfillRollups(ctx context.Context, db *pgxpool.Pool) error {
+	const retentionJobs = `
+    SELECT alter_job(job_id, scheduled => $1) FROM timescaledb_information.jobs
+    WHERE proc_name = 'policy_retention' AND hypertable_name = 'rates'`
+	if _, err := db.Exec(ctx, retentionJobs, false); err != nil {
+		return fmt.Errorf("pause rates retention: %w", err)
+	}
+
+	var oldest *time.Time
+	if err := db.QueryRow(ctx, "SELECT MIN(time) FROM rates").Scan(&oldest); err != nil {
+		return err
+	}
+	if oldest != nil {
+		for _, r := range rateRollups {
+			end := time.Now().Add(-r.lag)
+			if !oldest.Before(end) {
+				continue
+			}
+			refresh := fmt.Sprintf("CALL refresh_continuous_aggregate('%s', $1::timestamptz, $2::timestamptz)", r.view)
+			if _, err := db.Exec(ctx, refresh, *oldest, end); err != nil {
+				return fmt.Errorf("backfill %s: %w", r.view, err)
+			}
+		}
+	}
+
+	if _, err := db.Exec(ctx, "SELECT add_retention_policy('rates', INTERVAL '30 days', if_not_exists => TRUE)"); err != nil {
+		return err
+	}
+	_, err := db.Exec(ctx, retentionJobs, true)
 	return err
 }
 
@@ -82,6 +314,13 @@ func ConnectToRedis(ctx context.Context, redisURL string) (redis.UniversalClient
 	return client, nil
 }
 
+// RatesStreamName is the JetStream stream keeping every published rate update, on subjects
+// rates.<CURRENCY>, for a day.
+const RatesStreamName = "RATES"
+
+// WebhookDeliverySubject carries the IDs of webhook deliveries waiting to be attempted.
+const WebhookDeliverySubject = "webhooks.deliveries"
+
 func SetupNATS(nc *nats.Conn) nats.JetStreamContext {
 	if nc == nil {
 		return nil
@@ -95,7 +334,7 @@ func SetupNATS(nc *nats.Conn) nats.JetStreamContext {
 
 	log.Println("JetStream initialized.")
 	_, err = js.AddStream(&nats.StreamConfig{
-		Name:     "RATES",
+		Name:     RatesStreamName,
 		Subjects: []string{"rates.*"},
 		MaxAge:   24 * time.Hour,
 		Storage:  nats.FileStorage,
@@ -103,12 +342,23 @@ func SetupNATS(nc *nats.Conn) nats.JetStreamContext {
 	if err != nil {
 		log.Printf("Warning: Could not create stream: %v", err)
 	}
+
+	_, err = js.AddStream(&nats.StreamConfig{
+		Name:      "WEBHOOKS",
+		Subjects:  []string{WebhookDeliverySubject},
+		Retention: nats.WorkQueuePolicy,
+		MaxAge:    72 * time.Hour,
+		Storage:   nats.FileStorage,
+	})
+	if err != nil {
+		log.Printf("Warning: Could not create webhook stream: %v", err)
+	}
 	return js
 }
 
 func SyncCantorsToES(app *AppState) {
 	ctx := context.Background()
-	rows, err := app.DB.Query(ctx, "SELECT id, display_name, name, latitude, longitude FROM cantors")
+	rows, err := app.DB.Query(ctx, "SELECT id, display_name, name, latitude, longitude FROM cantors WHERE enabled")
 	if err != nil {
 		log.Printf("Sync DB Error: %v", err)
 		return
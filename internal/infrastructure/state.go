@@ -1,23 +1,32 @@
 package infrastructure
 
 import (
+	"crypto/tls"
+	"encoding/json"
 	"time"
 
+	"github.com/Niutaq/Gix/pkg/alerts"
 	"github.com/Niutaq/Gix/pkg/finops"
+	"github.com/Niutaq/Gix/pkg/money"
+	"github.com/Niutaq/Gix/pkg/ratelimit"
 	"github.com/Niutaq/Gix/pkg/search"
+	"github.com/Niutaq/Gix/pkg/streams"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/nats-io/nats.go"
 	"github.com/redis/go-redis/v9"
 )
 
-const MoneyMultiplier = 1000
-
 type AppState struct {
 	DB         *pgxpool.Pool
 	Cache      redis.UniversalClient
 	JS         nats.JetStreamContext
 	Search     *search.SearchEngine
 	Governance *finops.GovernanceEngine
+	Alerts     *alerts.Engine
+	JWTSecret  []byte // HS256 key for bearer tokens; JWTs are rejected when empty
+	Limiter    *ratelimit.Limiter
+	Streams    streams.Config // send queues of StreamRates clients
+	DRPCTLS    *tls.Config    // dRPC connections are TLS after the drpcmigrate header when set
 }
 
 type CantorInfo struct {
@@ -37,15 +46,206 @@ type CantorListResponse struct {
 	Longitude   float64 `json:"longitude"`
 	Strategy    string  `json:"strategy"`
 	Address     string  `json:"address"`
+	Origin      string  `json:"origin"`
+	Enabled     bool    `json:"enabled"`
+	// DistanceKm is set when the listing was queried with near=.
+	DistanceKm *float64 `json:"distanceKm,omitempty"`
+	// Buy and Sell are the current per-unit rates, set when the listing was queried with currency=.
+	Buy  *money.Rate `json:"buy,omitempty"`
+	Sell *money.Rate `json:"sell,omitempty"`
+}
+
+// GeoPoint is a WGS84 coordinate.
+type GeoPoint struct {
+	Lat float64
+	Lon float64
+}
+
+// BoundingBox is a map viewport. MinLon > MaxLon describes a box crossing the antimeridian.
+type BoundingBox struct {
+	MinLat, MinLon float64
+	MaxLat, MaxLon float64
+}
+
+// Contains reports whether the point lies inside the box, edges included.
+func (b BoundingBox) Contains(lat, lon float64) bool {
+	if lat < b.MinLat || lat > b.MaxLat {
+		return false
+	}
+	if b.MinLon <= b.MaxLon {
+		return lon >= b.MinLon && lon <= b.MaxLon
+	}
+	return lon >= b.MinLon || lon <= b.MaxLon
+}
+
+// CantorQuery filters, sorts and pages the cantor listing. Zero values disable a filter.
+type CantorQuery struct {
+	Near            *GeoPoint
+	RadiusKm        float64
+	BBox            *BoundingBox
+	Currency        string
+	Text            string
+	Sort            string
+	Limit           int
+	Cursor          string
+	IncludeDisabled bool
+}
+
+// Cantor is the full stored record of a cantor, as managed through the cantor API.
+type Cantor struct {
+	ID          int     `json:"id"`
+	Name        string  `json:"name"`
+	DisplayName string  `json:"displayName"`
+	BaseURL     string  `json:"baseURL"`
+	Strategy    string  `json:"strategy"`
+	Units       int     `json:"units"`
+	Latitude    float64 `json:"latitude"`
+	Longitude   float64 `json:"longitude"`
+	Address     string  `json:"address"`
+	Origin      string  `json:"origin"`
+	Enabled     bool    `json:"enabled"`
+}
+
+// CantorInput is the body of cantor create and update requests. On update, nil fields keep their
+// current value.
+type CantorInput struct {
+	Name        *string  `json:"name"`
+	DisplayName *string  `json:"displayName"`
+	BaseURL     *string  `json:"baseURL"`
+	Strategy    *string  `json:"strategy"`
+	Units       *int     `json:"units"`
+	Latitude    *float64 `json:"latitude"`
+	Longitude   *float64 `json:"longitude"`
+	Address     *string  `json:"address"`
+	Origin      *string  `json:"origin"`
+	Enabled     *bool    `json:"enabled"`
 }
 
 type HistoryParams struct {
-	CantorID int
-	Days     int
-	Cutoff   time.Time
+	CantorID  int
+	CantorIDs []int // restricts the history to a set of cantors when CantorID is unset
+	Days      int
+	Cutoff    time.Time
+	Until     time.Time // end of the range; zero means now
+	Interval  string    // bucket width such as "5m", "1h" or "1d"
+	Agg       string    // how a bucket is reduced to one value: avg, ohlc, min, max or last
+	PerCantor bool      // one series per cantor instead of the cross-cantor average
 }
 
 type ProcessedRates struct {
-	Buy  int64
-	Sell int64
+	Buy         money.Rate
+	Sell        money.Rate
+	Units       int
+	ScraperType string
+	Confidence  float64
+}
+
+// LatestRate is the current value of one cantor/currency pair as read from storage.
+type LatestRate struct {
+	CantorID    int
+	Currency    string
+	Buy         money.Rate
+	Sell        money.Rate
+	PastBuy     money.Rate // buy rate carried forward to 24 hours ago, zero when unknown
+	ObservedAt  time.Time
+	Units       int
+	ScraperType string
+	Confidence  float64
+	DisplayName string
+	Latitude    float64
+	Longitude   float64
+}
+
+// HistoryBucket is a single aggregated point of a rate history.
+type HistoryBucket struct {
+	Time     time.Time
+	CantorID int // set when the history is per cantor
+	Buy      money.Rate
+	Sell     money.Rate
+	BuyOHLC  Candle
+	SellOHLC Candle
+}
+
+// Candle is the open, high, low and close of a rate within one history bucket.
+type Candle struct {
+	Open  money.Rate
+	High  money.Rate
+	Low   money.Rate
+	Close money.Rate
+}
+
+// QuarantinedRate is a scraped value held back from publishing because it looked anomalous.
+type QuarantinedRate struct {
+	ID            int        `json:"id"`
+	CantorID      int        `json:"cantorID"`
+	Currency      string     `json:"currency"`
+	Buy           money.Rate `json:"buy"`
+	Sell          money.Rate `json:"sell"`
+	Units         int        `json:"units"`
+	ScraperType   string     `json:"scraperType"`
+	Confidence    float64    `json:"confidence"`
+	Reason        string     `json:"reason"`
+	ReferenceRate money.Rate `json:"referenceRate"`
+	Status        string     `json:"status"`
+	DetectedAt    time.Time  `json:"detectedAt"`
+	ResolvedAt    *time.Time `json:"resolvedAt,omitempty"`
+}
+
+// QuoteRequest describes an amount of currency to exchange, optionally near a location.
+type QuoteRequest struct {
+	Currency        string
+	Amount          money.Rate
+	Side            string // "sell": the user sells the currency for PLN, "buy": the user buys it with PLN
+	Lat, Lon        float64
+	HasLocation     bool
+	RadiusKm        float64    // 0 means unlimited
+	DistancePenalty money.Rate // PLN per kilometre
+}
+
+// Quote is the result of exchanging a QuoteRequest at one cantor.
+type Quote struct {
+	CantorID    int
+	DisplayName string
+	Rate        money.Rate
+	PLNAmount   money.Rate
+	Score       money.Rate
+	DistanceKm  float64 // -1 when unknown
+	ObservedAt  time.Time
+	Stale       bool
+	Units       int
+}
+
+// Webhook is an outbound HTTP subscription to server events.
+type Webhook struct {
+	ID        int       `json:"id"`
+	URL       string    `json:"url"`
+	Secret    string    `json:"secret,omitempty"` // only returned when the webhook is created
+	Events    []string  `json:"events"`
+	Enabled   bool      `json:"enabled"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// WebhookDelivery is one event queued for a webhook, with the outcome of its latest attempt.
+type WebhookDelivery struct {
+	ID           int64           `json:"id"`
+	WebhookID    int             `json:"webhookID"`
+	Event        string          `json:"event"`
+	Payload      json.RawMessage `json:"payload"`
+	Status       string          `json:"status"`
+	Attempts     int             `json:"attempts"`
+	ResponseCode int             `json:"responseCode,omitempty"`
+	LastError    string          `json:"lastError,omitempty"`
+	CreatedAt    time.Time       `json:"createdAt"`
+	UpdatedAt    time.Time       `json:"updatedAt"`
+}
+
+// APIKey is a stored API credential. Only the hash of the key is kept.
+type APIKey struct {
+	ID         int        `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Role       string     `json:"role"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+	RevokedAt  *time.Time `json:"revokedAt,omitempty"`
 }
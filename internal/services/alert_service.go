@@ -0,0 +1,245 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	pb "github.com/Niutaq/Gix/api/proto/v1"
+	"github.com/Niutaq/Gix/internal/infrastructure"
+	"github.com/Niutaq/Gix/pkg/alerts"
+	"github.com/Niutaq/Gix/pkg/auth"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"google.golang.org/protobuf/proto"
+)
+
+// AlertEventsChannel is the Redis pub/sub channel carrying fired alerts encoded as v1 AlertEvent.
+const AlertEventsChannel = "alerts_events"
+
+// Errors returned by the alert rule service.
+var (
+	// ErrAlertRuleNotFound is returned when a rule does not exist or belongs to another owner.
+	ErrAlertRuleNotFound = errors.New("alert rule not found")
+	// ErrAlertOwnerForbidden is returned when a request names an owner other than the caller.
+	ErrAlertOwnerForbidden = errors.New("alerts can only be managed by their owner")
+)
+
+const alertRuleColumns = `id, owner, currency, side, kind, threshold, COALESCE(change_bp, 0), COALESCE(cantor_id, 0),
+	COALESCE(latitude, 0), COALESCE(longitude, 0), COALESCE(radius_km, 0), cooldown_seconds, created_at`
+
+func scanAlertRule(row interface{ Scan(...any) error }) (alerts.Rule, error) {
+	var r alerts.Rule
+	err := row.Scan(&r.ID, &r.Owner, &r.Currency, &r.Side, &r.Kind, &r.Threshold, &r.ChangeBP, &r.CantorID,
+		&r.Lat, &r.Lon, &r.RadiusKm, &r.CooldownSeconds, &r.CreatedAt)
+	return r, err
+}
+
+// LoadAlertRules reloads every rule and the cantor locations into the in-memory engine.
+func LoadAlertRules(ctx context.Context, app *infrastructure.AppState) error {
+	if app.Alerts == nil {
+		return nil
+	}
+
+	rows, err := app.DB.Query(ctx, "SELECT "+alertRuleColumns+" FROM alert_rules")
+	if err != nil {
+		return err
+	}
+	var rules []alerts.Rule
+	for rows.Next() {
+		r, err := scanAlertRule(rows)
+		if err != nil {
+			log.Printf("Alert Rule Scan Error: %v", err)
+			continue
+		}
+		rules = append(rules, r)
+	}
+	rows.Close()
+
+	locations := make(map[int]alerts.Location)
+	locRows, err := app.DB.Query(ctx, "SELECT id, latitude, longitude FROM cantors")
+	if err != nil {
+		return err
+	}
+	defer locRows.Close()
+	for locRows.Next() {
+		var id int
+		var loc alerts.Location
+		if err := locRows.Scan(&id, &loc.Lat, &loc.Lon); err == nil {
+			locations[id] = loc
+		}
+	}
+
+	app.Alerts.Replace(rules, locations)
+	return nil
+}
+
+// StartAlertRuleSync loads the rules immediately and then refreshes them periodically, so rules
+// created through another replica are picked up.
+func StartAlertRuleSync(ctx context.Context, app *infrastructure.AppState, interval time.Duration) {
+	if err := LoadAlertRules(ctx, app); err != nil {
+		log.Printf("Alert Rules Load Error: %v", err)
+	}
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := LoadAlertRules(ctx, app); err != nil {
+					log.Printf("Alert Rules Load Error: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+// EvaluateAlerts runs a published rate through the alert engine, storing and broadcasting every fired event.
+func EvaluateAlerts(ctx context.Context, app *infrastructure.AppState, cantorID int, currency string, rates infrastructure.ProcessedRates) {
+	if app.Alerts == nil {
+		return
+	}
+
+	events := app.Alerts.Evaluate(alerts.Observation{
+		CantorID: cantorID,
+		Currency: currency,
+		Buy:      rates.Buy,
+		Sell:     rates.Sell,
+		At:       time.Now(),
+	})
+
+	for _, ev := range events {
+		err := app.DB.QueryRow(ctx, `
+			INSERT INTO alert_events (rule_id, owner, cantor_id, currency, side, kind, rate, reference, fired_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			RETURNING id`,
+			ev.RuleID, ev.Owner, ev.CantorID, ev.Currency, ev.Side, ev.Kind, ev.Rate, ev.Reference, ev.FiredAt).Scan(&ev.ID)
+		if err != nil {
+			log.Printf("Alert Event Insert Error: %v", err)
+			continue
+		}
+
+		protoBytes, err := proto.Marshal(AlertEventToProto(ev))
+		if err != nil {
+			log.Printf("Marshal Error: %v", err)
+			continue
+		}
+		app.Cache.Publish(ctx, AlertEventsChannel, protoBytes)
+	}
+}
+
+// AlertOwner returns the alert owner of the caller in ctx, see auth.Principal.Owner. A requested
+// owner is optional but must be the caller's own; alerts of other owners are never reachable.
+func AlertOwner(ctx context.Context, requested string) (string, error) {
+	principal, ok := auth.FromContext(ctx)
+	if !ok {
+		return "", ErrAlertOwnerForbidden
+	}
+	owner := principal.Owner()
+	if requested != "" && requested != owner {
+		return "", ErrAlertOwnerForbidden
+	}
+	return owner, nil
+}
+
+// CreateAlertRule validates and stores a rule, then reloads the engine.
+func CreateAlertRule(ctx context.Context, app *infrastructure.AppState, rule alerts.Rule) (alerts.Rule, error) {
+	if err := rule.Validate(); err != nil {
+		return rule, err
+	}
+
+	row := app.DB.QueryRow(ctx, `
+		INSERT INTO alert_rules (owner, currency, side, kind, threshold, change_bp, cantor_id, latitude, longitude, radius_km, cooldown_seconds)
+		VALUES ($1, $2, $3, $4, NULLIF($5::NUMERIC, 0), NULLIF($6, 0), NULLIF($7, 0), $8, $9, NULLIF($10, 0), $11)
+		RETURNING `+alertRuleColumns,
+		rule.Owner, rule.Currency, rule.Side, rule.Kind, rule.Threshold, rule.ChangeBP, rule.CantorID,
+		rule.Lat, rule.Lon, rule.RadiusKm, rule.CooldownSeconds)
+	created, err := scanAlertRule(row)
+	if err != nil {
+		return rule, err
+	}
+
+	if err := LoadAlertRules(ctx, app); err != nil {
+		log.Printf("Alert Rules Load Error: %v", err)
+	}
+	return created, nil
+}
+
+// ListAlertRules returns the rules of one owner.
+func ListAlertRules(ctx context.Context, db *pgxpool.Pool, owner string) ([]alerts.Rule, error) {
+	rows, err := db.Query(ctx, "SELECT "+alertRuleColumns+" FROM alert_rules WHERE owner = $1 ORDER BY id", owner)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rules := []alerts.Rule{}
+	for rows.Next() {
+		r, err := scanAlertRule(rows)
+		if err != nil {
+			log.Printf("Alert Rule Scan Error: %v", err)
+			continue
+		}
+		rules = append(rules, r)
+	}
+	return rules, rows.Err()
+}
+
+// DeleteAlertRule removes a rule of the given owner together with its events.
+func DeleteAlertRule(ctx context.Context, app *infrastructure.AppState, id int, owner string) error {
+	res, err := app.DB.Exec(ctx, "DELETE FROM alert_rules WHERE id = $1 AND owner = $2", id, owner)
+	if err != nil {
+		return err
+	}
+	if res.RowsAffected() == 0 {
+		return ErrAlertRuleNotFound
+	}
+	if err := LoadAlertRules(ctx, app); err != nil {
+		log.Printf("Alert Rules Load Error: %v", err)
+	}
+	return nil
+}
+
+// ListAlertEvents returns events fired for an owner since the given time, newest first.
+func ListAlertEvents(ctx context.Context, db *pgxpool.Pool, owner string, since time.Time, limit int) ([]alerts.Event, error) {
+	rows, err := db.Query(ctx, `
+		SELECT id, rule_id, owner, cantor_id, currency, side, kind, rate, reference, fired_at
+		FROM alert_events
+		WHERE owner = $1 AND fired_at >= $2
+		ORDER BY fired_at DESC
+		LIMIT $3`, owner, since, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []alerts.Event{}
+	for rows.Next() {
+		var ev alerts.Event
+		if err := rows.Scan(&ev.ID, &ev.RuleID, &ev.Owner, &ev.CantorID, &ev.Currency, &ev.Side, &ev.Kind,
+			&ev.Rate, &ev.Reference, &ev.FiredAt); err != nil {
+			log.Printf("Alert Event Scan Error: %v", err)
+			continue
+		}
+		events = append(events, ev)
+	}
+	return events, rows.Err()
+}
+
+// AlertEventToProto converts a fired event into its wire representation.
+func AlertEventToProto(ev alerts.Event) *pb.AlertEvent {
+	return &pb.AlertEvent{
+		Id:        ev.ID,
+		RuleId:    int32(ev.RuleID),
+		Owner:     ev.Owner,
+		CantorId:  int32(ev.CantorID),
+		Currency:  ev.Currency,
+		Side:      ev.Side,
+		Kind:      ev.Kind,
+		Rate:      ev.Rate.Proto(),
+		Reference: ev.Reference.Proto(),
+		FiredAt:   ev.FiredAt.Unix(),
+	}
+}
@@ -0,0 +1,35 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Niutaq/Gix/pkg/auth"
+)
+
+// TestAlertOwner checks that alert owners always come from the caller.
+func TestAlertOwner(t *testing.T) {
+	key := auth.NewContext(context.Background(), auth.Principal{Subject: "mobile", Role: auth.RoleViewer, KeyID: 7})
+	user := auth.NewContext(context.Background(), auth.Principal{Subject: "mobile", Role: auth.RoleViewer})
+
+	if owner, err := AlertOwner(key, ""); err != nil || owner != "key:7" {
+		t.Errorf("AlertOwner(key) = %q, %v", owner, err)
+	}
+	if owner, err := AlertOwner(user, "user:mobile"); err != nil || owner != "user:mobile" {
+		t.Errorf("AlertOwner(user, own) = %q, %v", owner, err)
+	}
+	for name, tc := range map[string]struct {
+		ctx       context.Context
+		requested string
+	}{
+		"other owner":      {key, "user:mobile"},
+		"key name":         {key, "mobile"},
+		"no principal":     {context.Background(), ""},
+		"no principal own": {context.Background(), "key:7"},
+	} {
+		if _, err := AlertOwner(tc.ctx, tc.requested); !errors.Is(err, ErrAlertOwnerForbidden) {
+			t.Errorf("%s: err = %v, want ErrAlertOwnerForbidden", name, err)
+		}
+	}
+}
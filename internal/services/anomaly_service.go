@@ -0,0 +1,195 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/Niutaq/Gix/internal/infrastructure"
+	"github.com/Niutaq/Gix/pkg/money"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Reasons recorded for quarantined rates.
+const (
+	AnomalyInvalidSpread    = "invalid_spread"
+	AnomalyHistoryDeviation = "history_deviation"
+	AnomalyPeerDeviation    = "peer_deviation"
+)
+
+// Quarantine statuses.
+const (
+	QuarantinePending  = "pending"
+	QuarantineReleased = "released"
+	QuarantineRejected = "rejected"
+)
+
+const (
+	// historyToleranceBP is the largest accepted move of the mid rate against the cantor's last value, in basis points.
+	historyToleranceBP = 1000
+	// peerToleranceBP is the largest accepted deviation of the mid rate from the peer median, in basis points.
+	peerToleranceBP = 1500
+	// minPeers is how many other cantors must quote the currency before the peer median is trusted.
+	minPeers = 3
+)
+
+// ErrQuarantineNotFound is returned when a quarantined rate does not exist or was already resolved.
+var ErrQuarantineNotFound = errors.New("quarantined rate not found or already resolved")
+
+// AnomalyBaseline is what a new rate is compared against.
+type AnomalyBaseline struct {
+	LastBuy    money.Rate // the cantor's current published value, zero when unknown
+	LastSell   money.Rate
+	PeerMedian money.Rate // median mid rate of other cantors, zero when unknown
+	Peers      int
+}
+
+// DetectAnomaly checks rates against the buy<sell invariant, the cantor's last value and the peer median.
+// It returns the reason and the reference value that triggered it, or an empty reason when the rate looks sane.
+// A move against history alone is accepted when enough peers confirm the new level.
+func DetectAnomaly(rates infrastructure.ProcessedRates, base AnomalyBaseline) (string, money.Rate) {
+	if rates.Buy.Sign() <= 0 || rates.Sell.Sign() <= 0 || rates.Buy.Cmp(rates.Sell) >= 0 {
+		return AnomalyInvalidSpread, money.Zero
+	}
+
+	mid := rates.Buy.Mid(rates.Sell)
+	peersKnown := base.Peers >= minPeers && base.PeerMedian.Sign() > 0
+	if peersKnown && abs(mid.ChangeBasisPoints(base.PeerMedian)) > peerToleranceBP {
+		return AnomalyPeerDeviation, base.PeerMedian
+	}
+
+	if !peersKnown && base.LastBuy.Sign() > 0 && base.LastSell.Sign() > 0 {
+		lastMid := base.LastBuy.Mid(base.LastSell)
+		if abs(mid.ChangeBasisPoints(lastMid)) > historyToleranceBP {
+			return AnomalyHistoryDeviation, lastMid
+		}
+	}
+
+	return "", money.Zero
+}
+
+func abs(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// LoadAnomalyBaseline reads the cantor's current value and the median of peers observed in the last 24 hours.
+func LoadAnomalyBaseline(ctx context.Context, db *pgxpool.Pool, cantorID int, currency string) (AnomalyBaseline, error) {
+	var base AnomalyBaseline
+	var median *float64
+	err := db.QueryRow(ctx, `
+		SELECT
+			(SELECT buy_rate FROM rate_heartbeats WHERE cantor_id = $1 AND currency = $2),
+			(SELECT sell_rate FROM rate_heartbeats WHERE cantor_id = $1 AND currency = $2),
+			percentile_cont(0.5) WITHIN GROUP (ORDER BY ((buy_rate + sell_rate) / 2)::FLOAT8),
+			COUNT(*)
+		FROM rate_heartbeats
+		WHERE currency = $2 AND cantor_id <> $1 AND observed_at > NOW() - INTERVAL '24 hours'`,
+		cantorID, currency).Scan(&base.LastBuy, &base.LastSell, &median, &base.Peers)
+	if median != nil {
+		base.PeerMedian = money.FromFloat(*median)
+	}
+	return base, err
+}
+
+// ScreenRates decides whether freshly scraped rates may be published. Anomalous values are written to
+// the quarantine table and false is returned; callers must then skip archiving and notification.
+// When the baseline cannot be loaded the rate is let through rather than blocking the pipeline.
+func ScreenRates(ctx context.Context, db *pgxpool.Pool, cantorID int, currency string, rates infrastructure.ProcessedRates) bool {
+	base, err := LoadAnomalyBaseline(ctx, db, cantorID, currency)
+	if err != nil {
+		log.Printf("Anomaly Baseline Error: %v", err)
+		base = AnomalyBaseline{}
+	}
+
+	reason, reference := DetectAnomaly(rates, base)
+	if reason == "" {
+		return true
+	}
+
+	log.Printf("Anomaly: cantor %d %s (%s / %s) quarantined: %s (reference %s)",
+		cantorID, currency, rates.Buy, rates.Sell, reason, reference)
+
+	// The same value scraped again while still pending is not queued twice.
+	_, err = db.Exec(ctx, `
+		INSERT INTO rate_quarantine (cantor_id, currency, buy_rate, sell_rate, units, scraper_type, confidence, reason, reference_rate)
+		SELECT $1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9::NUMERIC, 0)
+		WHERE NOT EXISTS (
+			SELECT 1 FROM rate_quarantine
+			WHERE cantor_id = $1 AND currency = $2 AND buy_rate = $3 AND sell_rate = $4 AND status = 'pending'
+		)`,
+		cantorID, currency, rates.Buy, rates.Sell, rates.Units, rates.ScraperType, rates.Confidence, reason, reference)
+	if err != nil {
+		log.Printf("Quarantine Insert Error: %v", err)
+	}
+	return false
+}
+
+// ListQuarantine returns quarantined rates with the given status, newest first.
+func ListQuarantine(ctx context.Context, db *pgxpool.Pool, status string, limit int) ([]infrastructure.QuarantinedRate, error) {
+	rows, err := db.Query(ctx, `
+		SELECT id, cantor_id, currency, buy_rate, sell_rate, units, COALESCE(scraper_type, ''), COALESCE(confidence, 0),
+			   reason, reference_rate, status, detected_at, resolved_at
+		FROM rate_quarantine
+		WHERE status = $1
+		ORDER BY detected_at DESC
+		LIMIT $2`, status, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := []infrastructure.QuarantinedRate{}
+	for rows.Next() {
+		var q infrastructure.QuarantinedRate
+		if err := rows.Scan(&q.ID, &q.CantorID, &q.Currency, &q.Buy, &q.Sell, &q.Units, &q.ScraperType, &q.Confidence,
+			&q.Reason, &q.ReferenceRate, &q.Status, &q.DetectedAt, &q.ResolvedAt); err != nil {
+			log.Printf("Quarantine Scan Error: %v", err)
+			continue
+		}
+		results = append(results, q)
+	}
+	return results, rows.Err()
+}
+
+// ResolveQuarantine marks a pending quarantined rate as released or rejected. Released values are
+// archived and published exactly as if the harvester had accepted them.
+func ResolveQuarantine(ctx context.Context, app *infrastructure.AppState, id int, release bool) (infrastructure.QuarantinedRate, error) {
+	status := QuarantineRejected
+	if release {
+		status = QuarantineReleased
+	}
+
+	var q infrastructure.QuarantinedRate
+	err := app.DB.QueryRow(ctx, `
+		UPDATE rate_quarantine SET status = $2, resolved_at = NOW()
+		WHERE id = $1 AND status = 'pending'
+		RETURNING id, cantor_id, currency, buy_rate, sell_rate, units, COALESCE(scraper_type, ''), COALESCE(confidence, 0),
+				  reason, reference_rate, status, detected_at, resolved_at`, id, status).
+		Scan(&q.ID, &q.CantorID, &q.Currency, &q.Buy, &q.Sell, &q.Units, &q.ScraperType, &q.Confidence,
+			&q.Reason, &q.ReferenceRate, &q.Status, &q.DetectedAt, &q.ResolvedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return q, ErrQuarantineNotFound
+	}
+	if err != nil {
+		return q, fmt.Errorf("resolve quarantine: %w", err)
+	}
+
+	if release {
+		rates := infrastructure.ProcessedRates{
+			Buy:         q.Buy,
+			Sell:        q.Sell,
+			Units:       q.Units,
+			ScraperType: q.ScraperType,
+			Confidence:  q.Confidence,
+		}
+		SaveToArchive(app.DB, q.CantorID, q.Currency, rates)
+		UpdateCacheAndNotify(ctx, app, q.CantorID, q.Currency, rates)
+		log.Printf("Quarantine: released rate %d for cantor %d %s", q.ID, q.CantorID, q.Currency)
+	}
+	return q, nil
+}
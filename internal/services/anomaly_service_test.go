@@ -0,0 +1,40 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/Niutaq/Gix/internal/infrastructure"
+	"github.com/Niutaq/Gix/pkg/money"
+)
+
+func TestDetectAnomaly(t *testing.T) {
+	rates := func(buy, sell string) infrastructure.ProcessedRates {
+		return infrastructure.ProcessedRates{Buy: money.MustParse(buy), Sell: money.MustParse(sell)}
+	}
+	history := AnomalyBaseline{LastBuy: money.MustParse("4.25"), LastSell: money.MustParse("4.31")}
+	peers := AnomalyBaseline{PeerMedian: money.MustParse("4.28"), Peers: 5}
+
+	tests := []struct {
+		name  string
+		rates infrastructure.ProcessedRates
+		base  AnomalyBaseline
+		want  string
+	}{
+		{"sane without baseline", rates("4.25", "4.31"), AnomalyBaseline{}, ""},
+		{"buy above sell", rates("4.31", "4.25"), AnomalyBaseline{}, AnomalyInvalidSpread},
+		{"zero sell", rates("4.25", "0"), AnomalyBaseline{}, AnomalyInvalidSpread},
+		{"small move against history", rates("4.27", "4.33"), history, ""},
+		{"phone number against history", rates("600.123", "600.456"), history, AnomalyHistoryDeviation},
+		{"outlier against peers", rates("5.10", "5.20"), peers, AnomalyPeerDeviation},
+		{"history jump confirmed by peers", rates("4.25", "4.31"),
+			AnomalyBaseline{LastBuy: money.MustParse("3.50"), LastSell: money.MustParse("3.60"), PeerMedian: money.MustParse("4.28"), Peers: 5}, ""},
+		{"too few peers falls back to history", rates("5.10", "5.20"),
+			AnomalyBaseline{LastBuy: money.MustParse("4.25"), LastSell: money.MustParse("4.31"), PeerMedian: money.MustParse("5.15"), Peers: 2}, AnomalyHistoryDeviation},
+	}
+
+	for _, tt := range tests {
+		if got, _ := DetectAnomaly(tt.rates, tt.base); got != tt.want {
+			t.Errorf("%s: DetectAnomaly = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/Niutaq/Gix/internal/infrastructure"
+	"github.com/Niutaq/Gix/pkg/auth"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ErrAPIKeyNotFound is returned when revoking a key that does not exist or is already revoked.
+var ErrAPIKeyNotFound = errors.New("api key not found")
+
+// Authenticate resolves a bearer credential into a principal. Credentials starting with the API key
+// prefix are looked up by hash; anything else is verified as a JWT signed with app.JWTSecret.
+func Authenticate(ctx context.Context, app *infrastructure.AppState, credential string) (auth.Principal, error) {
+	if credential == "" {
+		return auth.Principal{}, auth.ErrInvalidCredential
+	}
+
+	if !auth.IsAPIKey(credential) {
+		claims, err := auth.ParseToken(app.JWTSecret, credential, time.Now())
+		if err != nil {
+			return auth.Principal{}, err
+		}
+		return auth.Principal{Subject: claims.Subject, Role: claims.Role}, nil
+	}
+
+	var p auth.Principal
+	var role string
+	err := app.DB.QueryRow(ctx, `
+		UPDATE api_keys SET last_used_at = NOW()
+		WHERE key_hash = $1 AND revoked_at IS NULL
+		RETURNING id, name, role`, auth.HashAPIKey(credential)).Scan(&p.KeyID, &p.Subject, &role)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return p, auth.ErrInvalidCredential
+	}
+	if err != nil {
+		return p, err
+	}
+	p.Role, err = auth.ParseRole(role)
+	return p, err
+}
+
+// CreateAPIKey generates and stores a key. The plaintext key is returned once and never stored.
+func CreateAPIKey(ctx context.Context, db *pgxpool.Pool, name string, role auth.Role) (string, infrastructure.APIKey, error) {
+	key, prefix, hash, err := auth.GenerateAPIKey()
+	if err != nil {
+		return "", infrastructure.APIKey{}, err
+	}
+
+	rec := infrastructure.APIKey{Name: name, Prefix: prefix, Role: string(role)}
+	err = db.QueryRow(ctx, `
+		INSERT INTO api_keys (name, prefix, key_hash, role)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at`, name, prefix, hash, role).Scan(&rec.ID, &rec.CreatedAt)
+	if err != nil {
+		return "", rec, err
+	}
+	return key, rec, nil
+}
+
+// ListAPIKeys returns every key, including revoked ones.
+func ListAPIKeys(ctx context.Context, db *pgxpool.Pool) ([]infrastructure.APIKey, error) {
+	rows, err := db.Query(ctx, "SELECT id, name, prefix, role, created_at, last_used_at, revoked_at FROM api_keys ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := []infrastructure.APIKey{}
+	for rows.Next() {
+		var k infrastructure.APIKey
+		if err := rows.Scan(&k.ID, &k.Name, &k.Prefix, &k.Role, &k.CreatedAt, &k.LastUsedAt, &k.RevokedAt); err != nil {
+			log.Printf("API Key Scan Error: %v", err)
+			continue
+		}
+		keys = append(keys, k)
+	}
+	return keys, rows.Err()
+}
+
+// RevokeAPIKey disables a key. Revoked keys are kept for auditing.
+func RevokeAPIKey(ctx context.Context, db *pgxpool.Pool, id int) error {
+	res, err := db.Exec(ctx, "UPDATE api_keys SET revoked_at = NOW() WHERE id = $1 AND revoked_at IS NULL", id)
+	if err != nil {
+		return err
+	}
+	if res.RowsAffected() == 0 {
+		return ErrAPIKeyNotFound
+	}
+	return nil
+}
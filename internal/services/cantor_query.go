@@ -0,0 +1,388 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	pb "github.com/Niutaq/Gix/api/proto/v1"
+	"github.com/Niutaq/Gix/internal/infrastructure"
+	"github.com/Niutaq/Gix/pkg/auth"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Cantor listing sort orders. Buy lists the cantors paying the most for the currency first, sell
+// the ones charging the least.
+const (
+	SortCantorID       = "id"
+	SortCantorName     = "name"
+	SortCantorDistance = "distance"
+	SortCantorBuy      = "buy"
+	SortCantorSell     = "sell"
+)
+
+// Cantor listing page sizes and the largest accepted search radius.
+const (
+	DefaultCantorPageSize = 200
+	MaxCantorPageSize     = 1000
+	MaxCantorRadiusKm     = 1000
+)
+
+// ErrInvalidCantorQuery is wrapped by every listing parameter error.
+var ErrInvalidCantorQuery = errors.New("invalid cantor query")
+
+// ErrCantorListForbidden is returned when a caller without the operator role asks for disabled cantors.
+var ErrCantorListForbidden = errors.New("listing disabled cantors requires role operator")
+
+// cantorCursor is the position after the last row of a page: the sort key of that row and its ID.
+// The sort order is part of the cursor so it cannot be replayed against another ordering.
+type cantorCursor struct {
+	Sort  string `json:"s"`
+	Value string `json:"v,omitempty"`
+	ID    int    `json:"id"`
+}
+
+func encodeCantorCursor(c cantorCursor) string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeCantorCursor(s string) (cantorCursor, error) {
+	var c cantorCursor
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err == nil {
+		err = json.Unmarshal(raw, &c)
+	}
+	if err != nil {
+		return c, fmt.Errorf("%w: malformed cursor", ErrInvalidCantorQuery)
+	}
+	return c, nil
+}
+
+// NewCantorQuery parses listing parameters: near=lat,lon with optional radius_km, bbox=minLat,minLon,maxLat,maxLon,
+// currency, q (text), sort, limit and cursor.
+func NewCantorQuery(values url.Values) (infrastructure.CantorQuery, error) {
+	q := infrastructure.CantorQuery{
+		Currency: strings.ToUpper(strings.TrimSpace(values.Get("currency"))),
+		Text:     strings.TrimSpace(values.Get("q")),
+		Sort:     values.Get("sort"),
+		Cursor:   values.Get("cursor"),
+		Limit:    DefaultCantorPageSize,
+	}
+
+	if near := values.Get("near"); near != "" {
+		coords, err := parseFloats(near, 2)
+		if err != nil || !validLatLon(coords[0], coords[1]) {
+			return q, fmt.Errorf("%w: near must be lat,lon", ErrInvalidCantorQuery)
+		}
+		q.Near = &infrastructure.GeoPoint{Lat: coords[0], Lon: coords[1]}
+	}
+	if radius := values.Get("radius_km"); radius != "" {
+		r, err := strconv.ParseFloat(radius, 64)
+		if err != nil || r <= 0 || r > MaxCantorRadiusKm {
+			return q, fmt.Errorf("%w: radius_km must be in (0, %d]", ErrInvalidCantorQuery, MaxCantorRadiusKm)
+		}
+		if q.Near == nil {
+			return q, fmt.Errorf("%w: radius_km requires near", ErrInvalidCantorQuery)
+		}
+		q.RadiusKm = r
+	}
+	if bbox := values.Get("bbox"); bbox != "" {
+		b, err := parseFloats(bbox, 4)
+		if err != nil || !validLatLon(b[0], b[1]) || !validLatLon(b[2], b[3]) || b[0] > b[2] {
+			return q, fmt.Errorf("%w: bbox must be minLat,minLon,maxLat,maxLon", ErrInvalidCantorQuery)
+		}
+		q.BBox = &infrastructure.BoundingBox{MinLat: b[0], MinLon: b[1], MaxLat: b[2], MaxLon: b[3]}
+	}
+	if limit := values.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 1 || n > MaxCantorPageSize {
+			return q, fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidCantorQuery, MaxCantorPageSize)
+		}
+		q.Limit = n
+	}
+	if q.Currency != "" && len(q.Currency) != 3 {
+		return q, fmt.Errorf("%w: unknown currency %q", ErrInvalidCantorQuery, q.Currency)
+	}
+	return q, nil
+}
+
+// CantorQueryFromV1 parses the listing parameters of a ListCantors call with the rules of NewCantorQuery.
+func CantorQueryFromV1(req *pb.ListCantorsRequest) (infrastructure.CantorQuery, error) {
+	values := url.Values{}
+	set := func(key, value string) {
+		if value != "" {
+			values.Set(key, value)
+		}
+	}
+	formatFloat := func(f float64) string { return strconv.FormatFloat(f, 'f', -1, 64) }
+
+	if req.Near != nil {
+		set("near", formatFloat(req.Near.Lat)+","+formatFloat(req.Near.Lon))
+	}
+	if req.RadiusKm != 0 {
+		set("radius_km", formatFloat(req.RadiusKm))
+	}
+	if b := req.Bbox; b != nil {
+		set("bbox", strings.Join([]string{formatFloat(b.MinLat), formatFloat(b.MinLon), formatFloat(b.MaxLat), formatFloat(b.MaxLon)}, ","))
+	}
+	if req.Limit != 0 {
+		set("limit", strconv.Itoa(int(req.Limit)))
+	}
+	set("currency", req.Currency)
+	set("q", req.Q)
+	set("sort", req.Sort)
+	set("cursor", req.Cursor)
+
+	q, err := NewCantorQuery(values)
+	q.IncludeDisabled = req.All
+	return q, err
+}
+
+// CantorsToV1 converts a listing page to its RPC form.
+func CantorsToV1(cantors []infrastructure.CantorListResponse, next string) *pb.ListCantorsResponse {
+	out := &pb.ListCantorsResponse{Cantors: make([]*pb.Cantor, 0, len(cantors)), NextCursor: next}
+	for _, c := range cantors {
+		item := &pb.Cantor{
+			Id:          int32(c.ID),
+			DisplayName: c.DisplayName,
+			Name:        c.Name,
+			Latitude:    c.Latitude,
+			Longitude:   c.Longitude,
+			Strategy:    c.Strategy,
+			Address:     c.Address,
+			Origin:      c.Origin,
+			Enabled:     c.Enabled,
+			DistanceKm:  -1,
+		}
+		if c.DistanceKm != nil {
+			item.DistanceKm = *c.DistanceKm
+		}
+		if c.Buy != nil {
+			item.Buy = c.Buy.Proto()
+		}
+		if c.Sell != nil {
+			item.Sell = c.Sell.Proto()
+		}
+		out.Cantors = append(out.Cantors, item)
+	}
+	return out
+}
+
+func parseFloats(s string, n int) ([]float64, error) {
+	parts := strings.Split(s, ",")
+	if len(parts) != n {
+		return nil, fmt.Errorf("expected %d values", n)
+	}
+	out := make([]float64, n)
+	for i, p := range parts {
+		f, err := strconv.ParseFloat(strings.TrimSpace(p), 64)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = f
+	}
+	return out, nil
+}
+
+func validLatLon(lat, lon float64) bool {
+	return lat >= -90 && lat <= 90 && lon >= -180 && lon <= 180
+}
+
+// cantorSortKey returns the SQL sort expression of an ordering, its direction, and the type its cursor
+// value is cast to. The ID ordering has no separate key.
+func cantorSortKey(sort, distance string) (expr, dir, cast string) {
+	switch sort {
+	case SortCantorName:
+		return "c.display_name", "ASC", "text"
+	case SortCantorDistance:
+		return distance, "ASC", "float8"
+	case SortCantorBuy:
+		return "h.buy_rate", "DESC", "numeric"
+	case SortCantorSell:
+		return "h.sell_rate", "ASC", "numeric"
+	}
+	return "", "", ""
+}
+
+// BuildCantorQuery builds the listing query for q. It selects one row more than the page size so the
+// caller can tell whether another page follows. Distances use the earthdistance extension and are
+// pre-filtered with earth_box so the GiST index on ll_to_earth(latitude, longitude) applies.
+func BuildCantorQuery(q infrastructure.CantorQuery) (string, []any, error) {
+	if q.Sort == "" {
+		q.Sort = SortCantorID
+	}
+	switch q.Sort {
+	case SortCantorID, SortCantorName:
+	case SortCantorDistance:
+		if q.Near == nil {
+			return "", nil, fmt.Errorf("%w: sort=distance requires near", ErrInvalidCantorQuery)
+		}
+	case SortCantorBuy, SortCantorSell:
+		if q.Currency == "" {
+			return "", nil, fmt.Errorf("%w: sort=%s requires currency", ErrInvalidCantorQuery, q.Sort)
+		}
+	default:
+		return "", nil, fmt.Errorf("%w: unsupported sort %q", ErrInvalidCantorQuery, q.Sort)
+	}
+
+	var args []any
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	const cantorPoint = "ll_to_earth(c.latitude::float8, c.longitude::float8)"
+	where := []string{"(c.enabled OR " + arg(q.IncludeDisabled) + ")"}
+	distance, buy, sell, join := "NULL::float8", "NULL::numeric", "NULL::numeric", ""
+
+	if q.Near != nil {
+		origin := fmt.Sprintf("ll_to_earth(%s, %s)", arg(q.Near.Lat), arg(q.Near.Lon))
+		distance = fmt.Sprintf("earth_distance(%s, %s)", origin, cantorPoint)
+		if q.RadiusKm > 0 {
+			radius := arg(q.RadiusKm * 1000)
+			where = append(where,
+				fmt.Sprintf("earth_box(%s, %s) @> %s", origin, radius, cantorPoint),
+				fmt.Sprintf("%s <= %s", distance, radius))
+		}
+	}
+	if b := q.BBox; b != nil {
+		where = append(where, fmt.Sprintf("c.latitude BETWEEN %s AND %s", arg(b.MinLat), arg(b.MaxLat)))
+		if b.MinLon <= b.MaxLon {
+			where = append(where, fmt.Sprintf("c.longitude BETWEEN %s AND %s", arg(b.MinLon), arg(b.MaxLon)))
+		} else {
+			where = append(where, fmt.Sprintf("(c.longitude >= %s OR c.longitude <= %s)", arg(b.MinLon), arg(b.MaxLon)))
+		}
+	}
+	if q.Currency != "" {
+		join = "JOIN rate_heartbeats h ON h.cantor_id = c.id AND h.currency = " + arg(q.Currency)
+		buy, sell = "h.buy_rate", "h.sell_rate"
+	}
+	if q.Text != "" {
+		pattern := arg("%" + escapeLike(q.Text) + "%")
+		where = append(where, fmt.Sprintf("(c.display_name ILIKE %[1]s OR c.name ILIKE %[1]s OR c.address ILIKE %[1]s)", pattern))
+	}
+
+	order := "c.id ASC"
+	key, dir, cast := cantorSortKey(q.Sort, distance)
+	if key != "" {
+		order = key + " " + dir + ", c.id ASC"
+	}
+
+	if q.Cursor != "" {
+		cur, err := decodeCantorCursor(q.Cursor)
+		if err != nil {
+			return "", nil, err
+		}
+		if cur.Sort != q.Sort {
+			return "", nil, fmt.Errorf("%w: cursor belongs to sort=%s", ErrInvalidCantorQuery, cur.Sort)
+		}
+		if key == "" {
+			where = append(where, "c.id > "+arg(cur.ID))
+		} else {
+			cmp := ">"
+			if dir == "DESC" {
+				cmp = "<"
+			}
+			v, id := arg(cur.Value), arg(cur.ID)
+			where = append(where, fmt.Sprintf("(%[1]s %[2]s %[3]s::%[4]s OR (%[1]s = %[3]s::%[4]s AND c.id > %[5]s))",
+				key, cmp, v, cast, id))
+		}
+	}
+
+	query := fmt.Sprintf(`
+		SELECT c.id, c.display_name, c.name, c.latitude, c.longitude, c.strategy, COALESCE(c.address, ''), c.origin,
+			   c.enabled, %s AS distance, %s, %s
+		FROM cantors c
+		%s
+		WHERE %s
+		ORDER BY %s
+		LIMIT %s`, distance, buy, sell, join, strings.Join(where, "\n\t\t  AND "), order, arg(cantorPageSize(q)+1))
+	return query, args, nil
+}
+
+func cantorPageSize(q infrastructure.CantorQuery) int {
+	if q.Limit <= 0 || q.Limit > MaxCantorPageSize {
+		return DefaultCantorPageSize
+	}
+	return q.Limit
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// nextCantorCursor returns the cursor continuing after the given row.
+func nextCantorCursor(sort string, last infrastructure.CantorListResponse, distanceMeters float64) string {
+	if sort == "" {
+		sort = SortCantorID
+	}
+	cur := cantorCursor{Sort: sort, ID: last.ID}
+	switch sort {
+	case SortCantorName:
+		cur.Value = last.DisplayName
+	case SortCantorDistance:
+		cur.Value = strconv.FormatFloat(distanceMeters, 'g', -1, 64)
+	case SortCantorBuy:
+		cur.Value = last.Buy.String()
+	case SortCantorSell:
+		cur.Value = last.Sell.String()
+	}
+	return encodeCantorCursor(cur)
+}
+
+// ListCantors returns one page of the cantor listing and the cursor of the next page, which is empty on
+// the last page.
+func ListCantors(ctx context.Context, db *pgxpool.Pool, q infrastructure.CantorQuery) ([]infrastructure.CantorListResponse, string, error) {
+	if q.IncludeDisabled {
+		if p, ok := auth.FromContext(ctx); !ok || !p.Role.Allows(auth.RoleOperator) {
+			return nil, "", ErrCantorListForbidden
+		}
+	}
+	query, args, err := BuildCantorQuery(q)
+	if err != nil {
+		return nil, "", err
+	}
+	limit := cantorPageSize(q)
+
+	rows, err := db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	cantors := []infrastructure.CantorListResponse{}
+	var distances []float64
+	for rows.Next() {
+		var cr infrastructure.CantorListResponse
+		var distance *float64
+		if err := rows.Scan(&cr.ID, &cr.DisplayName, &cr.Name, &cr.Latitude, &cr.Longitude, &cr.Strategy, &cr.Address,
+			&cr.Origin, &cr.Enabled, &distance, &cr.Buy, &cr.Sell); err != nil {
+			return nil, "", err
+		}
+		if distance != nil {
+			km := *distance / 1000
+			cr.DistanceKm = &km
+			distances = append(distances, *distance)
+		}
+		cantors = append(cantors, cr)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", err
+	}
+
+	if len(cantors) <= limit {
+		return cantors, "", nil
+	}
+	cantors = cantors[:limit]
+	var lastDistance float64
+	if len(distances) >= limit {
+		lastDistance = distances[limit-1]
+	}
+	return cantors, nextCantorCursor(q.Sort, cantors[limit-1], lastDistance), nil
+}
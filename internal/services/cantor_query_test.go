@@ -0,0 +1,120 @@
+package services
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+
+	pb "github.com/Niutaq/Gix/api/proto/v1"
+	"github.com/Niutaq/Gix/internal/infrastructure"
+	"github.com/Niutaq/Gix/pkg/money"
+)
+
+func TestNewCantorQuery(t *testing.T) {
+	values, _ := url.ParseQuery("near=52.23,21.01&radius_km=5&bbox=52,20.9,52.4,21.2&currency=eur&q=centrum&sort=distance&limit=50")
+	q, err := NewCantorQuery(values)
+	if err != nil {
+		t.Fatalf("NewCantorQuery = %v", err)
+	}
+	if q.Near == nil || q.Near.Lat != 52.23 || q.Near.Lon != 21.01 || q.RadiusKm != 5 {
+		t.Errorf("near = %+v, radius = %v", q.Near, q.RadiusKm)
+	}
+	if q.BBox == nil || q.BBox.MinLat != 52 || q.BBox.MaxLon != 21.2 {
+		t.Errorf("bbox = %+v", q.BBox)
+	}
+	if q.Currency != "EUR" || q.Text != "centrum" || q.Limit != 50 {
+		t.Errorf("query = %+v", q)
+	}
+
+	for _, raw := range []string{
+		"near=52.23",
+		"near=95,21",
+		"radius_km=5",
+		"near=52,21&radius_km=0",
+		"bbox=52.4,20.9,52,21.2",
+		"limit=0",
+		"limit=5000",
+		"currency=EURO",
+	} {
+		values, _ := url.ParseQuery(raw)
+		if _, err := NewCantorQuery(values); !errors.Is(err, ErrInvalidCantorQuery) {
+			t.Errorf("NewCantorQuery(%s) = %v, want ErrInvalidCantorQuery", raw, err)
+		}
+	}
+}
+
+func TestCantorQueryFromV1(t *testing.T) {
+	q, err := CantorQueryFromV1(&pb.ListCantorsRequest{
+		Near:     &pb.GeoPoint{Lat: 52.23, Lon: 21.01},
+		RadiusKm: 5,
+		Bbox:     &pb.BoundingBox{MinLat: 52, MinLon: 20.9, MaxLat: 52.4, MaxLon: 21.2},
+		Currency: "eur",
+		Sort:     SortCantorDistance,
+		Limit:    50,
+		All:      true,
+	})
+	if err != nil {
+		t.Fatalf("CantorQueryFromV1 = %v", err)
+	}
+	if q.Near == nil || q.Near.Lat != 52.23 || q.RadiusKm != 5 || q.BBox == nil || q.BBox.MaxLon != 21.2 {
+		t.Errorf("area = %+v %v %+v", q.Near, q.RadiusKm, q.BBox)
+	}
+	if q.Currency != "EUR" || q.Limit != 50 || !q.IncludeDisabled {
+		t.Errorf("query = %+v", q)
+	}
+
+	if q, err := CantorQueryFromV1(&pb.ListCantorsRequest{}); err != nil || q.Limit != DefaultCantorPageSize {
+		t.Errorf("empty request = %+v, %v", q, err)
+	}
+	if _, err := CantorQueryFromV1(&pb.ListCantorsRequest{RadiusKm: 5}); !errors.Is(err, ErrInvalidCantorQuery) {
+		t.Errorf("radius without near: err = %v, want ErrInvalidCantorQuery", err)
+	}
+}
+
+func TestBuildCantorQueryValidation(t *testing.T) {
+	for name, q := range map[string]infrastructure.CantorQuery{
+		"distance without near": {Sort: SortCantorDistance},
+		"buy without currency":  {Sort: SortCantorBuy},
+		"unknown sort":          {Sort: "rating"},
+		"malformed cursor":      {Cursor: "!!"},
+		"cursor of other sort":  {Sort: SortCantorName, Cursor: encodeCantorCursor(cantorCursor{Sort: SortCantorID, ID: 3})},
+	} {
+		if _, _, err := BuildCantorQuery(q); !errors.Is(err, ErrInvalidCantorQuery) {
+			t.Errorf("%s: err = %v, want ErrInvalidCantorQuery", name, err)
+		}
+	}
+}
+
+func TestBuildCantorQueryKeyset(t *testing.T) {
+	buy := money.MustParse("4.2550")
+	last := infrastructure.CantorListResponse{ID: 7, Buy: &buy}
+	cursor := nextCantorCursor(SortCantorBuy, last, 0)
+
+	query, args, err := BuildCantorQuery(infrastructure.CantorQuery{Currency: "EUR", Sort: SortCantorBuy, Limit: 10, Cursor: cursor})
+	if err != nil {
+		t.Fatalf("BuildCantorQuery = %v", err)
+	}
+	if !strings.Contains(query, "ORDER BY h.buy_rate DESC, c.id ASC") {
+		t.Errorf("buy sort should order by the highest buy rate first:\n%s", query)
+	}
+	if !strings.Contains(query, "h.buy_rate < $3::numeric OR (h.buy_rate = $3::numeric AND c.id > $4)") {
+		t.Errorf("missing keyset condition:\n%s", query)
+	}
+	if args[2] != "4.2550" || args[3] != 7 || args[len(args)-1] != 11 {
+		t.Errorf("args = %v", args)
+	}
+}
+
+func TestBuildCantorQueryNear(t *testing.T) {
+	query, _, err := BuildCantorQuery(infrastructure.CantorQuery{
+		Near: &infrastructure.GeoPoint{Lat: 52.23, Lon: 21.01}, RadiusKm: 2, Sort: SortCantorDistance,
+	})
+	if err != nil {
+		t.Fatalf("BuildCantorQuery = %v", err)
+	}
+	// The earth_box pre-filter must use the indexed expression to hit cantors_earth_idx.
+	if !strings.Contains(query, "@> ll_to_earth(c.latitude::float8, c.longitude::float8)") {
+		t.Errorf("radius filter does not use the indexed expression:\n%s", query)
+	}
+}
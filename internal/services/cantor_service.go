@@ -0,0 +1,272 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Niutaq/Gix/internal/infrastructure"
+	"github.com/Niutaq/Gix/pkg/geo"
+	"github.com/Niutaq/Gix/pkg/scrapers"
+	"github.com/Niutaq/Gix/pkg/search"
+	"github.com/Niutaq/Gix/pkg/types"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Cantor origins. System cantors are curated and cannot be deleted through the API.
+const (
+	OriginSystem     = "system"
+	OriginDiscovered = "discovered"
+	OriginPartner    = "partner"
+)
+
+// CantorsUpdatesChannel is the Redis pub/sub channel announcing cantor changes as JSON
+// {"action": "created"|"updated"|"deleted", "cantor": {...}}.
+const CantorsUpdatesChannel = "cantors_updates"
+
+// Errors returned by the cantor management functions.
+var (
+	ErrCantorNotFound  = errors.New("cantor not found")
+	ErrCantorProtected = errors.New("cannot delete default system cantors")
+	ErrCantorConflict  = errors.New("a cantor with this name already exists")
+	ErrInvalidCantor   = errors.New("invalid cantor")
+)
+
+const cantorColumns = `id, name, display_name, base_url, strategy, COALESCE(units, 1), COALESCE(latitude, 0),
+	COALESCE(longitude, 0), COALESCE(address, ''), origin, enabled`
+
+func scanCantor(row pgx.Row) (infrastructure.Cantor, error) {
+	var c infrastructure.Cantor
+	err := row.Scan(&c.ID, &c.Name, &c.DisplayName, &c.BaseURL, &c.Strategy, &c.Units, &c.Latitude, &c.Longitude,
+		&c.Address, &c.Origin, &c.Enabled)
+	return c, err
+}
+
+// ValidateCantor normalises and checks a complete cantor record before it is stored. Errors wrap ErrInvalidCantor.
+func ValidateCantor(c *infrastructure.Cantor) error {
+	if err := validateCantor(c); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidCantor, err)
+	}
+	return nil
+}
+
+func validateCantor(c *infrastructure.Cantor) error {
+	c.DisplayName = strings.TrimSpace(c.DisplayName)
+	c.Name = strings.ToLower(strings.TrimSpace(c.Name))
+	if c.Name == "" {
+		c.Name = strings.ToLower(c.DisplayName)
+	}
+
+	switch {
+	case c.DisplayName == "" || len(c.DisplayName) > 100:
+		return fmt.Errorf("displayName must be 1-100 characters")
+	case len(c.Name) > 50:
+		return fmt.Errorf("name must be at most 50 characters")
+	case c.Units < 1:
+		return fmt.Errorf("units must be at least 1")
+	case !geo.ValidCoordinates(c.Latitude, c.Longitude):
+		return fmt.Errorf("coordinates out of range")
+	}
+
+	switch c.Origin {
+	case OriginSystem, OriginDiscovered, OriginPartner:
+	default:
+		return fmt.Errorf("origin must be system, discovered or partner")
+	}
+
+	if _, err := scrapers.GetScraper(c.Strategy); err != nil {
+		return err
+	}
+	if err := scrapers.ValidateURL(c.BaseURL); err != nil {
+		return fmt.Errorf("baseURL rejected: %v", err)
+	}
+	return nil
+}
+
+// checkCantorInput rejects input fields that clients may not set. System cantors are created only
+// by the schema setup, so the origin cannot be set to system through the API.
+func checkCantorInput(in infrastructure.CantorInput) error {
+	if in.Origin != nil && *in.Origin == OriginSystem {
+		return fmt.Errorf("%w: origin must be discovered or partner", ErrInvalidCantor)
+	}
+	return nil
+}
+
+// applyCantorInput copies the set fields of in onto c.
+func applyCantorInput(c *infrastructure.Cantor, in infrastructure.CantorInput) {
+	if in.Name != nil {
+		c.Name = *in.Name
+	}
+	if in.DisplayName != nil {
+		c.DisplayName = *in.DisplayName
+	}
+	if in.BaseURL != nil {
+		c.BaseURL = *in.BaseURL
+	}
+	if in.Strategy != nil {
+		c.Strategy = *in.Strategy
+	}
+	if in.Units != nil {
+		c.Units = *in.Units
+	}
+	if in.Latitude != nil {
+		c.Latitude = *in.Latitude
+	}
+	if in.Longitude != nil {
+		c.Longitude = *in.Longitude
+	}
+	if in.Address != nil {
+		c.Address = *in.Address
+	}
+	if in.Origin != nil {
+		c.Origin = *in.Origin
+	}
+	if in.Enabled != nil {
+		c.Enabled = *in.Enabled
+	}
+}
+
+// GetCantor returns one cantor.
+func GetCantor(ctx context.Context, db *pgxpool.Pool, id int) (infrastructure.Cantor, error) {
+	c, err := scanCantor(db.QueryRow(ctx, "SELECT "+cantorColumns+" FROM cantors WHERE id = $1", id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return c, ErrCantorNotFound
+	}
+	return c, err
+}
+
+// CreateCantor validates and stores a new cantor. Origin defaults to partner and units to 1.
+func CreateCantor(ctx context.Context, app *infrastructure.AppState, in infrastructure.CantorInput) (infrastructure.Cantor, error) {
+	c := infrastructure.Cantor{Units: 1, Origin: OriginPartner, Enabled: true}
+	if err := checkCantorInput(in); err != nil {
+		return c, err
+	}
+	applyCantorInput(&c, in)
+	if err := ValidateCantor(&c); err != nil {
+		return c, err
+	}
+
+	created, err := scanCantor(app.DB.QueryRow(ctx, `
+		INSERT INTO cantors (name, display_name, base_url, strategy, units, latitude, longitude, address, origin, enabled)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, ''), $9, $10)
+		RETURNING `+cantorColumns,
+		c.Name, c.DisplayName, c.BaseURL, c.Strategy, c.Units, c.Latitude, c.Longitude, c.Address, c.Origin, c.Enabled))
+	if err != nil {
+		return c, translateCantorError(err)
+	}
+
+	SyncCantor(ctx, app, "created", created)
+	return created, nil
+}
+
+// UpdateCantor applies a partial update to a cantor.
+func UpdateCantor(ctx context.Context, app *infrastructure.AppState, id int, in infrastructure.CantorInput) (infrastructure.Cantor, error) {
+	if err := checkCantorInput(in); err != nil {
+		return infrastructure.Cantor{}, err
+	}
+	c, err := GetCantor(ctx, app.DB, id)
+	if err != nil {
+		return c, err
+	}
+	applyCantorInput(&c, in)
+	if err := ValidateCantor(&c); err != nil {
+		return c, err
+	}
+
+	updated, err := scanCantor(app.DB.QueryRow(ctx, `
+		UPDATE cantors
+		SET name = $2, display_name = $3, base_url = $4, strategy = $5, units = $6, latitude = $7, longitude = $8,
+			address = NULLIF($9, ''), origin = $10, enabled = $11
+		WHERE id = $1
+		RETURNING `+cantorColumns,
+		id, c.Name, c.DisplayName, c.BaseURL, c.Strategy, c.Units, c.Latitude, c.Longitude, c.Address, c.Origin, c.Enabled))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return c, ErrCantorNotFound
+	}
+	if err != nil {
+		return c, translateCantorError(err)
+	}
+
+	SyncCantor(ctx, app, "updated", updated)
+	return updated, nil
+}
+
+// DeleteCantor removes a non-system cantor and its rate history. The foreign key of rates requires
+// the raw rows to go first, including those in compressed chunks; TimescaleDB decompresses only the
+// segments of this cantor since rates are segmented by cantor_id, so the cost grows with its history
+// (at most the 30-day raw retention). Hourly and daily rollups are left to their own retention.
+func DeleteCantor(ctx context.Context, app *infrastructure.AppState, id int) error {
+	c, err := GetCantor(ctx, app.DB, id)
+	if err != nil {
+		return err
+	}
+	if c.Origin == OriginSystem {
+		return ErrCantorProtected
+	}
+
+	if _, err := app.DB.Exec(ctx, "DELETE FROM rates WHERE cantor_id = $1", id); err != nil {
+		return err
+	}
+	res, err := app.DB.Exec(ctx, "DELETE FROM cantors WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+	if res.RowsAffected() == 0 {
+		return ErrCantorNotFound
+	}
+
+	SyncCantor(ctx, app, "deleted", c)
+	return nil
+}
+
+func translateCantorError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return ErrCantorConflict
+	}
+	return err
+}
+
+// SyncCantor brings Elasticsearch, the Redis rate cache and the alert engine in line with a
+// cantor change and announces it on CantorsUpdatesChannel. Disabled cantors are removed from search.
+func SyncCantor(ctx context.Context, app *infrastructure.AppState, action string, c infrastructure.Cantor) {
+	if app.Search != nil {
+		var err error
+		if action == "deleted" || !c.Enabled {
+			err = app.Search.DeleteCantor(c.ID)
+		} else {
+			err = app.Search.IndexCantor(search.CantorRecord{
+				ID:          c.ID,
+				Name:        c.Name,
+				DisplayName: c.DisplayName,
+				Location:    types.GeoPoint{Lat: c.Latitude, Lon: c.Longitude},
+			})
+		}
+		if err != nil {
+			log.Printf("Cantor ES Sync Error (%d): %v", c.ID, err)
+		}
+	}
+
+	if action != "created" {
+		keys := make([]string, 0, 2*len(types.GlobalCurrencies))
+		for _, curr := range types.GlobalCurrencies {
+			keys = append(keys, RateCacheKey(c.ID, curr), lastRateKey(c.ID, curr))
+		}
+		if err := app.Cache.Del(ctx, keys...).Err(); err != nil {
+			log.Printf("Cantor Cache Invalidation Error (%d): %v", c.ID, err)
+		}
+	}
+
+	if payload, err := json.Marshal(map[string]any{"action": action, "cantor": c}); err == nil {
+		app.Cache.Publish(ctx, CantorsUpdatesChannel, payload)
+	}
+
+	if err := LoadAlertRules(ctx, app); err != nil {
+		log.Printf("Alert Rules Load Error: %v", err)
+	}
+}
@@ -0,0 +1,56 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Niutaq/Gix/internal/infrastructure"
+	"github.com/Niutaq/Gix/pkg/scrapers"
+)
+
+func TestValidateCantor(t *testing.T) {
+	scrapers.AllowLocalhostForTesting = true
+	defer func() { scrapers.AllowLocalhostForTesting = false }()
+
+	valid := func() infrastructure.Cantor {
+		return infrastructure.Cantor{DisplayName: " Kantor Centrum ", BaseURL: "http://127.0.0.1/kursy", Strategy: "HEURISTIC",
+			Units: 1, Latitude: 52.23, Longitude: 21.01, Origin: OriginPartner}
+	}
+
+	c := valid()
+	if err := ValidateCantor(&c); err != nil {
+		t.Fatalf("ValidateCantor(valid) = %v", err)
+	}
+	if c.Name != "kantor centrum" || c.DisplayName != "Kantor Centrum" {
+		t.Errorf("normalised name = %q, display name = %q", c.Name, c.DisplayName)
+	}
+
+	for name, mutate := range map[string]func(*infrastructure.Cantor){
+		"unknown strategy": func(c *infrastructure.Cantor) { c.Strategy = "NOPE" },
+		"latitude":         func(c *infrastructure.Cantor) { c.Latitude = 91 },
+		"longitude":        func(c *infrastructure.Cantor) { c.Longitude = -181 },
+		"units":            func(c *infrastructure.Cantor) { c.Units = 0 },
+		"origin":           func(c *infrastructure.Cantor) { c.Origin = "user" },
+		"scheme":           func(c *infrastructure.Cantor) { c.BaseURL = "file:///etc/passwd" },
+		"private address":  func(c *infrastructure.Cantor) { c.BaseURL = "http://10.0.0.1/" },
+		"empty name":       func(c *infrastructure.Cantor) { c.DisplayName = "  " },
+	} {
+		c := valid()
+		mutate(&c)
+		if err := ValidateCantor(&c); !errors.Is(err, ErrInvalidCantor) {
+			t.Errorf("%s: err = %v, want ErrInvalidCantor", name, err)
+		}
+	}
+}
+
+func TestCheckCantorInput(t *testing.T) {
+	for origin, ok := range map[string]bool{OriginSystem: false, OriginPartner: true, OriginDiscovered: true} {
+		err := checkCantorInput(infrastructure.CantorInput{Origin: &origin})
+		if (err == nil) != ok || (err != nil && !errors.Is(err, ErrInvalidCantor)) {
+			t.Errorf("origin %s: err = %v, want ok %v", origin, err, ok)
+		}
+	}
+	if err := checkCantorInput(infrastructure.CantorInput{}); err != nil {
+		t.Errorf("empty input: %v", err)
+	}
+}
@@ -0,0 +1,163 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	pb "github.com/Niutaq/Gix/api/proto/v1"
+	"github.com/Niutaq/Gix/internal/infrastructure"
+	"github.com/Niutaq/Gix/pkg/scrapers"
+	"github.com/Niutaq/Gix/pkg/webhooks"
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrInvalidDiscovery is returned for a discovery request without a URL.
+var ErrInvalidDiscovery = errors.New("URL is required")
+
+// DiscoverRequest names the page of a cantor to add. The expected fields, e.g. from OpenStreetMap,
+// replace the metadata guessed from the page.
+type DiscoverRequest struct {
+	URL             string  `json:"url"`
+	ExpectedName    string  `json:"expected_name,omitempty"`
+	ExpectedLat     float64 `json:"expected_lat,omitempty"`
+	ExpectedLon     float64 `json:"expected_lon,omitempty"`
+	ExpectedAddress string  `json:"expected_address,omitempty"`
+}
+
+// Discovery is a stored discovered cantor with the EUR rates found on its page, "0.0000" when none were.
+type Discovery struct {
+	Cantor   infrastructure.CantorInfo
+	Address  string
+	Lat      float64
+	Lon      float64
+	BuyRate  string
+	SellRate string
+}
+
+// DiscoverCantor reads the metadata and EUR rates of the page in req, stores it as a discovered cantor
+// (updating a cantor of the same name) and announces it. Harvesting the other currencies is left to
+// the caller.
+func DiscoverCantor(ctx context.Context, app *infrastructure.AppState, req DiscoverRequest) (Discovery, error) {
+	if req.URL == "" {
+		return Discovery{}, ErrInvalidDiscovery
+	}
+
+	var info *scrapers.DiscoveredCantor
+	discoveryStart := time.Now()
+
+	if req.ExpectedName != "" {
+		info = &scrapers.DiscoveredCantor{
+			DisplayName: req.ExpectedName,
+			Latitude:    req.ExpectedLat,
+			Longitude:   req.ExpectedLon,
+			Address:     req.ExpectedAddress,
+		}
+		log.Printf("Discovery: Using provided OSM metadata for %s", req.ExpectedName)
+	} else {
+		log.Printf("Discovery: Analyzing URL %s for metadata...", req.URL)
+		var err error
+		info, err = scrapers.HeuristicDiscoverCantor(req.URL)
+		if err != nil {
+			log.Printf("Discovery Metadata Error: %v", err)
+			info = &scrapers.DiscoveredCantor{
+				DisplayName: "New Discovered Cantor",
+			}
+		}
+
+		if req.ExpectedLat != 0 || req.ExpectedLon != 0 {
+			info.Latitude = req.ExpectedLat
+			info.Longitude = req.ExpectedLon
+		}
+		if req.ExpectedAddress != "" {
+			info.Address = req.ExpectedAddress
+		}
+	}
+
+	discoveryDuration := time.Since(discoveryStart)
+	publishScrapeEvent(app, req.URL, "discovery", discoveryDuration)
+
+	log.Printf("Discovery: Attempting heuristic scrape for %s...", req.URL)
+	result, err := scrapers.HeuristicScrape(ctx, req.URL, "EUR")
+
+	scraperTypeUsed := "heuristic"
+	if result.UsedScraperType != "" {
+		scraperTypeUsed = result.UsedScraperType
+	}
+
+	if err != nil || result.BuyRate == "" || result.SellRate == "" {
+		log.Printf("Discovery: Heuristic scrape failed for %s (will use placeholder rates): %v", req.URL, err)
+		result.BuyRate = "0.0000"
+		result.SellRate = "0.0000"
+	} else {
+		log.Printf("Discovery: Found rates using %s: Buy=%s, Sell=%s", scraperTypeUsed, result.BuyRate, result.SellRate)
+	}
+	publishScrapeEvent(app, req.URL, scraperTypeUsed, time.Since(discoveryStart)-discoveryDuration)
+
+	var id int
+	nameLower := strings.ToLower(info.DisplayName)
+	err = app.DB.QueryRow(ctx,
+		"INSERT INTO cantors (name, display_name, base_url, strategy, latitude, longitude, address, origin) VALUES ($1, $2, $3, $4, $5, $6, $7, $8) ON CONFLICT (name) DO UPDATE SET display_name = EXCLUDED.display_name, base_url = EXCLUDED.base_url, address = EXCLUDED.address RETURNING id",
+		nameLower, info.DisplayName, req.URL, "HEURISTIC", info.Latitude, info.Longitude, info.Address, OriginDiscovered).Scan(&id)
+	if err != nil {
+		return Discovery{}, err
+	}
+
+	if cantor, err := GetCantor(ctx, app.DB, id); err == nil {
+		SyncCantor(ctx, app, "created", cantor)
+	}
+
+	EmitWebhookEvent(ctx, app, webhooks.EventCantorDiscovered, map[string]any{
+		"id":          id,
+		"displayName": info.DisplayName,
+		"url":         req.URL,
+		"address":     info.Address,
+		"latitude":    info.Latitude,
+		"longitude":   info.Longitude,
+	})
+
+	return Discovery{
+		Cantor: infrastructure.CantorInfo{
+			ID:          id,
+			DisplayName: info.DisplayName,
+			BaseURL:     req.URL,
+			Strategy:    "HEURISTIC",
+			Units:       1,
+		},
+		Address:  info.Address,
+		Lat:      info.Latitude,
+		Lon:      info.Longitude,
+		BuyRate:  result.BuyRate,
+		SellRate: result.SellRate,
+	}, nil
+}
+
+// publishScrapeEvent reports a discovery step to the FinOps cost tracking over JetStream.
+func publishScrapeEvent(app *infrastructure.AppState, url, scraperType string, d time.Duration) {
+	if app.JS == nil {
+		return
+	}
+	event := &pb.ScrapeCompletedEvent{
+		ProviderId:  url,
+		ScraperType: scraperType,
+		DurationMs:  d.Milliseconds(),
+		Timestamp:   time.Now().Unix(),
+	}
+	protoBytes, _ := proto.Marshal(event)
+	_, _ = app.JS.Publish("gix.scrape.v1.completed", protoBytes)
+}
+
+// DiscoveryToV1 converts a discovery result to its RPC form.
+func DiscoveryToV1(d Discovery) *pb.DiscoverResponse {
+	return &pb.DiscoverResponse{
+		Id:       int32(d.Cantor.ID),
+		Name:     d.Cantor.DisplayName,
+		Address:  d.Address,
+		Lat:      d.Lat,
+		Lon:      d.Lon,
+		BuyRate:  d.BuyRate,
+		SellRate: d.SellRate,
+	}
+}
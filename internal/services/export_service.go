@@ -0,0 +1,189 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Niutaq/Gix/internal/infrastructure"
+	"github.com/Niutaq/Gix/pkg/export"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// IntervalRaw exports the stored change points instead of buckets.
+const IntervalRaw = "raw"
+
+// Export limits. Ranges beyond the hourly rollup retention have nothing but daily buckets left, and
+// the point cap bounds the gap-filling work of a single cantor's series.
+const (
+	defaultExportDays = 7
+	maxExportDays     = MaxHistoryDays
+	maxExportPoints   = 100_000
+)
+
+// ExportRequest selects the rates of an export. Bucketed exports hold one row per cantor and bucket.
+type ExportRequest struct {
+	Currencies []string
+	CantorIDs  []int // empty exports every cantor
+	From       time.Time
+	To         time.Time
+	Interval   string // IntervalRaw or a history bucket width
+	Agg        string
+	Format     string
+}
+
+// NewExportRequest parses and validates export parameters as given on the query string or command
+// line: comma-separated currencies and cantor IDs, from/to as RFC 3339 or YYYY-MM-DD (defaulting to
+// the last week), the interval (raw by default, only within the raw retention), the aggregation and
+// the output format. Bucketed exports are read from the source HistorySource picks for their start.
+func NewExportRequest(currencies, cantorIDs, from, to, interval, agg, format string, now time.Time) (ExportRequest, error) {
+	var req ExportRequest
+	var err error
+	if req.Currencies, err = ParseCurrencies(strings.Split(currencies, ",")); err != nil {
+		return req, err
+	}
+	for _, raw := range strings.Split(cantorIDs, ",") {
+		if raw = strings.TrimSpace(raw); raw == "" {
+			continue
+		}
+		id, err := strconv.Atoi(raw)
+		if err != nil || id <= 0 {
+			return req, fmt.Errorf("invalid cantor ID %q", raw)
+		}
+		req.CantorIDs = append(req.CantorIDs, id)
+	}
+	if req.Format, err = export.ParseFormat(format); err != nil {
+		return req, err
+	}
+
+	req.To = now
+	if to != "" {
+		if req.To, err = parseExportTime(to); err != nil {
+			return req, err
+		}
+	}
+	req.From = req.To.AddDate(0, 0, -defaultExportDays)
+	if from != "" {
+		if req.From, err = parseExportTime(from); err != nil {
+			return req, err
+		}
+	}
+	if !req.From.Before(req.To) {
+		return req, fmt.Errorf("from must be before to")
+	}
+	if req.To.Sub(req.From) > maxExportDays*24*time.Hour {
+		return req, fmt.Errorf("range exceeds %d days", maxExportDays)
+	}
+
+	req.Interval = interval
+	if interval == "" {
+		req.Interval = IntervalRaw
+	}
+	if req.Interval == IntervalRaw && req.From.Before(now.AddDate(0, 0, -rawRetentionDays)) {
+		return req, fmt.Errorf("raw rates are kept for %d days; use an interval for older ranges", rawRetentionDays)
+	}
+	if req.Interval != IntervalRaw {
+		width, ok := historyIntervals[req.Interval]
+		if !ok {
+			return req, fmt.Errorf("unsupported interval %q (use raw, 5m, 15m, 1h, 6h or 1d)", interval)
+		}
+		if points := int(req.To.Sub(req.From) / width); points > maxExportPoints {
+			return req, fmt.Errorf("interval %s gives %d points per cantor, the limit is %d", req.Interval, points, maxExportPoints)
+		}
+	}
+	if req.Agg, err = parseHistoryAgg(agg); err != nil {
+		return req, err
+	}
+	return req, nil
+}
+
+func parseExportTime(raw string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.DateOnly, raw); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q (use RFC 3339 or YYYY-MM-DD)", raw)
+}
+
+// rawExportQuery selects stored change points. Only changes are archived, so a cantor's rate holds
+// from one row until its next.
+const rawExportQuery = `
+	SELECT time, cantor_id, currency, buy_rate, sell_rate
+	FROM rates
+	WHERE currency = ANY($1) AND time >= $2 AND time < $3 AND (cardinality($4::int[]) = 0 OR cantor_id = ANY($4))
+	ORDER BY time, cantor_id`
+
+// ExportHistory streams the requested rates into w and returns the number of rows written. Raw
+// exports are ordered by time; bucketed exports are read per currency through the history query
+// builder and ordered by currency, bucket and cantor.
+func ExportHistory(ctx context.Context, db *pgxpool.Pool, req ExportRequest, w export.Writer) (int, error) {
+	if req.Interval == IntervalRaw {
+		return exportRaw(ctx, db, req, w)
+	}
+
+	params := exportHistoryParams(req)
+	written := 0
+	for _, currency := range req.Currencies {
+		err := StreamHistory(ctx, db, currency, params, func(b infrastructure.HistoryBucket) error {
+			row := export.Row{Time: b.Time, CantorID: b.CantorID, Currency: currency, Buy: b.Buy, Sell: b.Sell}
+			if req.Agg == AggOHLC {
+				row.BuyOHLC = (*export.Candle)(&b.BuyOHLC)
+				row.SellOHLC = (*export.Candle)(&b.SellOHLC)
+			}
+			if err := w.Write(row); err != nil {
+				return err
+			}
+			written++
+			return nil
+		})
+		if err != nil {
+			return written, err
+		}
+	}
+	return written, nil
+}
+
+// exportHistoryParams translates a bucketed export into per-cantor history parameters. The cutoff,
+// not the length of the range, decides which source HistorySource reads.
+func exportHistoryParams(req ExportRequest) infrastructure.HistoryParams {
+	return infrastructure.HistoryParams{
+		CantorIDs: req.CantorIDs,
+		Days:      int((req.To.Sub(req.From) + 24*time.Hour - 1) / (24 * time.Hour)),
+		Cutoff:    req.From,
+		Until:     req.To,
+		Interval:  req.Interval,
+		Agg:       req.Agg,
+		PerCantor: true,
+	}
+}
+
+func exportRaw(ctx context.Context, db *pgxpool.Pool, req ExportRequest, w export.Writer) (int, error) {
+	cantorIDs := req.CantorIDs
+	if cantorIDs == nil {
+		cantorIDs = []int{}
+	}
+	rows, err := db.Query(ctx, rawExportQuery, req.Currencies, req.From, req.To, cantorIDs)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	written := 0
+	for rows.Next() {
+		var r export.Row
+		if err := rows.Scan(&r.Time, &r.CantorID, &r.Currency, &r.Buy, &r.Sell); err != nil {
+			log.Printf("Export Scan Error: %v", err)
+			continue
+		}
+		if err := w.Write(r); err != nil {
+			return written, err
+		}
+		written++
+	}
+	return written, rows.Err()
+}
@@ -0,0 +1,82 @@
+package services
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewExportRequest(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	req, err := NewExportRequest("eur,usd", "3, 5", "2026-03-01", "", "1h", "ohlc", "parquet", now)
+	if err != nil {
+		t.Fatalf("NewExportRequest = %v", err)
+	}
+	if !slices.Equal(req.Currencies, []string{"EUR", "USD"}) || !slices.Equal(req.CantorIDs, []int{3, 5}) {
+		t.Errorf("selection = %v %v", req.Currencies, req.CantorIDs)
+	}
+	if !req.From.Equal(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)) || !req.To.Equal(now) || req.Format != "parquet" {
+		t.Errorf("request = %+v", req)
+	}
+
+	req, err = NewExportRequest("EUR", "", "", "", "", "", "", now)
+	if err != nil || req.Interval != IntervalRaw || req.Format != "csv" || req.To.Sub(req.From) != 7*24*time.Hour {
+		t.Errorf("defaults = %+v, %v", req, err)
+	}
+
+	for name, args := range map[string][6]string{
+		"bad cantor":      {"EUR", "x", "", "", "", ""},
+		"reversed range":  {"EUR", "", "2026-03-09", "2026-03-01", "", ""},
+		"range too long":  {"EUR", "", "2024-01-01", "", "1d", ""},
+		"raw too old":     {"EUR", "", "2026-01-01", "2026-01-02", "", ""},
+		"too many points": {"EUR", "", "2025-03-20", "", "5m", ""},
+		"bad interval":    {"EUR", "", "", "", "2h", ""},
+		"bad format":      {"EUR", "", "", "", "", "xlsx"},
+	} {
+		if _, err := NewExportRequest(args[0], args[1], args[2], args[3], args[4], "", args[5], now); err == nil {
+			t.Errorf("%s accepted", name)
+		}
+	}
+}
+
+// TestExportSourceByAge checks that a short range far in the past is read from the rollup that still
+// holds it rather than from raw rates, which are gone after the raw retention.
+func TestExportSourceByAge(t *testing.T) {
+	now := time.Now()
+	from, to := now.AddDate(0, 0, -90).Format(time.DateOnly), now.AddDate(0, 0, -89).Format(time.DateOnly)
+	for interval, want := range map[string]string{"5m": SourceHourly, "1h": SourceHourly, "1d": SourceDaily} {
+		req, err := NewExportRequest("EUR", "", from, to, interval, "", "", now)
+		if err != nil {
+			t.Fatalf("%s: %v", interval, err)
+		}
+		if got := HistorySource(exportHistoryParams(req)); got != want {
+			t.Errorf("%s export 90 days ago: source %s, want %s", interval, got, want)
+		}
+	}
+
+	from, to = now.AddDate(-2, 0, 0).Format(time.DateOnly), now.AddDate(-2, 0, 1).Format(time.DateOnly)
+	req, err := NewExportRequest("EUR", "", from, to, "1h", "", "", now)
+	if got := HistorySource(exportHistoryParams(req)); err != nil || got != SourceDaily {
+		t.Errorf("1h export 2 years ago: source %s, %v, want %s", got, err, SourceDaily)
+	}
+
+	if _, err := NewExportRequest("EUR", "", now.AddDate(0, 0, -40).Format(time.DateOnly), "", "", "", "", now); err == nil {
+		t.Errorf("raw export beyond the raw retention accepted")
+	}
+	if _, err := NewExportRequest("EUR", "", now.AddDate(0, 0, -20).Format(time.DateOnly), "", "", "", "", now); err != nil {
+		t.Errorf("raw export within the raw retention: %v", err)
+	}
+}
+
+func TestBuildHistoryQueryPerCantor(t *testing.T) {
+	now := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	req, _ := NewExportRequest("EUR", "3,5", "2026-03-01", "2026-03-10", "1h", "", "", now)
+	query, args := BuildHistoryQuery("EUR", exportHistoryParams(req))
+	if !strings.Contains(query, "AND cantor_id = ANY($3)") || !strings.Contains(query, "$2::TIMESTAMPTZ, $4::TIMESTAMPTZ)") {
+		t.Errorf("missing cantor set or end of range:\n%s", query)
+	}
+	if !strings.Contains(query, "GROUP BY f.bucket, f.cantor_id") || len(args) != 4 {
+		t.Errorf("export should group by cantor, args %v:\n%s", args, query)
+	}
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	pb "github.com/Niutaq/Gix/api/proto/v1"
+	"github.com/Niutaq/Gix/internal/infrastructure"
+	"github.com/Niutaq/Gix/pkg/finops"
+)
+
+// FinOpsStatus is the scraping cost summary served by GET /finops and GetFinOpsStatus. Amounts are USD
+// formatted with eight fraction digits.
+type FinOpsStatus struct {
+	TotalScrapes     int                      `json:"total_scrapes"`
+	AvgDuration      string                   `json:"avg_duration_sec"`
+	ExpensiveTasks   []string                 `json:"expensive_tasks"`
+	RealSpend24hUSD  string                   `json:"real_spend_24h_usd"`
+	Infrastructure   string                   `json:"infrastructure"`
+	Tips             []finops.OptimizationTip `json:"tips"`
+	BlockedProviders []string                 `json:"blocked_providers,omitempty"`
+	GovernanceActive bool                     `json:"is_governance_active"`
+	SystemTime       string                   `json:"system_time"`
+	// ServiceBreakdown is the spend per FOCUS service category; nil when it could not be queried.
+	ServiceBreakdown map[string]string `json:"service_breakdown,omitempty"`
+}
+
+// GetFinOpsStatus combines the in-process scrape statistics with the spend recorded over the last 24
+// hours. Database errors are logged and leave the spend empty, so a summary is always returned.
+func GetFinOpsStatus(ctx context.Context, app *infrastructure.AppState) FinOpsStatus {
+	stats := finops.Stats.Snapshot()
+	status := FinOpsStatus{
+		TotalScrapes:   stats.TotalScrapes,
+		AvgDuration:    fmt.Sprintf("%.2fs", stats.AvgDuration.Seconds()),
+		ExpensiveTasks: stats.ExpensiveTasks,
+		Infrastructure: finops.DefaultRates.Summary(1, 1),
+		Tips:           finops.GenerateTips(1, 1),
+		SystemTime:     time.Now().Format(time.RFC3339),
+	}
+	if app.Governance != nil {
+		status.BlockedProviders = app.Governance.GetBlockedList()
+		status.GovernanceActive = true
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	var totalSpend float64
+	err := app.DB.QueryRow(ctx, "SELECT COALESCE(SUM(estimated_cost_usd), 0) FROM provider_unit_costs WHERE time > NOW() - INTERVAL '1 day'").Scan(&totalSpend)
+	if err != nil {
+		log.Printf("FinOps DB Query Error: %v", err)
+	}
+	status.RealSpend24hUSD = fmt.Sprintf("%.8f", totalSpend)
+
+	rows, err := app.DB.Query(ctx, "SELECT service_category, COALESCE(SUM(estimated_cost_usd), 0) FROM provider_unit_costs WHERE time > NOW() - INTERVAL '1 day' GROUP BY service_category")
+	if err != nil {
+		log.Printf("FinOps DB Query Error: %v", err)
+		return status
+	}
+	defer rows.Close()
+	status.ServiceBreakdown = make(map[string]string)
+	for rows.Next() {
+		var cat string
+		var cost float64
+		if err := rows.Scan(&cat, &cost); err != nil {
+			continue
+		}
+		if cat == "" {
+			cat = "Other"
+		}
+		status.ServiceBreakdown[cat] = fmt.Sprintf("%.8f", cost)
+	}
+	return status
+}
+
+// FinOpsToV1 converts the summary to its RPC form.
+func FinOpsToV1(s FinOpsStatus) *pb.FinOpsStatus {
+	out := &pb.FinOpsStatus{
+		TotalScrapes:       int64(s.TotalScrapes),
+		AvgDuration:        s.AvgDuration,
+		ExpensiveTasks:     s.ExpensiveTasks,
+		RealSpend_24HUsd:   s.RealSpend24hUSD,
+		Infrastructure:     s.Infrastructure,
+		BlockedProviders:   s.BlockedProviders,
+		IsGovernanceActive: s.GovernanceActive,
+		SystemTime:         s.SystemTime,
+		ServiceBreakdown:   s.ServiceBreakdown,
+	}
+	for _, tip := range s.Tips {
+		out.Tips = append(out.Tips, &pb.FinOpsTip{Title: tip.Title, Description: tip.Description, Potential: tip.Potential})
+	}
+	return out
+}
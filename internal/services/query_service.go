@@ -0,0 +1,436 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	pb "github.com/Niutaq/Gix/api/proto/v1"
+	pbv2 "github.com/Niutaq/Gix/api/proto/v2"
+	"github.com/Niutaq/Gix/internal/infrastructure"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// latestRatesQuery returns the current value of every cantor for a currency. 'rates' only holds
+// change points, so the latest row is the current value and the last row at or before the 24h mark
+// is the value carried forward to that moment. Heartbeats supply the observation time and scraper
+// metadata and cover values older than the retention window.
+const latestRatesQuery = `
+	WITH latest AS (
+		SELECT DISTINCT ON (cantor_id) cantor_id, buy_rate, sell_rate, time
+		FROM rates WHERE currency = $1 ORDER BY cantor_id, time DESC
+	),
+	past AS (
+		SELECT DISTINCT ON (cantor_id) cantor_id, buy_rate
+		FROM rates
+		WHERE currency = $1 AND time <= NOW() - INTERVAL '24 hours'
+		ORDER BY cantor_id, time DESC
+	),
+	current AS (
+		SELECT COALESCE(h.cantor_id, l.cantor_id) AS cantor_id,
+			   COALESCE(h.buy_rate, l.buy_rate) AS buy_rate,
+			   COALESCE(h.sell_rate, l.sell_rate) AS sell_rate,
+			   COALESCE(h.observed_at, l.time) AS observed_at,
+			   CASE WHEN h.changed_at <= NOW() - INTERVAL '24 hours' THEN h.buy_rate END AS unchanged_buy,
+			   h.scraper_type, h.confidence
+		FROM latest l
+		FULL JOIN (SELECT * FROM rate_heartbeats WHERE currency = $1) h ON h.cantor_id = l.cantor_id
+	)
+	SELECT c.cantor_id, c.buy_rate, c.sell_rate, c.observed_at, COALESCE(p.buy_rate, c.unchanged_buy, 0),
+		   COALESCE(k.units, 1), COALESCE(c.scraper_type, ''), COALESCE(c.confidence, 0),
+		   COALESCE(k.display_name, ''), COALESCE(k.latitude, 0), COALESCE(k.longitude, 0)
+	FROM current c
+	LEFT JOIN past p ON c.cantor_id = p.cantor_id
+	JOIN cantors k ON c.cantor_id = k.id AND k.enabled`
+
+// FetchLatestRates returns the latest known rate of every cantor quoting the given currency.
+func FetchLatestRates(ctx context.Context, db *pgxpool.Pool, currency string) ([]infrastructure.LatestRate, error) {
+	rows, err := db.Query(ctx, latestRatesQuery, currency)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []infrastructure.LatestRate
+	for rows.Next() {
+		r := infrastructure.LatestRate{Currency: currency}
+		if err := rows.Scan(&r.CantorID, &r.Buy, &r.Sell, &r.ObservedAt, &r.PastBuy,
+			&r.Units, &r.ScraperType, &r.Confidence, &r.DisplayName, &r.Latitude, &r.Longitude); err != nil {
+			log.Printf("Latest Rates Scan Error: %v", err)
+			continue
+		}
+		results = append(results, r)
+	}
+	return results, rows.Err()
+}
+
+// History aggregations. Each bucket is reduced per cantor first and then averaged across cantors.
+const (
+	AggAvg  = "avg"
+	AggOHLC = "ohlc"
+	AggMin  = "min"
+	AggMax  = "max"
+	AggLast = "last"
+)
+
+// historyIntervals maps the accepted bucket widths to their length.
+var historyIntervals = map[string]time.Duration{
+	"5m":  5 * time.Minute,
+	"15m": 15 * time.Minute,
+	"1h":  time.Hour,
+	"6h":  6 * time.Hour,
+	"1d":  24 * time.Hour,
+}
+
+// History limits. maxHistoryPoints caps the number of buckets a single request may produce;
+// MaxHistoryDays matches the hourly rollup retention, beyond which only daily buckets are left.
+const (
+	maxHistoryPoints = 2000
+	MaxHistoryDays   = hourlyRetentionDays
+)
+
+// AutoHistoryInterval picks a bucket width that keeps a range of `days` at a few hundred points.
+func AutoHistoryInterval(days int) string {
+	switch {
+	case days <= 1:
+		return "5m"
+	case days <= 3:
+		return "15m"
+	case days <= 14:
+		return "1h"
+	case days <= 60:
+		return "6h"
+	default:
+		return "1d"
+	}
+}
+
+// SetHistoryResolution validates the range, the requested interval and aggregation and stores them
+// in params. An empty interval is chosen from the range, an empty aggregation defaults to avg.
+func SetHistoryResolution(params *infrastructure.HistoryParams, interval, agg string) error {
+	if params.Days < 1 || params.Days > MaxHistoryDays {
+		return fmt.Errorf("days must be between 1 and %d", MaxHistoryDays)
+	}
+	if interval == "" {
+		interval = AutoHistoryInterval(params.Days)
+	}
+	width, ok := historyIntervals[interval]
+	if !ok {
+		return fmt.Errorf("unsupported interval %q (use 5m, 15m, 1h, 6h or 1d)", interval)
+	}
+	if points := int(time.Duration(params.Days) * 24 * time.Hour / width); points > maxHistoryPoints {
+		return fmt.Errorf("interval %s over %d days gives %d points, the limit is %d", interval, params.Days, points, maxHistoryPoints)
+	}
+
+	agg, err := parseHistoryAgg(agg)
+	if err != nil {
+		return err
+	}
+
+	params.Interval = interval
+	params.Agg = agg
+	return nil
+}
+
+// parseHistoryAgg validates an aggregation, defaulting to avg.
+func parseHistoryAgg(agg string) (string, error) {
+	switch agg {
+	case "":
+		return AggAvg, nil
+	case AggAvg, AggOHLC, AggMin, AggMax, AggLast:
+		return agg, nil
+	}
+	return "", fmt.Errorf("unsupported agg %q (use avg, ohlc, min, max or last)", agg)
+}
+
+// History sources. Raw change points are kept for rawRetentionDays; the continuous aggregates
+// rates_hourly and rates_daily outlive them.
+const (
+	SourceRaw    = "rates"
+	SourceHourly = "rates_hourly"
+	SourceDaily  = "rates_daily"
+)
+
+// Retention of the history sources in days, matching the policies set up by the schema migration.
+// rates_daily is kept indefinitely.
+const (
+	rawRetentionDays    = 30
+	hourlyRetentionDays = 400
+)
+
+// rollupThresholdDays is the oldest cutoff still read from raw change points at hourly or coarser
+// intervals. It stays well inside rawRetentionDays so a range never reaches into dropped chunks.
+const rollupThresholdDays = 14
+
+// HistorySource picks the table a history request is read from by the age of its cutoff, so ranges
+// given by cutoff and until are routed like ranges given in days: the daily rollup for daily buckets
+// or cutoffs beyond the hourly retention, the hourly rollup for hourly or coarser buckets reaching
+// past rollupThresholdDays and for any cutoff beyond the raw retention, and raw change points otherwise.
+func HistorySource(params infrastructure.HistoryParams) string {
+	width, ok := historyIntervals[params.Interval]
+	age := time.Since(params.Cutoff)
+	switch {
+	case !ok:
+		return SourceRaw
+	case width >= 24*time.Hour || age > hourlyRetentionDays*24*time.Hour:
+		return SourceDaily
+	case width >= time.Hour && age > rollupThresholdDays*24*time.Hour, age > rawRetentionDays*24*time.Hour:
+		return SourceHourly
+	default:
+		return SourceRaw
+	}
+}
+
+// BuildHistoryQuery builds a history query over change-point storage or its rollups. Every cantor's
+// series is seeded with the value valid at the cutoff, gap-filled with last-observation-carried-forward
+// and cut off at its last heartbeat, so buckets without a stored change still carry the observed rate.
+// Each row holds the average and the open/high/low/close of buy and sell; a bucket opens at the
+// previous close, since the value carried into it is part of its range. Per-cantor queries also
+// return the cantor ID after the bucket time.
+func BuildHistoryQuery(currency string, params infrastructure.HistoryParams) (string, []interface{}) {
+	args := []interface{}{currency, params.Cutoff}
+	cantorFilter := ""
+	switch {
+	case params.CantorID > 0:
+		args = append(args, params.CantorID)
+		cantorFilter = fmt.Sprintf("AND cantor_id = $%d", len(args))
+	case len(params.CantorIDs) > 0:
+		args = append(args, params.CantorIDs)
+		cantorFilter = fmt.Sprintf("AND cantor_id = ANY($%d)", len(args))
+	}
+	until := "NOW()"
+	if !params.Until.IsZero() {
+		args = append(args, params.Until)
+		until = fmt.Sprintf("$%d::TIMESTAMPTZ", len(args))
+	}
+	width, ok := historyIntervals[params.Interval]
+	if !ok {
+		width = time.Hour
+	}
+	selectCantor, groupBy := "", "f.bucket"
+	if params.PerCantor {
+		selectCantor, groupBy = "f.cantor_id,\n\t\t\t\t   ", "f.bucket, f.cantor_id"
+	}
+
+	// Raw rows are single observations, so every candle column of a point is the observed rate.
+	source := HistorySource(params)
+	timeCol, buyClose, sellClose := "time", "buy_rate", "sell_rate"
+	columns := `buy_rate, buy_rate, buy_rate, buy_rate, buy_rate,
+					   sell_rate, sell_rate, sell_rate, sell_rate, sell_rate`
+	if source != SourceRaw {
+		timeCol, buyClose, sellClose = "bucket", "buy_close", "sell_close"
+		columns = `buy_avg, buy_open, buy_high, buy_low, buy_close,
+					   sell_avg, sell_open, sell_high, sell_low, sell_close`
+	}
+
+	query := fmt.Sprintf(`
+			WITH seed AS (
+				SELECT DISTINCT ON (cantor_id) cantor_id, buy_rate, sell_rate
+				FROM (
+					SELECT cantor_id, %[4]s AS buy_rate, %[5]s AS sell_rate, %[3]s AS time FROM %[2]s
+					WHERE currency = $1 AND %[3]s <= $2 %[1]s
+					UNION ALL
+					SELECT cantor_id, buy_rate, sell_rate, changed_at FROM rate_heartbeats
+					WHERE currency = $1 AND changed_at <= $2 %[1]s
+				) s
+				ORDER BY cantor_id, time DESC
+			),
+			points (time, cantor_id, buy_avg, buy_open, buy_high, buy_low, buy_close,
+					sell_avg, sell_open, sell_high, sell_low, sell_close) AS (
+				SELECT $2::TIMESTAMPTZ, cantor_id, buy_rate, buy_rate, buy_rate, buy_rate, buy_rate,
+					   sell_rate, sell_rate, sell_rate, sell_rate, sell_rate
+				FROM seed
+				UNION ALL
+				SELECT %[3]s, cantor_id, %[6]s
+				FROM %[2]s
+				WHERE currency = $1 AND %[3]s > $2 AND %[3]s <= %[8]s %[1]s
+			),
+			filled AS (
+				SELECT time_bucket_gapfill('%[7]d seconds', time, $2::TIMESTAMPTZ, %[8]s) AS bucket,
+					   cantor_id,
+					   locf(AVG(buy_avg)) AS buy,
+					   locf(AVG(sell_avg)) AS sell,
+					   first(buy_open, time) AS buy_first,
+					   first(sell_open, time) AS sell_first,
+					   MAX(buy_high) AS buy_max,
+					   MIN(buy_low) AS buy_min,
+					   MAX(sell_high) AS sell_max,
+					   MIN(sell_low) AS sell_min,
+					   locf(last(buy_close, time)) AS buy_close,
+					   locf(last(sell_close, time)) AS sell_close
+				FROM points
+				WHERE time >= $2 AND time <= %[8]s
+				GROUP BY bucket, cantor_id
+			),
+			candles AS (
+				SELECT bucket, cantor_id, buy, sell, buy_close, sell_close,
+					   COALESCE(LAG(buy_close) OVER w, buy_first) AS buy_open,
+					   COALESCE(LAG(sell_close) OVER w, sell_first) AS sell_open,
+					   buy_max, buy_min, sell_max, sell_min
+				FROM filled
+				WINDOW w AS (PARTITION BY cantor_id ORDER BY bucket)
+			)
+			SELECT f.bucket,
+				   %[9]sROUND(AVG(f.buy), 8),
+				   ROUND(AVG(f.sell), 8),
+				   ROUND(AVG(f.buy_open), 8),
+				   ROUND(AVG(GREATEST(f.buy_open, f.buy_max, f.buy_close)), 8),
+				   ROUND(AVG(LEAST(f.buy_open, f.buy_min, f.buy_close)), 8),
+				   ROUND(AVG(f.buy_close), 8),
+				   ROUND(AVG(f.sell_open), 8),
+				   ROUND(AVG(GREATEST(f.sell_open, f.sell_max, f.sell_close)), 8),
+				   ROUND(AVG(LEAST(f.sell_open, f.sell_min, f.sell_close)), 8),
+				   ROUND(AVG(f.sell_close), 8)
+			FROM candles f
+			LEFT JOIN rate_heartbeats h ON h.cantor_id = f.cantor_id AND h.currency = $1
+			WHERE f.buy IS NOT NULL AND (h.observed_at IS NULL OR f.bucket <= h.observed_at)
+			GROUP BY %[10]s
+			ORDER BY %[10]s`, cantorFilter, source, timeCol, buyClose, sellClose, columns, int(width.Seconds()),
+		until, selectCantor, groupBy)
+	return query, args
+}
+
+// FetchHistory runs the history query and returns its buckets in chronological order, with Buy and
+// Sell reduced according to params.Agg.
+func FetchHistory(ctx context.Context, db *pgxpool.Pool, currency string, params infrastructure.HistoryParams) ([]infrastructure.HistoryBucket, error) {
+	var buckets []infrastructure.HistoryBucket
+	err := StreamHistory(ctx, db, currency, params, func(b infrastructure.HistoryBucket) error {
+		buckets = append(buckets, b)
+		return nil
+	})
+	return buckets, err
+}
+
+// StreamHistory runs the history query and passes each bucket to fn as it is read, so large ranges
+// are not held in memory. An error from fn stops the query and is returned.
+func StreamHistory(ctx context.Context, db *pgxpool.Pool, currency string, params infrastructure.HistoryParams, fn func(infrastructure.HistoryBucket) error) error {
+	query, args := BuildHistoryQuery(currency, params)
+	rows, err := db.Query(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var b infrastructure.HistoryBucket
+		dest := []interface{}{&b.Time, &b.Buy, &b.Sell,
+			&b.BuyOHLC.Open, &b.BuyOHLC.High, &b.BuyOHLC.Low, &b.BuyOHLC.Close,
+			&b.SellOHLC.Open, &b.SellOHLC.High, &b.SellOHLC.Low, &b.SellOHLC.Close}
+		if params.PerCantor {
+			dest = slices.Insert(dest, 1, interface{}(&b.CantorID))
+		}
+		if err := rows.Scan(dest...); err != nil {
+			log.Printf("History Scan Error: %v", err)
+			continue
+		}
+		ApplyHistoryAgg(&b, params.Agg)
+		if err := fn(b); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// ApplyHistoryAgg sets a bucket's Buy and Sell to the value selected by the aggregation. Avg keeps
+// the averages; ohlc reports the close, with the full candle in BuyOHLC and SellOHLC.
+func ApplyHistoryAgg(b *infrastructure.HistoryBucket, agg string) {
+	switch agg {
+	case AggMin:
+		b.Buy, b.Sell = b.BuyOHLC.Low, b.SellOHLC.Low
+	case AggMax:
+		b.Buy, b.Sell = b.BuyOHLC.High, b.SellOHLC.High
+	case AggLast, AggOHLC:
+		b.Buy, b.Sell = b.BuyOHLC.Close, b.SellOHLC.Close
+	}
+}
+
+// NewHistoryParams builds history parameters for the last `days` days (a week when 0), with the
+// interval chosen from the range and averaged buckets. SetHistoryResolution checks the range.
+func NewHistoryParams(cantorID, days int) infrastructure.HistoryParams {
+	if days == 0 {
+		days = 7
+	}
+	return infrastructure.HistoryParams{
+		CantorID: cantorID,
+		Days:     days,
+		Cutoff:   time.Now().AddDate(0, 0, -days),
+		Interval: AutoHistoryInterval(days),
+		Agg:      AggAvg,
+	}
+}
+
+// legacyHistoryScale is the number of decimal places of the int64 buyRate/sellRate v1 HistoryPoint fields.
+const legacyHistoryScale = 3
+
+// LatestRateToV1 converts a stored latest rate into the v1 wire representation.
+func LatestRateToV1(r infrastructure.LatestRate) *pb.RateResponse {
+	return &pb.RateResponse{
+		BuyRate:   r.Buy.String(),
+		SellRate:  r.Sell.String(),
+		CantorId:  int32(r.CantorID),
+		Currency:  r.Currency,
+		FetchedAt: r.ObservedAt.Unix(),
+		Change24H: r.Buy.ChangeBasisPoints(r.PastBuy),
+		Buy:       r.Buy.Proto(),
+		Sell:      r.Sell.Proto(),
+	}
+}
+
+// LatestRateToV2 converts a stored latest rate into the v2 wire representation.
+func LatestRateToV2(r infrastructure.LatestRate) *pbv2.Rate {
+	rate := pbv2.NewRate(int32(r.CantorID), r.Currency, r.Buy, r.Sell, r.Buy.ChangeBasisPoints(r.PastBuy))
+	rate.Units = int32(r.Units)
+	rate.ScraperType = r.ScraperType
+	rate.Confidence = r.Confidence
+	rate.ObservedAt = r.ObservedAt.Unix()
+	return rate
+}
+
+// HistoryToV1 converts history buckets into the v1 response, filling the legacy milli-unit fields.
+func HistoryToV1(currency string, params infrastructure.HistoryParams, buckets []infrastructure.HistoryBucket) *pb.HistoryResponse {
+	points := make([]*pb.HistoryPoint, 0, len(buckets))
+	for _, b := range buckets {
+		point := &pb.HistoryPoint{
+			Time:     b.Time.Unix(),
+			BuyRate:  b.Buy.Scaled(legacyHistoryScale),
+			SellRate: b.Sell.Scaled(legacyHistoryScale),
+			Buy:      b.Buy.Proto(),
+			Sell:     b.Sell.Proto(),
+		}
+		if params.Agg == AggOHLC {
+			point.BuyCandle = candleToV1(b.BuyOHLC)
+			point.SellCandle = candleToV1(b.SellOHLC)
+		}
+		points = append(points, point)
+	}
+	return &pb.HistoryResponse{Points: points, Currency: currency, Interval: params.Interval, Agg: params.Agg}
+}
+
+func candleToV1(c infrastructure.Candle) *pb.Candle {
+	return &pb.Candle{Open: c.Open.Proto(), High: c.High.Proto(), Low: c.Low.Proto(), Close: c.Close.Proto()}
+}
+
+// HistoryToV2 converts history buckets into the v2 response.
+func HistoryToV2(currency string, params infrastructure.HistoryParams, buckets []infrastructure.HistoryBucket) *pbv2.HistoryResponse {
+	points := make([]*pbv2.HistoryPoint, 0, len(buckets))
+	for _, b := range buckets {
+		point := &pbv2.HistoryPoint{
+			Time: b.Time.Unix(),
+			Buy:  pbv2.NewDecimal(b.Buy),
+			Sell: pbv2.NewDecimal(b.Sell),
+		}
+		if params.Agg == AggOHLC {
+			point.BuyOhlc = candleToV2(b.BuyOHLC)
+			point.SellOhlc = candleToV2(b.SellOHLC)
+		}
+		points = append(points, point)
+	}
+	return &pbv2.HistoryResponse{Currency: currency, Points: points, Interval: params.Interval, Agg: params.Agg}
+}
+
+func candleToV2(c infrastructure.Candle) *pbv2.Candle {
+	return &pbv2.Candle{Open: pbv2.NewDecimal(c.Open), High: pbv2.NewDecimal(c.High), Low: pbv2.NewDecimal(c.Low),
+		Close: pbv2.NewDecimal(c.Close)}
+}
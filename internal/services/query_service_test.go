@@ -0,0 +1,121 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Niutaq/Gix/internal/infrastructure"
+	"github.com/Niutaq/Gix/pkg/money"
+)
+
+func TestSetHistoryResolution(t *testing.T) {
+	for days, want := range map[int]string{1: "5m", 3: "15m", 7: "1h", 30: "6h", 365: "1d"} {
+		params := NewHistoryParams(0, days)
+		if err := SetHistoryResolution(&params, "", ""); err != nil || params.Interval != want || params.Agg != AggAvg {
+			t.Errorf("%d days: interval %q agg %q err %v, want %s avg", days, params.Interval, params.Agg, err, want)
+		}
+	}
+
+	params := NewHistoryParams(0, 30)
+	if err := SetHistoryResolution(&params, "5m", AggOHLC); err == nil {
+		t.Errorf("5m over 30 days (8640 points) accepted")
+	}
+	if err := SetHistoryResolution(&params, "2h", ""); err == nil {
+		t.Errorf("unsupported interval accepted")
+	}
+	if err := SetHistoryResolution(&params, "1h", "median"); err == nil {
+		t.Errorf("unsupported agg accepted")
+	}
+	if err := SetHistoryResolution(&params, "1h", AggOHLC); err != nil || params.Interval != "1h" || params.Agg != AggOHLC {
+		t.Errorf("1h ohlc: %+v, %v", params, err)
+	}
+
+	// Out-of-range days are rejected before the range is turned into a duration, which would
+	// overflow and slip past the point limit.
+	for _, days := range []int{-1, MaxHistoryDays + 1, 1 << 40} {
+		params := NewHistoryParams(0, days)
+		if err := SetHistoryResolution(&params, "1d", ""); err == nil {
+			t.Errorf("%d days accepted", days)
+		}
+	}
+	if params := NewHistoryParams(0, MaxHistoryDays); SetHistoryResolution(&params, "1d", "") != nil {
+		t.Errorf("%d days rejected", MaxHistoryDays)
+	}
+}
+
+func TestBuildHistoryQueryInterval(t *testing.T) {
+	params := NewHistoryParams(0, 1)
+	query, _ := BuildHistoryQuery("EUR", params)
+	if !strings.Contains(query, "time_bucket_gapfill('300 seconds'") {
+		t.Errorf("1-day history should use 5-minute buckets:\n%s", query)
+	}
+}
+
+func TestHistorySource(t *testing.T) {
+	cases := []struct {
+		days     int
+		interval string
+		want     string
+	}{
+		{1, "5m", SourceRaw},
+		{7, "1h", SourceRaw},
+		{20, "15m", SourceRaw},
+		{30, "1h", SourceHourly},
+		{90, "6h", SourceHourly},
+		{7, "1d", SourceDaily},
+		{365, "", SourceDaily},
+		{15, "1h", SourceHourly},
+	}
+	for _, tc := range cases {
+		params := NewHistoryParams(0, tc.days)
+		if err := SetHistoryResolution(&params, tc.interval, ""); err != nil {
+			t.Fatalf("%d days at %q: %v", tc.days, tc.interval, err)
+		}
+		if got := HistorySource(params); got != tc.want {
+			t.Errorf("%d days at %s: source %s, want %s", tc.days, params.Interval, got, tc.want)
+		}
+	}
+
+	// A short range is routed by the age of its cutoff, not by its length.
+	old := infrastructure.HistoryParams{Cutoff: time.Now().AddDate(0, 0, -60), Until: time.Now().AddDate(0, 0, -59), Days: 1, Interval: "1h"}
+	if got := HistorySource(old); got != SourceHourly {
+		t.Errorf("one hourly day, 60 days ago: source %s, want %s", got, SourceHourly)
+	}
+	old.Cutoff, old.Until = time.Now().AddDate(-2, 0, 0), time.Now().AddDate(-2, 0, 1)
+	if got := HistorySource(old); got != SourceDaily {
+		t.Errorf("one hourly day, 2 years ago: source %s, want %s", got, SourceDaily)
+	}
+
+	params := NewHistoryParams(0, 365)
+	query, _ := BuildHistoryQuery("EUR", params)
+	if !strings.Contains(query, "FROM rates_daily") || strings.Contains(query, "FROM rates\n") {
+		t.Errorf("1-year history should read the daily rollup:\n%s", query)
+	}
+}
+
+func TestApplyHistoryAgg(t *testing.T) {
+	bucket := func() infrastructure.HistoryBucket {
+		return infrastructure.HistoryBucket{
+			Buy:     money.MustParse("4.25"),
+			BuyOHLC: infrastructure.Candle{Open: money.MustParse("4.20"), High: money.MustParse("4.30"), Low: money.MustParse("4.18"), Close: money.MustParse("4.28")},
+		}
+	}
+	for agg, want := range map[string]string{AggAvg: "4.2500", AggMin: "4.1800", AggMax: "4.3000", AggLast: "4.2800", AggOHLC: "4.2800"} {
+		b := bucket()
+		ApplyHistoryAgg(&b, agg)
+		if b.Buy.String() != want {
+			t.Errorf("agg %s: buy = %s, want %s", agg, b.Buy, want)
+		}
+	}
+
+	params := NewHistoryParams(0, 7)
+	params.Agg = AggOHLC
+	resp := HistoryToV1("EUR", params, []infrastructure.HistoryBucket{bucket()})
+	if high, _ := money.FromProto(resp.Points[0].BuyCandle.GetHigh()); high.String() != "4.3000" {
+		t.Errorf("ohlc point lacks the buy candle: %v", resp.Points[0])
+	}
+	if resp.Interval != "1h" || resp.Agg != AggOHLC {
+		t.Errorf("response interval %q agg %q", resp.Interval, resp.Agg)
+	}
+}
@@ -0,0 +1,181 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	pb "github.com/Niutaq/Gix/api/proto/v1"
+	"github.com/Niutaq/Gix/internal/infrastructure"
+	"github.com/Niutaq/Gix/pkg/geo"
+	"github.com/Niutaq/Gix/pkg/money"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Quote sides, from the user's point of view.
+const (
+	SideSell = "sell"
+	SideBuy  = "buy"
+)
+
+// rateFreshness is how old an observation may be before it counts as stale: quotes built on it rank
+// behind fresh ones and snapshots flag it. The harvester refreshes every 15 minutes, so an hour covers
+// a few missed cycles.
+const rateFreshness = time.Hour
+
+// Upper bounds of a quote request. They keep amount × rate and penalty × distance well inside the
+// range of money.Rate (about 92 billion) for any realistic rate.
+var (
+	maxQuoteAmount  = money.MustParse("10000000")
+	maxQuotePenalty = money.MustParse("1000")
+)
+
+// ValidateQuoteRequest checks a quote request and fills in defaults.
+func ValidateQuoteRequest(req *infrastructure.QuoteRequest) error {
+	if req.Currency == "" {
+		return fmt.Errorf("currency is required")
+	}
+	if req.Amount.Sign() <= 0 {
+		return fmt.Errorf("amount must be positive")
+	}
+	if req.Amount.Cmp(maxQuoteAmount) > 0 {
+		return fmt.Errorf("amount must not exceed %s", maxQuoteAmount.StringFixed(0))
+	}
+	if req.Side == "" {
+		req.Side = SideSell
+	}
+	if req.Side != SideSell && req.Side != SideBuy {
+		return fmt.Errorf("side must be %q or %q", SideSell, SideBuy)
+	}
+	if req.HasLocation && !geo.ValidCoordinates(req.Lat, req.Lon) {
+		return fmt.Errorf("coordinates out of range")
+	}
+	if req.RadiusKm < 0 || req.DistancePenalty.Sign() < 0 {
+		return fmt.Errorf("radius and distance penalty must not be negative")
+	}
+	if req.DistancePenalty.Cmp(maxQuotePenalty) > 0 {
+		return fmt.Errorf("distance penalty must not exceed %s PLN/km", maxQuotePenalty.StringFixed(0))
+	}
+	return nil
+}
+
+// GetQuotes ranks every cantor quoting the requested currency by the PLN amount of the exchange.
+// req must have passed ValidateQuoteRequest.
+func GetQuotes(ctx context.Context, db *pgxpool.Pool, req infrastructure.QuoteRequest) ([]infrastructure.Quote, error) {
+	latest, err := FetchLatestRates(ctx, db, req.Currency)
+	if err != nil {
+		return nil, err
+	}
+	return RankQuotes(latest, req, time.Now()), nil
+}
+
+// RankQuotes turns latest rates into quotes for req, best first. Selling uses the cantor's buy rate and
+// ranks by the highest PLN received; buying uses the sell rate and ranks by the lowest PLN paid. The
+// distance penalty is applied to the score only, and stale observations always rank behind fresh ones.
+func RankQuotes(latest []infrastructure.LatestRate, req infrastructure.QuoteRequest, now time.Time) []infrastructure.Quote {
+	quotes := make([]infrastructure.Quote, 0, len(latest))
+	for _, r := range latest {
+		rate := r.Buy
+		if req.Side == SideBuy {
+			rate = r.Sell
+		}
+		if rate.Sign() <= 0 {
+			continue
+		}
+
+		distance := -1.0
+		hasCoordinates := r.Latitude != 0 || r.Longitude != 0
+		if req.HasLocation && hasCoordinates {
+			distance = geo.DistanceKm(req.Lat, req.Lon, r.Latitude, r.Longitude)
+		}
+		if req.HasLocation && req.RadiusKm > 0 && (distance < 0 || distance > req.RadiusKm) {
+			continue
+		}
+
+		pln := rate.Mul(req.Amount)
+		score := pln
+		if distance > 0 && req.DistancePenalty.Sign() > 0 {
+			penalty := req.DistancePenalty.Mul(money.FromFloat(distance))
+			if req.Side == SideBuy {
+				score = score.Add(penalty)
+			} else {
+				score = score.Sub(penalty)
+			}
+		}
+
+		quotes = append(quotes, infrastructure.Quote{
+			CantorID:    r.CantorID,
+			DisplayName: r.DisplayName,
+			Rate:        rate,
+			PLNAmount:   pln,
+			Score:       score,
+			DistanceKm:  distance,
+			ObservedAt:  r.ObservedAt,
+			Stale:       now.Sub(r.ObservedAt) > rateFreshness,
+			Units:       r.Units,
+		})
+	}
+
+	sort.SliceStable(quotes, func(i, j int) bool {
+		a, b := quotes[i], quotes[j]
+		if a.Stale != b.Stale {
+			return !a.Stale
+		}
+		if cmp := a.Score.Cmp(b.Score); cmp != 0 {
+			if req.Side == SideBuy {
+				return cmp < 0
+			}
+			return cmp > 0
+		}
+		return a.CantorID < b.CantorID
+	})
+	return quotes
+}
+
+// QuoteRequestFromProto converts a wire quote request. A location is considered given when either coordinate is set.
+// Decimals outside the range of money.Rate are rejected.
+func QuoteRequestFromProto(in *pb.QuoteRequest) (infrastructure.QuoteRequest, error) {
+	amount, err := money.FromProto(in.Amount)
+	if err != nil {
+		return infrastructure.QuoteRequest{}, fmt.Errorf("invalid amount: %w", err)
+	}
+	penalty, err := money.FromProto(in.DistancePenalty)
+	if err != nil {
+		return infrastructure.QuoteRequest{}, fmt.Errorf("invalid distance penalty: %w", err)
+	}
+	return infrastructure.QuoteRequest{
+		Currency:        in.Currency,
+		Amount:          amount,
+		Side:            in.Side,
+		Lat:             in.Lat,
+		Lon:             in.Lon,
+		HasLocation:     in.Lat != 0 || in.Lon != 0,
+		RadiusKm:        in.RadiusKm,
+		DistancePenalty: penalty,
+	}, nil
+}
+
+// QuotesToProto converts ranked quotes into the wire response.
+func QuotesToProto(req infrastructure.QuoteRequest, quotes []infrastructure.Quote) *pb.QuoteResponse {
+	resp := &pb.QuoteResponse{
+		Currency: req.Currency,
+		Side:     req.Side,
+		Amount:   req.Amount.Proto(),
+		Quotes:   make([]*pb.Quote, 0, len(quotes)),
+	}
+	for _, q := range quotes {
+		resp.Quotes = append(resp.Quotes, &pb.Quote{
+			CantorId:    int32(q.CantorID),
+			DisplayName: q.DisplayName,
+			Rate:        q.Rate.Proto(),
+			PlnAmount:   q.PLNAmount.Proto(),
+			Score:       q.Score.Proto(),
+			DistanceKm:  q.DistanceKm,
+			FetchedAt:   q.ObservedAt.Unix(),
+			Stale:       q.Stale,
+			Units:       int32(q.Units),
+		})
+	}
+	return resp
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	pb "github.com/Niutaq/Gix/api/proto/v1"
+	"github.com/Niutaq/Gix/internal/infrastructure"
+	"github.com/Niutaq/Gix/pkg/money"
+)
+
+func TestRankQuotes(t *testing.T) {
+	now := time.Now()
+	latest := []infrastructure.LatestRate{
+		{CantorID: 1, Buy: money.MustParse("4.20"), Sell: money.MustParse("4.30"), ObservedAt: now, Latitude: 52.23, Longitude: 21.01},
+		{CantorID: 2, Buy: money.MustParse("4.25"), Sell: money.MustParse("4.28"), ObservedAt: now, Latitude: 50.06, Longitude: 19.94},
+		{CantorID: 3, Buy: money.MustParse("4.40"), Sell: money.MustParse("4.45"), ObservedAt: now.Add(-3 * time.Hour)},
+	}
+	sell := infrastructure.QuoteRequest{Currency: "EUR", Amount: money.MustParse("1000"), Side: SideSell}
+
+	got := RankQuotes(latest, sell, now)
+	if len(got) != 3 || got[0].CantorID != 2 || got[1].CantorID != 1 || got[2].CantorID != 3 || !got[2].Stale {
+		t.Fatalf("sell ranking = %+v, want 2, 1, then stale 3", got)
+	}
+	if got[0].PLNAmount.String() != "4250.0000" {
+		t.Errorf("PLN amount = %s, want 4250.0000", got[0].PLNAmount)
+	}
+
+	buy := sell
+	buy.Side = SideBuy
+	if got := RankQuotes(latest, buy, now); got[0].CantorID != 2 || got[1].CantorID != 1 {
+		t.Errorf("buy ranking = %+v, want cheapest (2) first", got)
+	}
+
+	// Standing in Warsaw with a radius excludes Kraków and the cantor without coordinates;
+	// a steep penalty instead flips the order in favour of the nearby cantor.
+	near := sell
+	near.Lat, near.Lon, near.HasLocation, near.RadiusKm = 52.23, 21.0, true, 50
+	if got := RankQuotes(latest, near, now); len(got) != 1 || got[0].CantorID != 1 {
+		t.Errorf("radius ranking = %+v, want only cantor 1", got)
+	}
+	near.RadiusKm, near.DistancePenalty = 0, money.MustParse("1")
+	if got := RankQuotes(latest, near, now); got[0].CantorID != 1 {
+		t.Errorf("penalized ranking = %+v, want cantor 1 first", got)
+	}
+}
+
+func TestQuoteRequestFromProto(t *testing.T) {
+	req, err := QuoteRequestFromProto(&pb.QuoteRequest{Currency: "EUR", Amount: &pb.Decimal{Value: 1000, Scale: 2}})
+	if err != nil || req.Amount.String() != "10.0000" {
+		t.Fatalf("QuoteRequestFromProto = %+v, %v", req, err)
+	}
+	for _, in := range []*pb.QuoteRequest{
+		{Currency: "EUR", Amount: &pb.Decimal{Value: 1000, Scale: 80}},
+		{Currency: "EUR", Amount: &pb.Decimal{Value: 1, Scale: 1}, DistancePenalty: &pb.Decimal{Value: 1 << 62, Scale: 0}},
+	} {
+		if _, err := QuoteRequestFromProto(in); !errors.Is(err, money.ErrOutOfRange) {
+			t.Errorf("QuoteRequestFromProto(%v) error = %v, want ErrOutOfRange", in, err)
+		}
+	}
+}
+
+func TestValidateQuoteRequestBounds(t *testing.T) {
+	ok := infrastructure.QuoteRequest{Currency: "EUR", Amount: money.MustParse("10000000")}
+	if err := ValidateQuoteRequest(&ok); err != nil || ok.Side != SideSell {
+		t.Fatalf("ValidateQuoteRequest(max amount) = %v, side %q", err, ok.Side)
+	}
+	for name, req := range map[string]infrastructure.QuoteRequest{
+		"amount":  {Currency: "EUR", Amount: money.MustParse("10000000.01")},
+		"penalty": {Currency: "EUR", Amount: money.MustParse("1"), DistancePenalty: money.MustParse("1000.5")},
+	} {
+		if err := ValidateQuoteRequest(&req); err == nil {
+			t.Errorf("%s above the limit accepted", name)
+		}
+	}
+}
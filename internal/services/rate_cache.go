@@ -0,0 +1,230 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	pb "github.com/Niutaq/Gix/api/proto/v1"
+	"github.com/Niutaq/Gix/internal/infrastructure"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/sync/singleflight"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	freshRateTTL   = 60 * time.Second // a cached rate younger than this is served as is
+	lastRateTTL    = 24 * time.Hour   // the last known value, served stale while a refresh runs
+	refreshLockTTL = 2 * time.Minute  // covers a heuristic scrape with LLM fallback
+	lockWaitLimit  = 15 * time.Second // how long a request without any cached value waits on another replica
+)
+
+// Errors returned by GetRate.
+var (
+	ErrRateQuarantined = errors.New("scraped rate failed validation and is awaiting review")
+	ErrRateUnavailable = errors.New("rate unavailable")
+)
+
+// rateRefreshes coalesces concurrent refreshes of the same cantor/currency within this process;
+// the Redis lock in refreshRate does the same across replicas.
+var rateRefreshes singleflight.Group
+
+// releaseLockScript deletes the lock only if it is still held by the caller's token.
+var releaseLockScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+// RateCacheKey is the Redis key of the fresh cached RateResponse.
+func RateCacheKey(cantorID int, currency string) string {
+	return fmt.Sprintf("rates:proto%d:%s", cantorID, currency)
+}
+
+func lastRateKey(cantorID int, currency string) string {
+	return fmt.Sprintf("rates:last:%d:%s", cantorID, currency)
+}
+
+func refreshLockKey(cantorID int, currency string) string {
+	return fmt.Sprintf("rates:lock:%d:%s", cantorID, currency)
+}
+
+// GetRate returns the current rate of a cantor. A fresh cached value is returned directly. Otherwise
+// the last known value is returned with Stale set while a background refresh runs, and only when
+// nothing is cached does the caller wait for a (coalesced) scrape. If that scrape fails, the latest
+// archived value is returned as stale instead of an error. pgx.ErrNoRows means the cantor does not exist.
+func GetRate(ctx context.Context, app *infrastructure.AppState, cantorID int, currency string) (*pb.RateResponse, error) {
+	if resp, ok := cachedRate(ctx, app.Cache, RateCacheKey(cantorID, currency)); ok {
+		return resp, nil
+	}
+
+	if last, ok := cachedRate(ctx, app.Cache, lastRateKey(cantorID, currency)); ok {
+		last.Stale = true
+		go func() {
+			_, _, _ = rateRefreshes.Do(RateCacheKey(cantorID, currency), func() (any, error) {
+				return refreshRate(app, cantorID, currency)
+			})
+		}()
+		return last, nil
+	}
+
+	ch := rateRefreshes.DoChan(RateCacheKey(cantorID, currency), func() (any, error) {
+		return refreshRate(app, cantorID, currency)
+	})
+
+	var err error
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-ch:
+		if res.Err == nil {
+			return res.Val.(*pb.RateResponse), nil
+		}
+		err = res.Err
+	}
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+	log.Printf("Rate Refresh Error (cantor %d, %s): %v", cantorID, currency, err)
+
+	archived, archErr := fetchArchivedRate(ctx, app.DB, cantorID, currency)
+	if archErr == nil {
+		return archived, nil
+	}
+	if !errors.Is(archErr, pgx.ErrNoRows) {
+		log.Printf("Archive Fallback Error: %v", archErr)
+	}
+	if errors.Is(err, ErrRateQuarantined) {
+		return nil, err
+	}
+	return nil, fmt.Errorf("%w: %v", ErrRateUnavailable, err)
+}
+
+// refreshRate scrapes, screens and caches one rate while holding the cross-replica lock. If another
+// replica holds the lock it waits for that replica's result instead of scraping again. It runs on a
+// detached context so a disconnecting client does not abort a refresh other callers are waiting on.
+func refreshRate(app *infrastructure.AppState, cantorID int, currency string) (*pb.RateResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), refreshLockTTL)
+	defer cancel()
+
+	lockKey := refreshLockKey(cantorID, currency)
+	token := lockToken()
+	acquired, err := app.Cache.SetNX(ctx, lockKey, token, refreshLockTTL).Result()
+	if err != nil {
+		log.Printf("Rate Lock Error: %v", err)
+		acquired = true // Redis is unavailable, so there is no other replica to coordinate with
+	}
+	if !acquired {
+		return waitForRefresh(ctx, app.Cache, cantorID, currency)
+	}
+	defer func() {
+		if err := releaseLockScript.Run(context.Background(), app.Cache, []string{lockKey}, token).Err(); err != nil {
+			log.Printf("Rate Unlock Error: %v", err)
+		}
+	}()
+
+	// Another replica may have finished a refresh between our cache miss and taking the lock.
+	if resp, ok := cachedRate(ctx, app.Cache, RateCacheKey(cantorID, currency)); ok {
+		return resp, nil
+	}
+
+	cantorInfo, err := FetchCantorInfo(ctx, app.DB, cantorID)
+	if err != nil {
+		return nil, err
+	}
+
+	response, rates, err := ScrapeAndProcess(ctx, app, cantorInfo, cantorID, currency)
+	if err != nil {
+		return nil, fmt.Errorf("processing error (%s): %w", cantorInfo.Strategy, err)
+	}
+
+	if !ScreenRates(ctx, app.DB, cantorID, currency, rates) {
+		return nil, ErrRateQuarantined
+	}
+
+	CacheAndArchive(ctx, app, cantorID, currency, response, rates)
+	return response, nil
+}
+
+// waitForRefresh polls for the value another replica is scraping until its lock is released.
+func waitForRefresh(ctx context.Context, cache redis.UniversalClient, cantorID int, currency string) (*pb.RateResponse, error) {
+	ticker := time.NewTicker(250 * time.Millisecond)
+	defer ticker.Stop()
+	deadline := time.After(lockWaitLimit)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-deadline:
+			return nil, fmt.Errorf("timed out waiting for refresh on another replica")
+		case <-ticker.C:
+			if resp, ok := cachedRate(ctx, cache, RateCacheKey(cantorID, currency)); ok {
+				return resp, nil
+			}
+			if n, err := cache.Exists(ctx, refreshLockKey(cantorID, currency)).Result(); err == nil && n == 0 {
+				return nil, fmt.Errorf("refresh on another replica did not produce a rate")
+			}
+		}
+	}
+}
+
+// fetchArchivedRate returns the most recent stored value, preferring the heartbeat over the last change point.
+func fetchArchivedRate(ctx context.Context, db *pgxpool.Pool, cantorID int, currency string) (*pb.RateResponse, error) {
+	var rates infrastructure.ProcessedRates
+	var observedAt time.Time
+	err := db.QueryRow(ctx, `
+		SELECT buy_rate, sell_rate, observed_at FROM (
+			SELECT buy_rate, sell_rate, observed_at FROM rate_heartbeats WHERE cantor_id = $1 AND currency = $2
+			UNION ALL
+			(SELECT buy_rate, sell_rate, time FROM rates WHERE cantor_id = $1 AND currency = $2 ORDER BY time DESC LIMIT 1)
+		) latest
+		ORDER BY observed_at DESC
+		LIMIT 1`, cantorID, currency).Scan(&rates.Buy, &rates.Sell, &observedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	response := newRateResponse(cantorID, currency, rates)
+	response.FetchedAt = observedAt.Unix()
+	response.Stale = true
+	return response, nil
+}
+
+// cachedRate reads and decodes a cached RateResponse.
+func cachedRate(ctx context.Context, cache redis.UniversalClient, key string) (*pb.RateResponse, bool) {
+	cachedBytes, err := cache.Get(ctx, key).Bytes()
+	if err != nil {
+		return nil, false
+	}
+	var resp pb.RateResponse
+	if err := proto.Unmarshal(cachedBytes, &resp); err != nil {
+		log.Printf("Unmarshal Error: %v", err)
+		return nil, false
+	}
+	return &resp, true
+}
+
+// cacheRate stores an encoded RateResponse as both the fresh and the last known value.
+func cacheRate(ctx context.Context, cache redis.UniversalClient, cantorID int, currency string, protoBytes []byte) {
+	pipe := cache.Pipeline()
+	pipe.Set(ctx, RateCacheKey(cantorID, currency), protoBytes, freshRateTTL)
+	pipe.Set(ctx, lastRateKey(cantorID, currency), protoBytes, lastRateTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		log.Printf("Cache Error: %v", err)
+	}
+}
+
+func lockToken() string {
+	buf := make([]byte, 16)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
@@ -4,17 +4,25 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"strconv"
 	"strings"
 	"time"
 
 	pb "github.com/Niutaq/Gix/api/proto/v1"
+	pbv2 "github.com/Niutaq/Gix/api/proto/v2"
 	"github.com/Niutaq/Gix/internal/infrastructure"
+	"github.com/Niutaq/Gix/pkg/money"
 	"github.com/Niutaq/Gix/pkg/scrapers"
+	"github.com/Niutaq/Gix/pkg/webhooks"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"google.golang.org/protobuf/proto"
 )
 
+// Redis pub/sub channels carrying every published rate, encoded as v1 RateResponse and v2 Rate respectively.
+const (
+	RatesUpdatesChannel   = "rates_updates"
+	RatesUpdatesV2Channel = "rates_updates:v2"
+)
+
 func ScrapeAndProcess(ctx context.Context, app *infrastructure.AppState, ci infrastructure.CantorInfo, id int, currency string) (*pb.RateResponse, infrastructure.ProcessedRates, error) {
 	providerIDStr := fmt.Sprintf("%d", id)
 
@@ -51,17 +59,10 @@ func ScrapeAndProcess(ctx context.Context, app *infrastructure.AppState, ci infr
 		return nil, infrastructure.ProcessedRates{}, fmt.Errorf("rates parsing error: %w", err)
 	}
 
-	response := &pb.RateResponse{
-		BuyRate:   fmt.Sprintf("%.3f", float64(rates.Buy)/infrastructure.MoneyMultiplier),
-		SellRate:  fmt.Sprintf("%.3f", float64(rates.Sell)/infrastructure.MoneyMultiplier),
-		CantorId:  int32(id),
-		Currency:  currency,
-		FetchedAt: time.Now().Unix(),
-	}
+	response := newRateResponse(id, currency, rates)
 
-	if prevBuy, err := GetPreviousRate(app.DB, id, currency); err == nil && prevBuy > 0 {
-		change := ((rates.Buy - prevBuy) * 10000) / prevBuy
-		response.Change24H = change
+	if prevBuy, err := GetPreviousRate(app.DB, id, currency); err == nil && prevBuy.Sign() > 0 {
+		response.Change24H = rates.Buy.ChangeBasisPoints(prevBuy)
 	}
 
 	return response, rates, nil
@@ -76,22 +77,56 @@ func runScrapeStrategy(ctx context.Context, ci infrastructure.CantorInfo, curren
 }
 
 func processRates(result scrapers.ScrapeResult, units int) (infrastructure.ProcessedRates, error) {
-	buyRateF, errB := strconv.ParseFloat(cleanRate(result.BuyRate), 64)
-	sellRateF, errS := strconv.ParseFloat(cleanRate(result.SellRate), 64)
+	buyRate, errB := money.Parse(cleanRate(result.BuyRate))
+	sellRate, errS := money.Parse(cleanRate(result.SellRate))
 
 	if errB != nil || errS != nil {
 		return infrastructure.ProcessedRates{}, fmt.Errorf("couldn't parse data")
 	}
 
-	buyRateInt := int64(buyRateF * infrastructure.MoneyMultiplier)
-	sellRateInt := int64(sellRateF * infrastructure.MoneyMultiplier)
+	scraperType := result.UsedScraperType
+	if scraperType == "" {
+		scraperType = "static"
+	}
+	confidence := result.Confidence
+	if confidence == 0 {
+		confidence = 1
+	}
+	if units < 1 {
+		units = 1
+	}
+
+	return infrastructure.ProcessedRates{
+		Buy:         buyRate.DivUnits(units),
+		Sell:        sellRate.DivUnits(units),
+		Units:       units,
+		ScraperType: scraperType,
+		Confidence:  confidence,
+	}, nil
+}
 
-	if units > 1 {
-		buyRateInt = buyRateInt / int64(units)
-		sellRateInt = sellRateInt / int64(units)
+// newRateResponse builds the v1 wire representation of processed rates, filling both the legacy
+// string fields and the exact decimal ones.
+func newRateResponse(cantorID int, currency string, rates infrastructure.ProcessedRates) *pb.RateResponse {
+	return &pb.RateResponse{
+		BuyRate:   rates.Buy.String(),
+		SellRate:  rates.Sell.String(),
+		CantorId:  int32(cantorID),
+		Currency:  currency,
+		FetchedAt: time.Now().Unix(),
+		Buy:       rates.Buy.Proto(),
+		Sell:      rates.Sell.Proto(),
 	}
+}
 
-	return infrastructure.ProcessedRates{Buy: buyRateInt, Sell: sellRateInt}, nil
+// newRateV2 builds the v2 wire representation of processed rates.
+func newRateV2(cantorID int, currency string, rates infrastructure.ProcessedRates) *pbv2.Rate {
+	rate := pbv2.NewRate(int32(cantorID), currency, rates.Buy, rates.Sell, 0)
+	rate.Units = int32(rates.Units)
+	rate.ScraperType = rates.ScraperType
+	rate.Confidence = rates.Confidence
+	rate.ObservedAt = time.Now().Unix()
+	return rate
 }
 
 func cleanRate(raw string) string {
@@ -106,28 +141,46 @@ func cleanRate(raw string) string {
 	return cleaned.String()
 }
 
-func GetPreviousRate(db *pgxpool.Pool, id int, currency string) (int64, error) {
+func GetPreviousRate(db *pgxpool.Pool, id int, currency string) (money.Rate, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 	defer cancel()
 
-	var buyRateFloat float64
+	var buyRate money.Rate
 	err := db.QueryRow(ctx,
 		"SELECT buy_rate FROM rates WHERE cantor_id=$1 AND currency=$2 AND time <= NOW() - INTERVAL '24 hours' ORDER BY time DESC LIMIT 1",
-		id, currency).Scan(&buyRateFloat)
-	return int64(buyRateFloat * infrastructure.MoneyMultiplier), err
+		id, currency).Scan(&buyRate)
+	return buyRate, err
 }
 
-func SaveToArchive(db *pgxpool.Pool, cantorID int, currency string, buyRate, sellRate int64) {
+// archiveQuery upserts the heartbeat for a cantor/currency pair and appends a change point to
+// 'rates' only when buy/sell differ from the last stored value.
+const archiveQuery = `
+	WITH heartbeat AS (
+		INSERT INTO rate_heartbeats AS h (cantor_id, currency, buy_rate, sell_rate, changed_at, observed_at, scraper_type, confidence)
+		VALUES ($1, $2, $3, $4, NOW(), NOW(), $5, $6)
+		ON CONFLICT (cantor_id, currency) DO UPDATE SET
+			changed_at = CASE
+				WHEN h.buy_rate <> EXCLUDED.buy_rate OR h.sell_rate <> EXCLUDED.sell_rate THEN EXCLUDED.changed_at
+				ELSE h.changed_at
+			END,
+			observed_at = EXCLUDED.observed_at,
+			scraper_type = EXCLUDED.scraper_type,
+			confidence = EXCLUDED.confidence,
+			buy_rate = EXCLUDED.buy_rate,
+			sell_rate = EXCLUDED.sell_rate
+		RETURNING changed_at = observed_at AS changed
+	)
+	INSERT INTO rates (time, cantor_id, currency, buy_rate, sell_rate)
+	SELECT NOW(), $1, $2, $3, $4 FROM heartbeat WHERE changed
+	ON CONFLICT (time, cantor_id, currency) DO NOTHING`
+
+// SaveToArchive records an observation of the given rates. A new row lands in the 'rates' hypertable
+// only when the value changed; otherwise just the heartbeat's observed_at is refreshed.
+func SaveToArchive(db *pgxpool.Pool, cantorID int, currency string, rates infrastructure.ProcessedRates) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	buyF := float64(buyRate) / infrastructure.MoneyMultiplier
-	sellF := float64(sellRate) / infrastructure.MoneyMultiplier
-
-	_, err := db.Exec(ctx,
-		"INSERT INTO rates (time, cantor_id, currency, buy_rate, sell_rate) VALUES (NOW(), $1, $2, $3, $4)",
-		cantorID, currency, buyF, sellF,
-	)
+	_, err := db.Exec(ctx, archiveQuery, cantorID, currency, rates.Buy, rates.Sell, rates.ScraperType, rates.Confidence)
 	if err != nil {
 		if strings.Contains(err.Error(), "23503") || strings.Contains(err.Error(), "foreign key constraint") {
 			log.Printf("Archive Skip: Cantor %d was deleted, ignoring rate save.", cantorID)
@@ -137,25 +190,18 @@ func SaveToArchive(db *pgxpool.Pool, cantorID int, currency string, buyRate, sel
 	}
 }
 
-func CacheAndArchive(ctx context.Context, app *infrastructure.AppState, key string, id int, curr string,
+func CacheAndArchive(ctx context.Context, app *infrastructure.AppState, id int, curr string,
 	resp *pb.RateResponse, rates infrastructure.ProcessedRates) {
 	if protoBytes, err := proto.Marshal(resp); err == nil {
-		app.Cache.Set(ctx, key, protoBytes, 60*time.Second)
+		cacheRate(ctx, app.Cache, id, curr, protoBytes)
 	} else {
 		log.Printf("Marshal Error: %v", err)
 	}
-	go SaveToArchive(app.DB, id, curr, rates.Buy, rates.Sell)
+	go SaveToArchive(app.DB, id, curr, rates)
 }
 
 func UpdateCacheAndNotify(ctx context.Context, app *infrastructure.AppState, cantorID int, curr string, rates infrastructure.ProcessedRates) {
-	cacheKey := fmt.Sprintf("rates:proto%d:%s", cantorID, curr)
-	response := &pb.RateResponse{
-		BuyRate:   fmt.Sprintf("%.3f", float64(rates.Buy)/infrastructure.MoneyMultiplier),
-		SellRate:  fmt.Sprintf("%.3f", float64(rates.Sell)/infrastructure.MoneyMultiplier),
-		CantorId:  int32(cantorID),
-		Currency:  curr,
-		FetchedAt: time.Now().Unix(),
-	}
+	response := newRateResponse(cantorID, curr, rates)
 
 	protoBytes, err := proto.Marshal(response)
 	if err != nil {
@@ -163,8 +209,14 @@ func UpdateCacheAndNotify(ctx context.Context, app *infrastructure.AppState, can
 		return
 	}
 
-	app.Cache.Set(ctx, cacheKey, protoBytes, 60*time.Second)
-	app.Cache.Publish(ctx, "rates_updates", protoBytes)
+	cacheRate(ctx, app.Cache, cantorID, curr, protoBytes)
+	app.Cache.Publish(ctx, RatesUpdatesChannel, protoBytes)
+
+	if v2Bytes, err := proto.Marshal(newRateV2(cantorID, curr, rates)); err == nil {
+		app.Cache.Publish(ctx, RatesUpdatesV2Channel, v2Bytes)
+	} else {
+		log.Printf("Marshal Error: %v", err)
+	}
 
 	if app.JS != nil {
 		subject := fmt.Sprintf("rates.%s", curr)
@@ -173,11 +225,22 @@ func UpdateCacheAndNotify(ctx context.Context, app *infrastructure.AppState, can
 			log.Printf("NATS Publish Error: %v", err)
 		}
 	}
+
+	EvaluateAlerts(ctx, app, cantorID, curr, rates)
+	EmitWebhookEvent(ctx, app, webhooks.EventRateUpdated, map[string]any{
+		"cantorID":    cantorID,
+		"currency":    curr,
+		"buy":         rates.Buy,
+		"sell":        rates.Sell,
+		"units":       rates.Units,
+		"scraperType": rates.ScraperType,
+		"confidence":  rates.Confidence,
+	})
 }
 
 func FetchCantorInfo(ctx context.Context, db *pgxpool.Pool, id int) (infrastructure.CantorInfo, error) {
 	var ci infrastructure.CantorInfo
-	err := db.QueryRow(ctx, "SELECT base_url, strategy, units FROM cantors where id = $1", id).
+	err := db.QueryRow(ctx, "SELECT base_url, strategy, units FROM cantors WHERE id = $1 AND enabled", id).
 		Scan(&ci.BaseURL, &ci.Strategy, &ci.Units)
 	return ci, err
 }
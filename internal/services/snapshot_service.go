@@ -0,0 +1,83 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	pb "github.com/Niutaq/Gix/api/proto/v1"
+	"github.com/Niutaq/Gix/internal/infrastructure"
+	"github.com/Niutaq/Gix/pkg/types"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"golang.org/x/sync/errgroup"
+)
+
+// ParseCurrencies normalises a list of currency codes, dropping duplicates and rejecting unsupported
+// ones. An empty list selects every supported currency.
+func ParseCurrencies(codes []string) ([]string, error) {
+	var out []string
+	for _, code := range codes {
+		code = strings.ToUpper(strings.TrimSpace(code))
+		if code == "" || slices.Contains(out, code) {
+			continue
+		}
+		if !slices.Contains(types.GlobalCurrencies, code) {
+			return nil, fmt.Errorf("unsupported currency %q", code)
+		}
+		out = append(out, code)
+	}
+	if len(out) == 0 {
+		return slices.Clone(types.GlobalCurrencies), nil
+	}
+	return out, nil
+}
+
+// FetchLatestSnapshot returns the latest rates of every cantor for each currency, keyed by currency.
+// The per-currency queries run concurrently.
+func FetchLatestSnapshot(ctx context.Context, db *pgxpool.Pool, currencies []string) (map[string][]infrastructure.LatestRate, error) {
+	results := make([][]infrastructure.LatestRate, len(currencies))
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(4)
+	for i, currency := range currencies {
+		g.Go(func() error {
+			latest, err := FetchLatestRates(ctx, db, currency)
+			results[i] = latest
+			return err
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	snapshot := make(map[string][]infrastructure.LatestRate, len(currencies))
+	for i, currency := range currencies {
+		snapshot[currency] = results[i]
+	}
+	return snapshot, nil
+}
+
+// SnapshotToV1 arranges a snapshot into the v1 cantor x currency matrix, ordered by cantor ID.
+func SnapshotToV1(currencies []string, snapshot map[string][]infrastructure.LatestRate, now time.Time) *pb.SnapshotResponse {
+	rows := make(map[int]*pb.SnapshotRow)
+	for _, currency := range currencies {
+		for _, r := range snapshot[currency] {
+			row, ok := rows[r.CantorID]
+			if !ok {
+				row = &pb.SnapshotRow{CantorId: int32(r.CantorID), DisplayName: r.DisplayName, Rates: map[string]*pb.RateResponse{}}
+				rows[r.CantorID] = row
+			}
+			rate := LatestRateToV1(r)
+			rate.Stale = now.Sub(r.ObservedAt) > rateFreshness
+			row.Rates[currency] = rate
+		}
+	}
+
+	resp := &pb.SnapshotResponse{GeneratedAt: now.Unix(), Currencies: currencies, Cantors: make([]*pb.SnapshotRow, 0, len(rows))}
+	for _, row := range rows {
+		resp.Cantors = append(resp.Cantors, row)
+	}
+	slices.SortFunc(resp.Cantors, func(a, b *pb.SnapshotRow) int { return int(a.CantorId - b.CantorId) })
+	return resp
+}
@@ -0,0 +1,51 @@
+package services
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Niutaq/Gix/internal/infrastructure"
+	"github.com/Niutaq/Gix/pkg/money"
+	"github.com/Niutaq/Gix/pkg/types"
+)
+
+func TestParseCurrencies(t *testing.T) {
+	got, err := ParseCurrencies([]string{"eur", " USD", "EUR", ""})
+	if err != nil || !slices.Equal(got, []string{"EUR", "USD"}) {
+		t.Errorf("ParseCurrencies = %v, %v; want [EUR USD]", got, err)
+	}
+	if got, _ := ParseCurrencies([]string{""}); len(got) != len(types.GlobalCurrencies) {
+		t.Errorf("empty list selected %v, want every supported currency", got)
+	}
+	if _, err := ParseCurrencies([]string{"EUR", "XXX"}); err == nil {
+		t.Errorf("unsupported currency accepted")
+	}
+}
+
+func TestSnapshotToV1(t *testing.T) {
+	now := time.Now()
+	snapshot := map[string][]infrastructure.LatestRate{
+		"EUR": {
+			{CantorID: 2, Currency: "EUR", Buy: money.MustParse("4.25"), Sell: money.MustParse("4.28"), ObservedAt: now},
+			{CantorID: 1, Currency: "EUR", Buy: money.MustParse("4.20"), Sell: money.MustParse("4.30"), ObservedAt: now.Add(-2 * time.Hour)},
+		},
+		"USD": {
+			{CantorID: 2, Currency: "USD", Buy: money.MustParse("3.90"), Sell: money.MustParse("3.95"), ObservedAt: now},
+		},
+	}
+
+	resp := SnapshotToV1([]string{"EUR", "USD"}, snapshot, now)
+	if len(resp.Cantors) != 2 || resp.Cantors[0].CantorId != 1 || resp.Cantors[1].CantorId != 2 {
+		t.Fatalf("rows = %v, want cantors 1 and 2 in order", resp.Cantors)
+	}
+	if _, ok := resp.Cantors[0].Rates["USD"]; ok {
+		t.Errorf("cantor 1 does not quote USD but has a USD cell")
+	}
+	if !resp.Cantors[0].Rates["EUR"].Stale || resp.Cantors[1].Rates["EUR"].Stale {
+		t.Errorf("only the two-hour-old observation should be stale")
+	}
+	if got := resp.Cantors[1].Rates["USD"].BuyRate; got != "3.9000" {
+		t.Errorf("USD buy = %s, want 3.9000", got)
+	}
+}
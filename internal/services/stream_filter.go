@@ -0,0 +1,241 @@
+package services
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	pb "github.com/Niutaq/Gix/api/proto/v1"
+	"github.com/Niutaq/Gix/internal/infrastructure"
+	"github.com/Niutaq/Gix/pkg/geo"
+	"github.com/Niutaq/Gix/pkg/money"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Stream subscription limits.
+const (
+	MaxStreamCantors  = 1000
+	MaxStreamThrottle = time.Hour
+)
+
+// StreamFilter decides which rate updates a StreamRates subscriber receives. Currencies, cantors and
+// the area are resolved into sets when the stream starts, so matching an update costs two map
+// lookups. A filter belongs to one stream and is not safe for concurrent use.
+type StreamFilter struct {
+	currencies  map[string]bool
+	cantors     map[int32]bool // nil matches every cantor
+	minChangeBP int64
+	throttle    time.Duration
+
+	sent     map[streamKey]sentRate
+	held     map[streamKey]*pb.RateResponse
+	position uint64 // highest sequence passed to Admit
+}
+
+type streamKey struct {
+	cantorID int32
+	currency string
+}
+
+// sentRate is the last rate a subscriber received for a cantor and currency.
+type sentRate struct {
+	buy, sell money.Rate
+	at        time.Time
+}
+
+// StreamNeedsLocations reports whether the request selects an area, which needs the cantor locations.
+func StreamNeedsLocations(req *pb.StreamRatesRequest) bool {
+	return req.RadiusKm != 0 || req.Bbox != nil
+}
+
+// FetchCantorLocations returns the position of every cantor with known coordinates.
+func FetchCantorLocations(ctx context.Context, db *pgxpool.Pool) (map[int]infrastructure.GeoPoint, error) {
+	rows, err := db.Query(ctx, "SELECT id, latitude, longitude FROM cantors WHERE latitude IS NOT NULL AND longitude IS NOT NULL")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	locations := make(map[int]infrastructure.GeoPoint)
+	for rows.Next() {
+		var id int
+		var p infrastructure.GeoPoint
+		if err := rows.Scan(&id, &p.Lat, &p.Lon); err != nil {
+			return nil, err
+		}
+		locations[id] = p
+	}
+	return locations, rows.Err()
+}
+
+// NewStreamFilter validates the subscription options of req. locations are only used when the request
+// selects an area; cantors without a position never match one.
+func NewStreamFilter(req *pb.StreamRatesRequest, locations map[int]infrastructure.GeoPoint) (*StreamFilter, error) {
+	currencies, err := ParseCurrencies(req.Currencies)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.CantorIds) > MaxStreamCantors {
+		return nil, fmt.Errorf("at most %d cantor IDs are allowed", MaxStreamCantors)
+	}
+	if req.RadiusKm < 0 || req.RadiusKm > MaxCantorRadiusKm {
+		return nil, fmt.Errorf("radius_km must be in (0, %d]", MaxCantorRadiusKm)
+	}
+	if req.RadiusKm > 0 && !validLatLon(req.Lat, req.Lon) {
+		return nil, errors.New("radius_km requires a valid lat and lon")
+	}
+	if b := req.Bbox; b != nil && (!validLatLon(b.MinLat, b.MinLon) || !validLatLon(b.MaxLat, b.MaxLon) || b.MinLat > b.MaxLat) {
+		return nil, errors.New("bbox must hold valid corners with min_lat <= max_lat")
+	}
+	if req.MinChangeBp < 0 {
+		return nil, errors.New("min_change_bp must not be negative")
+	}
+	throttle := time.Duration(req.ThrottleMs) * time.Millisecond
+	if throttle < 0 || throttle > MaxStreamThrottle {
+		return nil, fmt.Errorf("throttle_ms must be between 0 and %d", MaxStreamThrottle.Milliseconds())
+	}
+
+	f := &StreamFilter{
+		currencies:  make(map[string]bool, len(currencies)),
+		minChangeBP: req.MinChangeBp,
+		throttle:    throttle,
+		sent:        make(map[streamKey]sentRate),
+		held:        make(map[streamKey]*pb.RateResponse),
+	}
+	for _, c := range currencies {
+		f.currencies[c] = true
+	}
+
+	if len(req.CantorIds) > 0 {
+		f.cantors = make(map[int32]bool, len(req.CantorIds))
+		for _, id := range req.CantorIds {
+			f.cantors[id] = true
+		}
+	}
+	if StreamNeedsLocations(req) {
+		var box *infrastructure.BoundingBox
+		if b := req.Bbox; b != nil {
+			box = &infrastructure.BoundingBox{MinLat: b.MinLat, MinLon: b.MinLon, MaxLat: b.MaxLat, MaxLon: b.MaxLon}
+		}
+		inArea := make(map[int32]bool)
+		for id, p := range locations {
+			if f.cantors != nil && !f.cantors[int32(id)] {
+				continue
+			}
+			if req.RadiusKm > 0 && geo.DistanceKm(req.Lat, req.Lon, p.Lat, p.Lon) > req.RadiusKm {
+				continue
+			}
+			if box != nil && !box.Contains(p.Lat, p.Lon) {
+				continue
+			}
+			inArea[int32(id)] = true
+		}
+		f.cantors = inArea
+	}
+	return f, nil
+}
+
+// Throttle returns the minimum interval between two updates of a cantor and currency; zero disables
+// throttling.
+func (f *StreamFilter) Throttle() time.Duration {
+	return f.throttle
+}
+
+// Match reports whether the rate belongs to the subscribed currencies, cantors and area.
+func (f *StreamFilter) Match(rate *pb.RateResponse) bool {
+	return f.currencies[rate.Currency] && (f.cantors == nil || f.cantors[rate.CantorId])
+}
+
+// Sent records a rate delivered without going through Admit, such as a snapshot row, as the
+// reference for later updates.
+func (f *StreamFilter) Sent(rate *pb.RateResponse, now time.Time) {
+	f.record(streamKey{rate.CantorId, rate.Currency}, rate, now)
+}
+
+// Admit decides whether a matching update is sent now. Updates that moved less than the minimum
+// change since the last rate sent are dropped, together with any update held back for the same
+// cantor and currency; updates arriving within the throttle interval are held back for Due.
+//
+// An admitted update is restamped with its resume point: while an earlier update is held back, a
+// client resuming after the stamp is replayed the held one instead of skipping it.
+func (f *StreamFilter) Admit(rate *pb.RateResponse, now time.Time) bool {
+	key := streamKey{rate.CantorId, rate.Currency}
+	f.position = max(f.position, rate.Sequence)
+	prev, seen := f.sent[key]
+	if seen && f.minChangeBP > 0 && !f.moved(prev, rate) {
+		delete(f.held, key)
+		return false
+	}
+	if seen && now.Sub(prev.at) < f.throttle {
+		f.held[key] = rate
+		return false
+	}
+	f.record(key, rate, now)
+	rate.Sequence = f.resumePoint()
+	return true
+}
+
+// Due returns the held back updates whose throttle interval has ended, in stream order. They are
+// the latest rates of their cantor and currency up to the last update admitted, and are stamped
+// with its resume point, so sequences never decrease along the stream.
+func (f *StreamFilter) Due(now time.Time) []*pb.RateResponse {
+	var due []*pb.RateResponse
+	for key, rate := range f.held {
+		if now.Sub(f.sent[key].at) >= f.throttle {
+			f.record(key, rate, now)
+			due = append(due, rate)
+		}
+	}
+	slices.SortFunc(due, func(a, b *pb.RateResponse) int {
+		if c := cmp.Compare(a.Sequence, b.Sequence); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.CantorId, b.CantorId)
+	})
+	seq := f.resumePoint()
+	for _, rate := range due {
+		rate.Sequence = seq
+	}
+	return due
+}
+
+// resumePoint returns the highest sequence a client may resume after without skipping an update
+// still held back. It is zero for streams without sequences.
+func (f *StreamFilter) resumePoint() uint64 {
+	seq := f.position
+	for _, rate := range f.held {
+		if rate.Sequence > 0 && rate.Sequence-1 < seq {
+			seq = rate.Sequence - 1
+		}
+	}
+	return seq
+}
+
+func (f *StreamFilter) record(key streamKey, rate *pb.RateResponse, now time.Time) {
+	buy, _ := money.FromProto(rate.Buy)
+	sell, _ := money.FromProto(rate.Sell)
+	f.sent[key] = sentRate{buy: buy, sell: sell, at: now}
+	delete(f.held, key)
+}
+
+// moved reports whether buy or sell changed by at least the minimum since prev.
+func (f *StreamFilter) moved(prev sentRate, rate *pb.RateResponse) bool {
+	buy, _ := money.FromProto(rate.Buy)
+	sell, _ := money.FromProto(rate.Sell)
+	for _, pair := range [2][2]money.Rate{{prev.buy, buy}, {prev.sell, sell}} {
+		was, now := pair[0], pair[1]
+		if was.IsZero() {
+			if !now.IsZero() {
+				return true
+			}
+			continue
+		}
+		if bp := now.ChangeBasisPoints(was); bp >= f.minChangeBP || -bp >= f.minChangeBP {
+			return true
+		}
+	}
+	return false
+}
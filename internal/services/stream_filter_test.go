@@ -0,0 +1,179 @@
+package services
+
+import (
+	"maps"
+	"testing"
+	"time"
+
+	pb "github.com/Niutaq/Gix/api/proto/v1"
+	"github.com/Niutaq/Gix/internal/infrastructure"
+	"github.com/Niutaq/Gix/pkg/money"
+	"google.golang.org/protobuf/proto"
+)
+
+func streamRate(cantorID int32, currency, buy string, seq uint64) *pb.RateResponse {
+	r := money.MustParse(buy)
+	return &pb.RateResponse{CantorId: cantorID, Currency: currency, Buy: r.Proto(), Sell: r.Proto(), Sequence: seq}
+}
+
+func TestStreamFilterMatch(t *testing.T) {
+	locations := map[int]infrastructure.GeoPoint{
+		1: {Lat: 52.23, Lon: 21.01}, // Warsaw centre
+		2: {Lat: 52.25, Lon: 21.05}, // a few km away
+		3: {Lat: 50.06, Lon: 19.94}, // Kraków
+	}
+	f, err := NewStreamFilter(&pb.StreamRatesRequest{
+		Currencies: []string{"eur"},
+		CantorIds:  []int32{1, 3},
+		Lat:        52.23,
+		Lon:        21.01,
+		RadiusKm:   10,
+	}, locations)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		cantorID int32
+		currency string
+		want     bool
+	}{
+		{1, "EUR", true},
+		{1, "USD", false},
+		{2, "EUR", false}, // in the area but not selected
+		{3, "EUR", false}, // selected but outside the area
+	} {
+		if got := f.Match(streamRate(tc.cantorID, tc.currency, "4", 0)); got != tc.want {
+			t.Errorf("Match(%d %s) = %v, want %v", tc.cantorID, tc.currency, got, tc.want)
+		}
+	}
+
+	f, err = NewStreamFilter(&pb.StreamRatesRequest{
+		Bbox: &pb.BoundingBox{MinLat: 49, MinLon: 19, MaxLat: 51, MaxLon: 20},
+	}, locations)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Match(streamRate(1, "EUR", "4", 0)) || !f.Match(streamRate(3, "USD", "4", 0)) {
+		t.Error("bbox should select only Kraków")
+	}
+
+	for name, req := range map[string]*pb.StreamRatesRequest{
+		"currency":   {Currencies: []string{"XYZ"}},
+		"radius":     {RadiusKm: 5000, Lat: 52, Lon: 21},
+		"no centre":  {RadiusKm: 5, Lat: 95},
+		"bbox":       {Bbox: &pb.BoundingBox{MinLat: 51, MaxLat: 49}},
+		"min change": {MinChangeBp: -1},
+		"throttle":   {ThrottleMs: -5},
+	} {
+		if _, err := NewStreamFilter(req, nil); err == nil {
+			t.Errorf("%s: invalid request accepted", name)
+		}
+	}
+}
+
+func TestStreamFilterAdmit(t *testing.T) {
+	f, err := NewStreamFilter(&pb.StreamRatesRequest{MinChangeBp: 10, ThrottleMs: 1000}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t0 := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	if !f.Admit(streamRate(1, "EUR", "4.0000", 1), t0) {
+		t.Fatal("first update should be sent")
+	}
+	if f.Admit(streamRate(1, "EUR", "4.0020", 2), t0.Add(2*time.Second)) {
+		t.Error("a 5bp move should be dropped")
+	}
+	if !f.Admit(streamRate(1, "EUR", "4.0100", 3), t0.Add(2*time.Second)) {
+		t.Error("a 25bp move after the interval should be sent")
+	}
+
+	// Within the interval the latest update is held back and released by Due.
+	if f.Admit(streamRate(1, "EUR", "4.0500", 4), t0.Add(2500*time.Millisecond)) ||
+		f.Admit(streamRate(1, "EUR", "4.0600", 5), t0.Add(2700*time.Millisecond)) {
+		t.Error("updates within the interval should be held back")
+	}
+	if due := f.Due(t0.Add(2900 * time.Millisecond)); len(due) != 0 {
+		t.Errorf("Due before the interval ended = %v", due)
+	}
+	due := f.Due(t0.Add(3 * time.Second))
+	if len(due) != 1 || due[0].Sequence != 5 {
+		t.Fatalf("Due = %v, want the update with sequence 5", due)
+	}
+
+	// A held back update is discarded when the rate returns to the last value sent.
+	f.Admit(streamRate(1, "EUR", "4.1000", 6), t0.Add(3100*time.Millisecond))
+	f.Admit(streamRate(1, "EUR", "4.0600", 7), t0.Add(3200*time.Millisecond))
+	if due := f.Due(t0.Add(time.Minute)); len(due) != 0 {
+		t.Errorf("Due = %v, want nothing", due)
+	}
+}
+
+// TestStreamFilterResume cuts a throttled stream after every update sent and resumes after the last
+// sequence received; the client must end at the latest rate of every cantor.
+func TestStreamFilterResume(t *testing.T) {
+	t0 := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	published := []struct {
+		rate *pb.RateResponse
+		at   time.Duration
+	}{
+		{streamRate(1, "EUR", "4.00", 1), 0},
+		{streamRate(2, "EUR", "4.10", 2), 0},
+		{streamRate(1, "EUR", "4.01", 3), 100 * time.Millisecond}, // held back
+		{streamRate(2, "EUR", "4.20", 4), 1500 * time.Millisecond},
+		{streamRate(3, "EUR", "4.30", 5), 1600 * time.Millisecond},
+	}
+	latest := map[int32]string{1: "4.01", 2: "4.20", 3: "4.30"}
+	buy := func(rate *pb.RateResponse) string {
+		r, _ := money.FromProto(rate.Buy)
+		return r.StringFixed(2)
+	}
+
+	// stream replays the updates after resumeFrom through a new filter, releasing held back ones at
+	// the end of the run, and returns what the client receives.
+	stream := func(resumeFrom uint64) []*pb.RateResponse {
+		f, err := NewStreamFilter(&pb.StreamRatesRequest{ThrottleMs: 1000}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var out []*pb.RateResponse
+		for _, p := range published {
+			if p.rate.Sequence <= resumeFrom {
+				continue
+			}
+			rate := proto.Clone(p.rate).(*pb.RateResponse)
+			if f.Admit(rate, t0.Add(p.at)) {
+				out = append(out, rate)
+			}
+		}
+		return append(out, f.Due(t0.Add(time.Minute))...)
+	}
+
+	sent := stream(0)
+	for i := 1; i < len(sent); i++ {
+		if sent[i].Sequence < sent[i-1].Sequence {
+			t.Fatalf("sequence decreases at %d: %v", i, sent)
+		}
+	}
+	for cut := 0; cut <= len(sent); cut++ {
+		got := make(map[int32]string)
+		var resume uint64
+		for _, rate := range sent[:cut] {
+			got[rate.CantorId] = buy(rate)
+			resume = rate.Sequence
+		}
+		for _, rate := range stream(resume) {
+			got[rate.CantorId] = buy(rate)
+		}
+		if !maps.Equal(got, latest) {
+			t.Errorf("cut after %d: client ends at %v, want %v", cut, got, latest)
+		}
+	}
+}
+
+func TestBoundingBoxContains(t *testing.T) {
+	box := infrastructure.BoundingBox{MinLat: -10, MinLon: 170, MaxLat: 10, MaxLon: -170}
+	if !box.Contains(0, 175) || !box.Contains(0, -175) || box.Contains(0, 0) || box.Contains(20, 175) {
+		t.Error("antimeridian box containment is wrong")
+	}
+}
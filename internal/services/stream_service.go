@@ -0,0 +1,85 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	pb "github.com/Niutaq/Gix/api/proto/v1"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// StreamHeartbeat is how often an HTTP rate stream sends a heartbeat, keeping proxies from closing
+// idle connections and letting clients detect a dead one.
+const StreamHeartbeat = 15 * time.Second
+
+// ReplayStart returns the first RATES stream sequence to deliver to a client resuming after
+// resumeFrom, given the first and last sequences the stream still retains. A zero resumeFrom starts
+// with the next update. gap reports that updates after resumeFrom are no longer retained, or that
+// resumeFrom is ahead of the stream, so the client needs the current rates before the live updates.
+func ReplayStart(resumeFrom, first, last uint64) (start uint64, gap bool) {
+	if resumeFrom == 0 {
+		return last + 1, false
+	}
+	if resumeFrom > last || resumeFrom+1 < first {
+		return last + 1, true
+	}
+	return resumeFrom + 1, false
+}
+
+// RateResumeToken returns the token a client presents to resume a stream after the given update.
+// Tokens are opaque to clients.
+func RateResumeToken(rate *pb.RateResponse) string {
+	return strconv.FormatInt(rate.FetchedAt, 10)
+}
+
+// ParseResumeToken returns the observation time a resume token stands for.
+func ParseResumeToken(token string) (time.Time, error) {
+	sec, err := strconv.ParseInt(token, 10, 64)
+	if err != nil || sec <= 0 {
+		return time.Time{}, fmt.Errorf("invalid resume token %q", token)
+	}
+	return time.Unix(sec, 0), nil
+}
+
+// SnapshotRates returns the latest rate of every cantor in the given currencies, as served by
+// GetAllRates, marked as a stream snapshot.
+func SnapshotRates(ctx context.Context, db *pgxpool.Pool, currencies []string) ([]*pb.RateResponse, error) {
+	snapshot, err := FetchLatestSnapshot(ctx, db, currencies)
+	if err != nil {
+		return nil, err
+	}
+
+	var rates []*pb.RateResponse
+	for _, currency := range currencies {
+		for _, r := range snapshot[currency] {
+			rate := LatestRateToV1(r)
+			rate.Snapshot = true
+			rates = append(rates, rate)
+		}
+	}
+	return rates, nil
+}
+
+// RatesSince returns the latest rate of every cantor in the given currencies observed at or after
+// since, oldest first. Replaying them brings a resumed stream up to date; a rate that changed several
+// times while the client was away is only sent in its latest state.
+func RatesSince(ctx context.Context, db *pgxpool.Pool, currencies []string, since time.Time) ([]*pb.RateResponse, error) {
+	snapshot, err := FetchLatestSnapshot(ctx, db, currencies)
+	if err != nil {
+		return nil, err
+	}
+
+	var rates []*pb.RateResponse
+	for _, currency := range currencies {
+		for _, r := range snapshot[currency] {
+			if !r.ObservedAt.Before(since) {
+				rates = append(rates, LatestRateToV1(r))
+			}
+		}
+	}
+	slices.SortStableFunc(rates, func(a, b *pb.RateResponse) int { return int(a.FetchedAt - b.FetchedAt) })
+	return rates, nil
+}
@@ -0,0 +1,24 @@
+package services
+
+import "testing"
+
+func TestReplayStart(t *testing.T) {
+	for name, tc := range map[string]struct {
+		resumeFrom, first, last, start uint64
+		gap                            bool
+	}{
+		"fresh":          {0, 10, 50, 51, false},
+		"within window":  {20, 10, 50, 21, false},
+		"oldest kept":    {9, 10, 50, 10, false},
+		"up to date":     {50, 10, 50, 51, false},
+		"expired":        {5, 10, 50, 51, true},
+		"ahead":          {70, 10, 50, 51, true},
+		"empty stream":   {0, 0, 0, 1, false},
+		"stream was cut": {3, 0, 0, 1, true},
+	} {
+		start, gap := ReplayStart(tc.resumeFrom, tc.first, tc.last)
+		if start != tc.start || gap != tc.gap {
+			t.Errorf("%s: ReplayStart = %d, %v, want %d, %v", name, start, gap, tc.start, tc.gap)
+		}
+	}
+}
@@ -0,0 +1,272 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Niutaq/Gix/internal/infrastructure"
+	"github.com/Niutaq/Gix/pkg/scrapers"
+	"github.com/Niutaq/Gix/pkg/webhooks"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Delivery statuses stored in webhook_deliveries.
+const (
+	DeliveryPending   = "pending"
+	DeliveryRetrying  = "retrying"
+	DeliveryDelivered = "delivered"
+	DeliveryFailed    = "failed"
+)
+
+// ErrWebhookNotFound is returned when a webhook does not exist.
+var ErrWebhookNotFound = errors.New("webhook not found")
+
+// webhookClient does not follow redirects, so a receiver cannot bounce deliveries to an address
+// that ValidateURL would have refused.
+var webhookClient = &http.Client{
+	Timeout: 10 * time.Second,
+	CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+// webhookEnvelope is the JSON body POSTed to receivers.
+type webhookEnvelope struct {
+	Event     string    `json:"event"`
+	CreatedAt time.Time `json:"createdAt"`
+	Data      any       `json:"data"`
+}
+
+// ValidateWebhook checks the target URL and event filters and removes duplicate events.
+func ValidateWebhook(hook *infrastructure.Webhook) error {
+	if hook.URL == "" {
+		return fmt.Errorf("url is required")
+	}
+	if err := scrapers.ValidateURL(hook.URL); err != nil {
+		return err
+	}
+	if len(hook.Events) == 0 {
+		return fmt.Errorf("at least one event is required")
+	}
+
+	seen := make(map[string]bool)
+	events := hook.Events[:0]
+	for _, ev := range hook.Events {
+		if !webhooks.IsValidEvent(ev) {
+			return fmt.Errorf("unsupported event %q", ev)
+		}
+		if !seen[ev] {
+			seen[ev] = true
+			events = append(events, ev)
+		}
+	}
+	hook.Events = events
+	return nil
+}
+
+// CreateWebhook validates and stores a webhook. A signing secret is generated when none is given;
+// the returned webhook is the only place it is exposed.
+func CreateWebhook(ctx context.Context, db *pgxpool.Pool, hook infrastructure.Webhook) (infrastructure.Webhook, error) {
+	if err := ValidateWebhook(&hook); err != nil {
+		return hook, err
+	}
+	if hook.Secret == "" {
+		secret, err := webhooks.NewSecret()
+		if err != nil {
+			return hook, err
+		}
+		hook.Secret = secret
+	}
+
+	err := db.QueryRow(ctx, `
+		INSERT INTO webhooks (url, secret, events)
+		VALUES ($1, $2, $3)
+		RETURNING id, enabled, created_at`,
+		hook.URL, hook.Secret, hook.Events).Scan(&hook.ID, &hook.Enabled, &hook.CreatedAt)
+	return hook, err
+}
+
+// ListWebhooks returns every webhook without its secret.
+func ListWebhooks(ctx context.Context, db *pgxpool.Pool) ([]infrastructure.Webhook, error) {
+	rows, err := db.Query(ctx, "SELECT id, url, events, enabled, created_at FROM webhooks ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	hooks := []infrastructure.Webhook{}
+	for rows.Next() {
+		var h infrastructure.Webhook
+		if err := rows.Scan(&h.ID, &h.URL, &h.Events, &h.Enabled, &h.CreatedAt); err != nil {
+			log.Printf("Webhook Scan Error: %v", err)
+			continue
+		}
+		hooks = append(hooks, h)
+	}
+	return hooks, rows.Err()
+}
+
+// DeleteWebhook removes a webhook together with its delivery log.
+func DeleteWebhook(ctx context.Context, db *pgxpool.Pool, id int) error {
+	res, err := db.Exec(ctx, "DELETE FROM webhooks WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+	if res.RowsAffected() == 0 {
+		return ErrWebhookNotFound
+	}
+	return nil
+}
+
+// ListWebhookDeliveries returns the delivery log of a webhook, newest first, optionally filtered by status.
+func ListWebhookDeliveries(ctx context.Context, db *pgxpool.Pool, webhookID int, status string, limit int) ([]infrastructure.WebhookDelivery, error) {
+	rows, err := db.Query(ctx, `
+		SELECT id, webhook_id, event, payload, status, attempts, COALESCE(response_code, 0), COALESCE(last_error, ''),
+			created_at, updated_at
+		FROM webhook_deliveries
+		WHERE webhook_id = $1 AND ($2 = '' OR status = $2)
+		ORDER BY created_at DESC
+		LIMIT $3`, webhookID, status, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := []infrastructure.WebhookDelivery{}
+	for rows.Next() {
+		var d infrastructure.WebhookDelivery
+		if err := rows.Scan(&d.ID, &d.WebhookID, &d.Event, &d.Payload, &d.Status, &d.Attempts, &d.ResponseCode,
+			&d.LastError, &d.CreatedAt, &d.UpdatedAt); err != nil {
+			log.Printf("Webhook Delivery Scan Error: %v", err)
+			continue
+		}
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, rows.Err()
+}
+
+// EmitWebhookEvent records a delivery for every enabled webhook subscribed to the event and queues them.
+func EmitWebhookEvent(ctx context.Context, app *infrastructure.AppState, event string, data any) {
+	payload, err := json.Marshal(webhookEnvelope{Event: event, CreatedAt: time.Now().UTC(), Data: data})
+	if err != nil {
+		log.Printf("Webhook Marshal Error: %v", err)
+		return
+	}
+
+	rows, err := app.DB.Query(ctx, `
+		INSERT INTO webhook_deliveries (webhook_id, event, payload)
+		SELECT id, $1, $2 FROM webhooks WHERE enabled AND $1 = ANY(events)
+		RETURNING id`, event, payload)
+	if err != nil {
+		log.Printf("Webhook Enqueue Error: %v", err)
+		return
+	}
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	rows.Close()
+
+	for _, id := range ids {
+		enqueueDelivery(app, id)
+	}
+}
+
+// enqueueDelivery hands a delivery to the JetStream dispatcher. Without NATS the delivery is retried
+// in-process instead, which loses pending retries on restart but keeps webhooks working in development.
+func enqueueDelivery(app *infrastructure.AppState, id int64) {
+	if app.JS != nil {
+		_, err := app.JS.Publish(infrastructure.WebhookDeliverySubject, []byte(strconv.FormatInt(id, 10)))
+		if err == nil {
+			return
+		}
+		log.Printf("NATS Publish Error: %v", err)
+	}
+
+	go func() {
+		for {
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+			retryAfter, retry := AttemptDelivery(ctx, app.DB, id)
+			cancel()
+			if !retry {
+				return
+			}
+			time.Sleep(retryAfter)
+		}
+	}()
+}
+
+// AttemptDelivery sends a queued delivery once and records the outcome. It reports whether the delivery
+// should be attempted again and after what delay.
+func AttemptDelivery(ctx context.Context, db *pgxpool.Pool, id int64) (time.Duration, bool) {
+	var (
+		d       infrastructure.WebhookDelivery
+		url     string
+		secret  string
+		enabled bool
+	)
+	err := db.QueryRow(ctx, `
+		SELECT d.event, d.payload, d.status, d.attempts, w.url, w.secret, w.enabled
+		FROM webhook_deliveries d
+		JOIN webhooks w ON w.id = d.webhook_id
+		WHERE d.id = $1`, id).Scan(&d.Event, &d.Payload, &d.Status, &d.Attempts, &url, &secret, &enabled)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, false
+	}
+	if err != nil {
+		log.Printf("Webhook Delivery Load Error: %v", err)
+		return webhooks.Backoff(d.Attempts + 1), true
+	}
+	if d.Status == DeliveryDelivered || d.Status == DeliveryFailed {
+		return 0, false
+	}
+
+	var res webhooks.Result
+	permanent := true
+	if !enabled {
+		res.Err = fmt.Errorf("webhook disabled")
+	} else if err := scrapers.ValidateURL(url); err != nil {
+		res.Err = fmt.Errorf("security block: %v", err)
+	} else {
+		res = webhooks.Send(ctx, webhookClient, url, secret, d.Event, strconv.FormatInt(id, 10), d.Payload)
+		permanent = !res.Retryable()
+	}
+
+	attempts := d.Attempts + 1
+	status := DeliveryFailed
+	retry := false
+	switch {
+	case res.OK():
+		status = DeliveryDelivered
+	case !permanent && attempts < webhooks.MaxAttempts:
+		status = DeliveryRetrying
+		retry = true
+	}
+
+	var lastError string
+	if res.Err != nil {
+		lastError = res.Err.Error()
+	} else if !res.OK() {
+		lastError = http.StatusText(res.StatusCode)
+	}
+
+	_, err = db.Exec(ctx, `
+		UPDATE webhook_deliveries
+		SET status = $2, attempts = $3, response_code = NULLIF($4, 0), last_error = NULLIF($5, ''), updated_at = NOW()
+		WHERE id = $1`, id, status, attempts, res.StatusCode, lastError)
+	if err != nil {
+		log.Printf("Webhook Delivery Update Error: %v", err)
+	}
+
+	return webhooks.Backoff(attempts), retry
+}
@@ -50,8 +50,30 @@ func Harvest(app *infrastructure.AppState, currencies []string) {
 	log.Println("Background Harvest: Parallel cycle completed.")
 }
 
+// HarvestNewCantor collects every currency of a just discovered cantor in the background, two at a
+// time, so its rates show up before the next harvest cycle.
+func HarvestNewCantor(app *infrastructure.AppState, ci infrastructure.CantorInfo) {
+	go func() {
+		log.Printf("Starting post-discovery background harvest for new cantor: %s", ci.DisplayName)
+		var wg sync.WaitGroup
+		sem := make(chan struct{}, 2)
+		for _, curr := range types.GlobalCurrencies {
+			wg.Add(1)
+			go func(c string) {
+				defer wg.Done()
+				sem <- struct{}{}
+				ProcessCantorCurrency(context.Background(), app, ci, c)
+				time.Sleep(1 * time.Second)
+				<-sem
+			}(curr)
+		}
+		wg.Wait()
+		log.Printf("Finished post-discovery background harvest for: %s", ci.DisplayName)
+	}()
+}
+
 func FetchAllCantors(ctx context.Context, db *pgxpool.Pool) ([]infrastructure.CantorInfo, error) {
-	rows, err := db.Query(ctx, "SELECT id, display_name, base_url, strategy, units FROM cantors")
+	rows, err := db.Query(ctx, "SELECT id, display_name, base_url, strategy, units FROM cantors WHERE enabled")
 	if err != nil {
 		return nil, err
 	}
@@ -79,13 +101,17 @@ func ProcessCantorCurrency(ctx context.Context, app *infrastructure.AppState, ci
 		return
 	}
 
-	if rates.Buy == 0 && rates.Sell == 0 {
+	if rates.Buy.IsZero() && rates.Sell.IsZero() {
 		return
 	}
 
-	log.Printf("Harvesting: %s -> %s (%.3f / %.3f) [Perf: %v]", ci.DisplayName, curr, float64(rates.Buy)/infrastructure.MoneyMultiplier, float64(rates.Sell)/infrastructure.MoneyMultiplier, duration)
+	log.Printf("Harvesting: %s -> %s (%s / %s) [Perf: %v]", ci.DisplayName, curr, rates.Buy, rates.Sell, duration)
 	finops.Stats.Record(ci.DisplayName, duration)
 
-	services.SaveToArchive(app.DB, ci.ID, curr, rates.Buy, rates.Sell)
+	if !services.ScreenRates(ctx, app.DB, ci.ID, curr, rates) {
+		return
+	}
+
+	services.SaveToArchive(app.DB, ci.ID, curr, rates)
 	services.UpdateCacheAndNotify(ctx, app, ci.ID, curr, rates)
 }
@@ -0,0 +1,45 @@
+package workers
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/Niutaq/Gix/internal/infrastructure"
+	"github.com/Niutaq/Gix/internal/services"
+	"github.com/nats-io/nats.go"
+)
+
+// StartWebhookDispatcher consumes queued webhook deliveries from JetStream. Failed attempts are
+// NAKed with an exponential delay so retries survive restarts; replicas share the durable consumer.
+func StartWebhookDispatcher(app *infrastructure.AppState) {
+	if app.JS == nil {
+		log.Println("Webhook Dispatcher: NATS unavailable, deliveries are retried in-process.")
+		return
+	}
+
+	_, err := app.JS.QueueSubscribe(infrastructure.WebhookDeliverySubject, "webhook-dispatcher", func(msg *nats.Msg) {
+		id, err := strconv.ParseInt(string(msg.Data), 10, 64)
+		if err != nil {
+			log.Printf("Webhook Dispatcher: invalid delivery ID %q", msg.Data)
+			_ = msg.Term()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+		retryAfter, retry := services.AttemptDelivery(ctx, app.DB, id)
+		cancel()
+
+		if retry {
+			_ = msg.NakWithDelay(retryAfter)
+			return
+		}
+		_ = msg.Ack()
+	}, nats.Durable("webhook-dispatcher"), nats.ManualAck(), nats.AckWait(30*time.Second))
+	if err != nil {
+		log.Printf("Webhook Dispatcher Subscribe Error: %v", err)
+		return
+	}
+	log.Println("Webhook Dispatcher: consuming deliveries from JetStream.")
+}
@@ -0,0 +1,260 @@
+package alerts
+
+import (
+	// Standard libraries
+	"fmt"
+	"sync"
+	"time"
+
+	// External utilities
+	"github.com/Niutaq/Gix/pkg/geo"
+	"github.com/Niutaq/Gix/pkg/money"
+)
+
+// Rule kinds.
+const (
+	KindAbove  = "above"  // fires when the rate rises to or above Threshold
+	KindBelow  = "below"  // fires when the rate falls to or below Threshold
+	KindChange = "change" // fires when the rate moves by ChangeBP from the last reference value
+)
+
+// Rule sides, i.e. which of the cantor's rates is watched.
+const (
+	SideBuy  = "buy"
+	SideSell = "sell"
+)
+
+// defaultCooldown is used when a rule does not set one.
+const defaultCooldown = time.Hour
+
+// Rule is a user's subscription to a rate condition.
+type Rule struct {
+	ID              int        `json:"id"`
+	Owner           string     `json:"owner"`
+	Currency        string     `json:"currency"`
+	Side            string     `json:"side"`
+	Kind            string     `json:"kind"`
+	Threshold       money.Rate `json:"threshold"`
+	ChangeBP        int64      `json:"changeBP"`           // basis points, for KindChange
+	CantorID        int        `json:"cantorID,omitempty"` // 0 watches every cantor
+	Lat             float64    `json:"lat,omitempty"`
+	Lon             float64    `json:"lon,omitempty"`
+	RadiusKm        float64    `json:"radiusKm,omitempty"` // 0 watches every area
+	CooldownSeconds int        `json:"cooldownSeconds"`
+	CreatedAt       time.Time  `json:"createdAt"`
+}
+
+// Validate checks that the rule is complete and fills in defaults.
+func (r *Rule) Validate() error {
+	if r.Owner == "" || r.Currency == "" {
+		return fmt.Errorf("owner and currency are required")
+	}
+	if r.Side == "" {
+		r.Side = SideBuy
+	}
+	if r.Side != SideBuy && r.Side != SideSell {
+		return fmt.Errorf("side must be %q or %q", SideBuy, SideSell)
+	}
+	switch r.Kind {
+	case KindAbove, KindBelow:
+		if r.Threshold.Sign() <= 0 {
+			return fmt.Errorf("threshold must be positive")
+		}
+	case KindChange:
+		if r.ChangeBP <= 0 {
+			return fmt.Errorf("changeBP must be positive")
+		}
+	default:
+		return fmt.Errorf("kind must be %q, %q or %q", KindAbove, KindBelow, KindChange)
+	}
+	if r.RadiusKm < 0 || (r.RadiusKm > 0 && !geo.ValidCoordinates(r.Lat, r.Lon)) {
+		return fmt.Errorf("invalid area")
+	}
+	if r.CooldownSeconds < 0 {
+		return fmt.Errorf("cooldownSeconds must not be negative")
+	}
+	if r.CooldownSeconds == 0 {
+		r.CooldownSeconds = int(defaultCooldown / time.Second)
+	}
+	return nil
+}
+
+// Location is a cantor's position used for area rules.
+type Location struct {
+	Lat, Lon float64
+}
+
+// Observation is a freshly published rate.
+type Observation struct {
+	CantorID int
+	Currency string
+	Buy      money.Rate
+	Sell     money.Rate
+	At       time.Time
+}
+
+// Event is a fired rule.
+type Event struct {
+	ID        int64      `json:"id"`
+	RuleID    int        `json:"ruleID"`
+	Owner     string     `json:"owner"`
+	CantorID  int        `json:"cantorID"`
+	Currency  string     `json:"currency"`
+	Side      string     `json:"side"`
+	Kind      string     `json:"kind"`
+	Rate      money.Rate `json:"rate"`
+	Reference money.Rate `json:"reference"` // threshold, or the value the change was measured from
+	FiredAt   time.Time  `json:"firedAt"`
+}
+
+type stateKey struct {
+	ruleID   int
+	cantorID int
+}
+
+// ruleState tracks one rule against one cantor so that a rate hovering around a threshold fires once.
+type ruleState struct {
+	active    bool       // condition held on the previous observation
+	reference money.Rate // baseline for KindChange
+	lastFired time.Time
+}
+
+// Engine evaluates rules against published rates. Rules are indexed by currency, so an
+// observation only visits the rules that watch its currency.
+type Engine struct {
+	mu        sync.Mutex
+	rules     map[string][]Rule
+	locations map[int]Location
+	states    map[stateKey]*ruleState
+}
+
+// NewEngine creates an engine without rules.
+func NewEngine() *Engine {
+	return &Engine{
+		rules:     make(map[string][]Rule),
+		locations: make(map[int]Location),
+		states:    make(map[stateKey]*ruleState),
+	}
+}
+
+// Replace swaps the rule set and cantor locations. Debounce state of rules that still exist is kept.
+func (e *Engine) Replace(rules []Rule, locations map[int]Location) {
+	byCurrency := make(map[string][]Rule)
+	ids := make(map[int]bool, len(rules))
+	for _, r := range rules {
+		byCurrency[r.Currency] = append(byCurrency[r.Currency], r)
+		ids[r.ID] = true
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.rules = byCurrency
+	e.locations = locations
+	for key := range e.states {
+		if !ids[key.ruleID] {
+			delete(e.states, key)
+		}
+	}
+}
+
+// RuleCount returns the number of loaded rules.
+func (e *Engine) RuleCount() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	n := 0
+	for _, rules := range e.rules {
+		n += len(rules)
+	}
+	return n
+}
+
+// Evaluate checks an observation against the rules for its currency and returns the events that fire.
+// Threshold rules fire on the transition into the condition; every rule is silent for its cooldown after firing.
+// A transition is only recorded when it fires, so a condition entered during the cooldown fires once the
+// cooldown is over if it still holds.
+func (e *Engine) Evaluate(obs Observation) []Event {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var events []Event
+	for _, rule := range e.rules[obs.Currency] {
+		if !e.matchesScope(rule, obs.CantorID) {
+			continue
+		}
+
+		rate := obs.Buy
+		if rule.Side == SideSell {
+			rate = obs.Sell
+		}
+		if rate.Sign() <= 0 {
+			continue
+		}
+
+		key := stateKey{ruleID: rule.ID, cantorID: obs.CantorID}
+		st, ok := e.states[key]
+		if !ok {
+			st = &ruleState{}
+			e.states[key] = st
+		}
+
+		cooledDown := obs.At.Sub(st.lastFired) >= time.Duration(rule.CooldownSeconds)*time.Second
+		reference, fire := evaluateRule(rule, st, rate)
+		if fire && cooledDown {
+			st.lastFired = obs.At
+			if rule.Kind == KindChange {
+				st.reference = rate
+			} else {
+				st.active = true
+			}
+			events = append(events, Event{
+				RuleID:    rule.ID,
+				Owner:     rule.Owner,
+				CantorID:  obs.CantorID,
+				Currency:  obs.Currency,
+				Side:      rule.Side,
+				Kind:      rule.Kind,
+				Rate:      rate,
+				Reference: reference,
+				FiredAt:   obs.At,
+			})
+		}
+	}
+	return events
+}
+
+// evaluateRule updates st with rate and reports whether the rule's condition newly holds. Entering a
+// threshold condition is left for Evaluate to record when the rule fires.
+func evaluateRule(rule Rule, st *ruleState, rate money.Rate) (money.Rate, bool) {
+	switch rule.Kind {
+	case KindChange:
+		if st.reference.IsZero() {
+			st.reference = rate
+			return money.Zero, false
+		}
+		move := rate.ChangeBasisPoints(st.reference)
+		return st.reference, move >= rule.ChangeBP || -move >= rule.ChangeBP
+	default:
+		holds := rate.Cmp(rule.Threshold) >= 0
+		if rule.Kind == KindBelow {
+			holds = rate.Cmp(rule.Threshold) <= 0
+		}
+		if !holds {
+			st.active = false
+		}
+		return rule.Threshold, holds && !st.active
+	}
+}
+
+func (e *Engine) matchesScope(rule Rule, cantorID int) bool {
+	if rule.CantorID != 0 && rule.CantorID != cantorID {
+		return false
+	}
+	if rule.RadiusKm <= 0 {
+		return true
+	}
+	loc, ok := e.locations[cantorID]
+	if !ok || (loc.Lat == 0 && loc.Lon == 0) {
+		return false
+	}
+	return geo.DistanceKm(rule.Lat, rule.Lon, loc.Lat, loc.Lon) <= rule.RadiusKm
+}
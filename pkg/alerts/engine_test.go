@@ -0,0 +1,105 @@
+package alerts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Niutaq/Gix/pkg/money"
+)
+
+func observe(e *Engine, cantorID int, buy string, at time.Time) []Event {
+	return e.Evaluate(Observation{CantorID: cantorID, Currency: "EUR", Buy: money.MustParse(buy), Sell: money.MustParse("9"), At: at})
+}
+
+// TestEngine_ThresholdDebounce checks that a flapping rate fires once per crossing and respects the cooldown
+func TestEngine_ThresholdDebounce(t *testing.T) {
+	e := NewEngine()
+	e.Replace([]Rule{{ID: 1, Owner: "u", Currency: "EUR", Side: SideBuy, Kind: KindAbove, Threshold: money.MustParse("4.30"), CooldownSeconds: 600}}, nil)
+
+	start := time.Now()
+	if got := observe(e, 7, "4.29", start); len(got) != 0 {
+		t.Fatalf("below threshold fired: %v", got)
+	}
+	if got := observe(e, 7, "4.31", start.Add(time.Minute)); len(got) != 1 || got[0].Reference != money.MustParse("4.30") {
+		t.Fatalf("crossing should fire once, got %v", got)
+	}
+	if got := observe(e, 7, "4.32", start.Add(2*time.Minute)); len(got) != 0 {
+		t.Errorf("staying above fired again: %v", got)
+	}
+	observe(e, 7, "4.29", start.Add(3*time.Minute))
+	if got := observe(e, 7, "4.31", start.Add(4*time.Minute)); len(got) != 0 {
+		t.Errorf("re-crossing within cooldown fired: %v", got)
+	}
+	observe(e, 7, "4.29", start.Add(20*time.Minute))
+	if got := observe(e, 7, "4.31", start.Add(21*time.Minute)); len(got) != 1 {
+		t.Errorf("re-crossing after cooldown should fire, got %v", got)
+	}
+}
+
+// TestEngine_CrossingDuringCooldown checks that a condition entered during the cooldown fires once it ends
+func TestEngine_CrossingDuringCooldown(t *testing.T) {
+	e := NewEngine()
+	e.Replace([]Rule{{ID: 1, Owner: "u", Currency: "EUR", Side: SideBuy, Kind: KindAbove, Threshold: money.MustParse("4.30"), CooldownSeconds: 600}}, nil)
+
+	start := time.Now()
+	observe(e, 7, "4.29", start)
+	if got := observe(e, 7, "4.31", start.Add(time.Minute)); len(got) != 1 {
+		t.Fatalf("crossing should fire, got %v", got)
+	}
+	observe(e, 7, "4.29", start.Add(2*time.Minute))
+	if got := observe(e, 7, "4.33", start.Add(3*time.Minute)); len(got) != 0 {
+		t.Errorf("crossing within cooldown fired: %v", got)
+	}
+	if got := observe(e, 7, "4.34", start.Add(11*time.Minute)); len(got) != 1 || got[0].Rate != money.MustParse("4.34") {
+		t.Errorf("condition still holding after cooldown should fire, got %v", got)
+	}
+	if got := observe(e, 7, "4.35", start.Add(30*time.Minute)); len(got) != 0 {
+		t.Errorf("staying above fired again: %v", got)
+	}
+}
+
+// TestEngine_ChangeAndScope checks percent-move rules, the cantor filter and the area filter
+func TestEngine_ChangeAndScope(t *testing.T) {
+	e := NewEngine()
+	e.Replace([]Rule{
+		{ID: 1, Owner: "u", Currency: "EUR", Side: SideBuy, Kind: KindChange, ChangeBP: 100, CantorID: 7},
+		{ID: 2, Owner: "u", Currency: "EUR", Side: SideBuy, Kind: KindBelow, Threshold: money.MustParse("4.00"),
+			Lat: 52.23, Lon: 21.01, RadiusKm: 10},
+	}, map[int]Location{7: {Lat: 52.24, Lon: 21.02}, 8: {Lat: 50.06, Lon: 19.94}})
+
+	start := time.Now()
+	observe(e, 7, "4.30", start)
+	if got := observe(e, 7, "4.33", start.Add(time.Minute)); len(got) != 0 {
+		t.Errorf("0.7%% move fired: %v", got)
+	}
+	if got := observe(e, 7, "4.35", start.Add(2*time.Minute)); len(got) != 1 || got[0].RuleID != 1 {
+		t.Errorf("1.16%% move should fire rule 1, got %v", got)
+	}
+	if got := observe(e, 8, "3.00", start); len(got) != 0 {
+		t.Errorf("cantor outside area fired: %v", got)
+	}
+	if got := observe(e, 7, "3.99", start.Add(3*time.Minute)); len(got) != 2 {
+		t.Errorf("cantor inside area should fire area rule and change rule, got %v", got)
+	}
+	if n := e.RuleCount(); n != 2 {
+		t.Errorf("RuleCount = %d, want 2", n)
+	}
+}
+
+// TestRule_Validate checks defaults and rejection of incomplete rules
+func TestRule_Validate(t *testing.T) {
+	r := Rule{Owner: "u", Currency: "EUR", Kind: KindAbove, Threshold: money.MustParse("4.3")}
+	if err := r.Validate(); err != nil || r.Side != SideBuy || r.CooldownSeconds != 3600 {
+		t.Errorf("Validate = %v, rule %+v", err, r)
+	}
+	for _, bad := range []Rule{
+		{Owner: "u", Currency: "EUR", Kind: KindAbove},
+		{Owner: "u", Currency: "EUR", Kind: "sideways", Threshold: money.MustParse("1")},
+		{Owner: "u", Currency: "EUR", Kind: KindChange},
+		{Owner: "u", Currency: "EUR", Kind: KindBelow, Threshold: money.MustParse("1"), RadiusKm: 5, Lat: 95},
+	} {
+		if err := bad.Validate(); err == nil {
+			t.Errorf("Validate(%+v) expected error", bad)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestToken_RoundTrip checks issuing and verifying a JWT
+func TestToken_RoundTrip(t *testing.T) {
+	secret := []byte("test-secret")
+	now := time.Now()
+
+	token, err := IssueToken(secret, "ci", RoleOperator, time.Hour, now)
+	if err != nil {
+		t.Fatalf("IssueToken: %v", err)
+	}
+	claims, err := ParseToken(secret, token, now.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("ParseToken: %v", err)
+	}
+	if claims.Subject != "ci" || claims.Role != RoleOperator {
+		t.Errorf("claims = %+v", claims)
+	}
+}
+
+// TestToken_Rejections checks wrong secrets, tampering, expiry and the "none" algorithm
+func TestToken_Rejections(t *testing.T) {
+	secret := []byte("test-secret")
+	now := time.Now()
+	token, _ := IssueToken(secret, "ci", RoleViewer, time.Hour, now)
+
+	if _, err := ParseToken([]byte("other"), token, now); err != ErrInvalidCredential {
+		t.Errorf("wrong secret: err = %v", err)
+	}
+	if _, err := ParseToken(secret, token, now.Add(2*time.Hour)); err != ErrExpiredToken {
+		t.Errorf("expired token: err = %v", err)
+	}
+
+	// Swap the payload for one granting admin, keeping the original signature.
+	admin, _ := IssueToken(secret, "ci", RoleAdmin, time.Hour, now)
+	parts, adminParts := strings.Split(token, "."), strings.Split(admin, ".")
+	forged := parts[0] + "." + adminParts[1] + "." + parts[2]
+	if _, err := ParseToken(secret, forged, now); err != ErrInvalidCredential {
+		t.Errorf("forged payload: err = %v", err)
+	}
+
+	none := "eyJhbGciOiJub25lIiwidHlwIjoiSldUIn0." + parts[1] + "."
+	if _, err := ParseToken(secret, none, now); err != ErrInvalidCredential {
+		t.Errorf("alg none: err = %v", err)
+	}
+}
+
+// TestRole_Allows checks the role hierarchy
+func TestRole_Allows(t *testing.T) {
+	if !RoleAdmin.Allows(RoleOperator) || !RoleOperator.Allows(RoleViewer) || !RoleViewer.Allows(RoleViewer) {
+		t.Errorf("higher roles should include lower ones")
+	}
+	if RoleViewer.Allows(RoleOperator) || Role("root").Allows(RoleViewer) {
+		t.Errorf("lower or unknown roles must not be granted more")
+	}
+	if _, err := ParseRole("superuser"); err == nil {
+		t.Errorf("ParseRole accepted an unknown role")
+	}
+}
+
+// TestGenerateAPIKey checks the key format and that the stored hash matches
+func TestGenerateAPIKey(t *testing.T) {
+	key, prefix, hash, err := GenerateAPIKey()
+	if err != nil {
+		t.Fatalf("GenerateAPIKey: %v", err)
+	}
+	if !IsAPIKey(key) || !strings.HasPrefix(key, prefix+"_") {
+		t.Errorf("key %q does not start with prefix %q", key, prefix)
+	}
+	if HashAPIKey(key) != hash || len(hash) != 64 {
+		t.Errorf("hash mismatch")
+	}
+	if BearerToken("Bearer "+key) != key || BearerToken("Basic abc") != "" {
+		t.Errorf("BearerToken parsing failed")
+	}
+}
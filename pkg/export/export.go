@@ -0,0 +1,211 @@
+package export
+
+import (
+	// Standard libraries
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	// External utilities
+	"github.com/Niutaq/Gix/pkg/money"
+	"github.com/parquet-go/parquet-go"
+)
+
+// Output formats.
+const (
+	FormatCSV     = "csv"
+	FormatNDJSON  = "ndjson"
+	FormatParquet = "parquet"
+)
+
+// parquetRowGroup is the number of rows buffered before a Parquet row group is written out.
+const parquetRowGroup = 50_000
+
+// Row is one exported rate: a raw change point or a bucket of one cantor. The candles are only set
+// for OHLC exports.
+type Row struct {
+	Time     time.Time  `json:"time"`
+	CantorID int        `json:"cantorID"`
+	Currency string     `json:"currency"`
+	Buy      money.Rate `json:"buy"`
+	Sell     money.Rate `json:"sell"`
+	BuyOHLC  *Candle    `json:"buyOhlc,omitempty"`
+	SellOHLC *Candle    `json:"sellOhlc,omitempty"`
+}
+
+// Candle is the open, high, low and close of a rate within one bucket.
+type Candle struct {
+	Open  money.Rate `json:"open"`
+	High  money.Rate `json:"high"`
+	Low   money.Rate `json:"low"`
+	Close money.Rate `json:"close"`
+}
+
+// Writer encodes rows to an output stream. Close flushes buffered data and writes any footer; it
+// does not close the underlying io.Writer.
+type Writer interface {
+	Write(Row) error
+	Close() error
+}
+
+// ParseFormat validates an output format, defaulting to CSV.
+func ParseFormat(format string) (string, error) {
+	switch format {
+	case "":
+		return FormatCSV, nil
+	case FormatCSV, FormatNDJSON, FormatParquet:
+		return format, nil
+	}
+	return "", fmt.Errorf("unsupported format %q (use csv, ndjson or parquet)", format)
+}
+
+// ContentType returns the MIME type of a format.
+func ContentType(format string) string {
+	switch format {
+	case FormatNDJSON:
+		return "application/x-ndjson"
+	case FormatParquet:
+		return "application/vnd.apache.parquet"
+	default:
+		return "text/csv; charset=utf-8"
+	}
+}
+
+// NewWriter returns a Writer for the format. With ohlc set, CSV output gets open/high/low/close
+// columns for buy and sell.
+func NewWriter(w io.Writer, format string, ohlc bool) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w, ohlc), nil
+	case FormatNDJSON:
+		return &ndjsonWriter{enc: json.NewEncoder(w)}, nil
+	case FormatParquet:
+		return newParquetWriter(w), nil
+	}
+	return nil, fmt.Errorf("unsupported format %q", format)
+}
+
+type csvWriter struct {
+	w      *csv.Writer
+	ohlc   bool
+	header bool
+	record []string
+}
+
+func newCSVWriter(w io.Writer, ohlc bool) *csvWriter {
+	return &csvWriter{w: csv.NewWriter(w), ohlc: ohlc}
+}
+
+func (cw *csvWriter) Write(r Row) error {
+	if !cw.header {
+		cw.header = true
+		header := []string{"time", "cantor_id", "currency", "buy", "sell"}
+		if cw.ohlc {
+			header = append(header, "buy_open", "buy_high", "buy_low", "buy_close",
+				"sell_open", "sell_high", "sell_low", "sell_close")
+		}
+		if err := cw.w.Write(header); err != nil {
+			return err
+		}
+	}
+
+	cw.record = append(cw.record[:0], r.Time.UTC().Format(time.RFC3339), strconv.Itoa(r.CantorID), r.Currency,
+		r.Buy.String(), r.Sell.String())
+	if cw.ohlc {
+		cw.record = appendCandle(cw.record, r.BuyOHLC)
+		cw.record = appendCandle(cw.record, r.SellOHLC)
+	}
+	return cw.w.Write(cw.record)
+}
+
+func appendCandle(record []string, c *Candle) []string {
+	if c == nil {
+		return append(record, "", "", "", "")
+	}
+	return append(record, c.Open.String(), c.High.String(), c.Low.String(), c.Close.String())
+}
+
+func (cw *csvWriter) Close() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+type ndjsonWriter struct {
+	enc *json.Encoder
+}
+
+func (nw *ndjsonWriter) Write(r Row) error {
+	return nw.enc.Encode(r)
+}
+
+func (nw *ndjsonWriter) Close() error {
+	return nil
+}
+
+// parquetRow is the Parquet schema. Rates are stored as exact decimals in money.Scale places.
+type parquetRow struct {
+	Time      time.Time `parquet:"time,timestamp(millisecond)"`
+	CantorID  int32     `parquet:"cantor_id"`
+	Currency  string    `parquet:"currency,dict"`
+	Buy       int64     `parquet:"buy,decimal(8:18)"`
+	Sell      int64     `parquet:"sell,decimal(8:18)"`
+	BuyOpen   *int64    `parquet:"buy_open,optional,decimal(8:18)"`
+	BuyHigh   *int64    `parquet:"buy_high,optional,decimal(8:18)"`
+	BuyLow    *int64    `parquet:"buy_low,optional,decimal(8:18)"`
+	BuyClose  *int64    `parquet:"buy_close,optional,decimal(8:18)"`
+	SellOpen  *int64    `parquet:"sell_open,optional,decimal(8:18)"`
+	SellHigh  *int64    `parquet:"sell_high,optional,decimal(8:18)"`
+	SellLow   *int64    `parquet:"sell_low,optional,decimal(8:18)"`
+	SellClose *int64    `parquet:"sell_close,optional,decimal(8:18)"`
+}
+
+type parquetWriter struct {
+	w       *parquet.GenericWriter[parquetRow]
+	pending int
+	row     [1]parquetRow
+}
+
+// newParquetWriter writes zstd-compressed Parquet. The candle columns are optional and stay null
+// unless a row carries candles.
+func newParquetWriter(w io.Writer) *parquetWriter {
+	return &parquetWriter{w: parquet.NewGenericWriter[parquetRow](w, parquet.Compression(&parquet.Zstd))}
+}
+
+func (pw *parquetWriter) Write(r Row) error {
+	row := parquetRow{
+		Time:     r.Time,
+		CantorID: int32(r.CantorID),
+		Currency: r.Currency,
+		Buy:      r.Buy.Scaled(money.Scale),
+		Sell:     r.Sell.Scaled(money.Scale),
+	}
+	if c := r.BuyOHLC; c != nil {
+		row.BuyOpen, row.BuyHigh, row.BuyLow, row.BuyClose = scaled(c.Open), scaled(c.High), scaled(c.Low), scaled(c.Close)
+	}
+	if c := r.SellOHLC; c != nil {
+		row.SellOpen, row.SellHigh, row.SellLow, row.SellClose = scaled(c.Open), scaled(c.High), scaled(c.Low), scaled(c.Close)
+	}
+
+	pw.row[0] = row
+	if _, err := pw.w.Write(pw.row[:]); err != nil {
+		return err
+	}
+	pw.pending++
+	if pw.pending >= parquetRowGroup {
+		pw.pending = 0
+		return pw.w.Flush()
+	}
+	return nil
+}
+
+func scaled(r money.Rate) *int64 {
+	v := r.Scaled(money.Scale)
+	return &v
+}
+
+func (pw *parquetWriter) Close() error {
+	return pw.w.Close()
+}
@@ -0,0 +1,72 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Niutaq/Gix/pkg/money"
+	"github.com/parquet-go/parquet-go"
+)
+
+func testRows() []Row {
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	return []Row{
+		{Time: at, CantorID: 1, Currency: "EUR", Buy: money.MustParse("4.255"), Sell: money.MustParse("4.30")},
+		{Time: at.Add(time.Hour), CantorID: 2, Currency: "EUR", Buy: money.MustParse("4.26"), Sell: money.MustParse("4.31"),
+			BuyOHLC: &Candle{Open: money.MustParse("4.25"), High: money.MustParse("4.27"), Low: money.MustParse("4.24"), Close: money.MustParse("4.26")}},
+	}
+}
+
+func write(t *testing.T, format string, ohlc bool) string {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, format, ohlc)
+	if err != nil {
+		t.Fatalf("NewWriter(%s) = %v", format, err)
+	}
+	for _, r := range testRows() {
+		if err := w.Write(r); err != nil {
+			t.Fatalf("%s write: %v", format, err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("%s close: %v", format, err)
+	}
+	return buf.String()
+}
+
+func TestCSV(t *testing.T) {
+	lines := strings.Split(strings.TrimSpace(write(t, FormatCSV, true)), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "time,cantor_id,currency,buy,sell,buy_open") {
+		t.Fatalf("csv = %q", lines)
+	}
+	if lines[1] != "2026-03-01T12:00:00Z,1,EUR,4.2550,4.3000,,,,,,,," {
+		t.Errorf("row without candles = %q", lines[1])
+	}
+	if !strings.Contains(lines[2], ",4.2500,4.2700,4.2400,4.2600,") {
+		t.Errorf("row with a buy candle = %q", lines[2])
+	}
+}
+
+func TestNDJSON(t *testing.T) {
+	lines := strings.Split(strings.TrimSpace(write(t, FormatNDJSON, false)), "\n")
+	if len(lines) != 2 || lines[0] != `{"time":"2026-03-01T12:00:00Z","cantorID":1,"currency":"EUR","buy":"4.2550","sell":"4.3000"}` {
+		t.Errorf("ndjson = %q", lines)
+	}
+}
+
+func TestParquet(t *testing.T) {
+	data := write(t, FormatParquet, true)
+	rows, err := parquet.Read[parquetRow](strings.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("read back: %v", err)
+	}
+	if len(rows) != 2 || rows[0].Buy != 425_500_000 || rows[0].BuyOpen != nil || rows[1].BuyHigh == nil || *rows[1].BuyHigh != 427_000_000 {
+		t.Errorf("rows = %+v", rows)
+	}
+	if !rows[1].Time.Equal(testRows()[1].Time) || rows[1].Currency != "EUR" {
+		t.Errorf("row 1 = %+v", rows[1])
+	}
+}
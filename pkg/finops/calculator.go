@@ -40,18 +40,31 @@ func (s *GlobalStats) Record(cantorID string, duration time.Duration) {
 	}
 }
 
-// GetSummary returns a summary of the statistics.
-func (s *GlobalStats) GetSummary() map[string]any {
+// StatsSnapshot is a copy of the statistics taken under the lock.
+type StatsSnapshot struct {
+	TotalScrapes   int
+	AvgDuration    time.Duration
+	ExpensiveTasks []string
+}
+
+// Snapshot returns a copy of the statistics that is safe to read while scrapes are recorded.
+func (s *GlobalStats) Snapshot() StatsSnapshot {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	avg := 0.0
+	snap := StatsSnapshot{TotalScrapes: s.TotalScrapes, ExpensiveTasks: append([]string(nil), s.ExpensiveTasks...)}
 	if s.TotalScrapes > 0 {
-		avg = s.TotalDuration.Seconds() / float64(s.TotalScrapes)
+		snap.AvgDuration = s.TotalDuration / time.Duration(s.TotalScrapes)
 	}
+	return snap
+}
+
+// GetSummary returns a summary of the statistics.
+func (s *GlobalStats) GetSummary() map[string]any {
+	snap := s.Snapshot()
 	return map[string]any{
-		"total_scrapes":    s.TotalScrapes,
-		"avg_duration_sec": fmt.Sprintf("%.2fs", avg),
-		"expensive_tasks":  s.ExpensiveTasks,
+		"total_scrapes":    snap.TotalScrapes,
+		"avg_duration_sec": fmt.Sprintf("%.2fs", snap.AvgDuration.Seconds()),
+		"expensive_tasks":  snap.ExpensiveTasks,
 	}
 }
 
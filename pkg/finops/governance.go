@@ -14,6 +14,7 @@ type GovernanceEngine struct {
 	mu        sync.RWMutex
 	blocked   map[string]bool // map of ProviderID -> blocked status
 	limitUSD  float64         // Maximum allowed spend per provider per 24h
+	onTrip    func(providerID string, spendUSD, limitUSD float64)
 }
 
 // NewGovernanceEngine creates a new FinOps guardrail system.
@@ -45,6 +46,14 @@ func (g *GovernanceEngine) GetBlockedList() []string {
 	return list
 }
 
+// OnTrip registers a callback invoked whenever a provider's circuit breaker trips.
+// It fires once per transition from allowed to blocked, not on every evaluation.
+func (g *GovernanceEngine) OnTrip(fn func(providerID string, spendUSD, limitUSD float64)) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.onTrip = fn
+}
+
 // StartMonitor begins a background routine that periodically evaluates burn rates.
 func (g *GovernanceEngine) StartMonitor(ctx context.Context, interval time.Duration) {
 	ticker := time.NewTicker(interval)
@@ -84,6 +93,7 @@ func (g *GovernanceEngine) evaluateGuardrails() {
 	defer rows.Close()
 
 	newBlocked := make(map[string]bool)
+	spends := make(map[string]float64)
 	var blockedCount int
 
 	for rows.Next() {
@@ -91,15 +101,26 @@ func (g *GovernanceEngine) evaluateGuardrails() {
 		var spend float64
 		if err := rows.Scan(&providerID, &spend); err == nil {
 			newBlocked[providerID] = true
+			spends[providerID] = spend
 			blockedCount++
 			log.Printf("[FinOps Guardrail] ALARM: Provider %s exceeded daily budget ($%.4f). Circuit Breaker TRIPPED.", providerID, spend)
 		}
 	}
 
 	g.mu.Lock()
+	previous := g.blocked
 	g.blocked = newBlocked
+	onTrip := g.onTrip
 	g.mu.Unlock()
 
+	if onTrip != nil {
+		for providerID := range newBlocked {
+			if !previous[providerID] {
+				onTrip(providerID, spends[providerID], g.limitUSD)
+			}
+		}
+	}
+
 	if blockedCount > 0 {
 		log.Printf("[FinOps Governance] Refreshed guardrails. %d providers are currently throttled.", blockedCount)
 	}
@@ -0,0 +1,333 @@
+package money
+
+import (
+	// Standard libraries
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	// External utilities
+	pb "github.com/Niutaq/Gix/api/proto/v1"
+)
+
+// Scale is the number of decimal places a Rate keeps. Eight places hold a 4-decimal quote
+// divided by up to 10 000 units without losing digits.
+const Scale = 8
+
+const scaleFactor int64 = 100_000_000
+
+// MaxScale is the largest number of decimal places accepted by FromScaled; 10^18 is the largest
+// power of ten an int64 holds.
+const MaxScale = 18
+
+// ErrOutOfRange is returned when a scaled decimal has an unsupported scale or does not fit a Rate.
+var ErrOutOfRange = errors.New("decimal out of range")
+
+// Rate is an exact fixed-point decimal exchange rate stored as an integer number of 10^-Scale units.
+type Rate struct {
+	v int64
+}
+
+// Zero is the zero-valued Rate.
+var Zero = Rate{}
+
+// Parse converts a decimal string such as "4.2550" or "4,255" into a Rate.
+// Digits beyond Scale are rounded half away from zero.
+func Parse(raw string) (Rate, error) {
+	s := strings.TrimSpace(strings.ReplaceAll(raw, ",", "."))
+	if s == "" {
+		return Zero, fmt.Errorf("empty rate")
+	}
+
+	negative := false
+	if s[0] == '-' || s[0] == '+' {
+		negative = s[0] == '-'
+		s = s[1:]
+	}
+
+	intPart, fracPart, _ := strings.Cut(s, ".")
+	if intPart == "" && fracPart == "" {
+		return Zero, fmt.Errorf("invalid rate: %q", raw)
+	}
+	if intPart == "" {
+		intPart = "0"
+	}
+
+	whole, err := strconv.ParseInt(intPart, 10, 64)
+	if err != nil || strings.ContainsAny(intPart, "+-") {
+		return Zero, fmt.Errorf("invalid rate: %q", raw)
+	}
+	if whole > math.MaxInt64/scaleFactor-1 {
+		return Zero, fmt.Errorf("rate out of range: %q", raw)
+	}
+
+	var frac int64
+	roundUp := false
+	for i, r := range fracPart {
+		if r < '0' || r > '9' {
+			return Zero, fmt.Errorf("invalid rate: %q", raw)
+		}
+		if i < Scale {
+			frac = frac*10 + int64(r-'0')
+		} else if i == Scale {
+			roundUp = r >= '5'
+		}
+	}
+	for i := len(fracPart); i < Scale; i++ {
+		frac *= 10
+	}
+
+	v := whole*scaleFactor + frac
+	if roundUp {
+		v++
+	}
+	if negative {
+		v = -v
+	}
+	return Rate{v: v}, nil
+}
+
+// MustParse is like Parse but panics on invalid input. Intended for constants and tests.
+func MustParse(raw string) Rate {
+	r, err := Parse(raw)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// FromFloat converts a float64 into a Rate, rounding to Scale decimal places.
+func FromFloat(f float64) Rate {
+	return Rate{v: int64(math.Round(f * float64(scaleFactor)))}
+}
+
+// FromScaled builds a Rate from an integer value with the given number of decimal places. It fails
+// with ErrOutOfRange when scale is outside 0..MaxScale or the value overflows at Scale places.
+func FromScaled(value int64, scale int32) (Rate, error) {
+	if scale < 0 || scale > MaxScale {
+		return Zero, fmt.Errorf("%w: scale %d", ErrOutOfRange, scale)
+	}
+	if scale < Scale {
+		limit := math.MaxInt64 / pow10(Scale-scale)
+		if value > limit || value < -limit {
+			return Zero, fmt.Errorf("%w: %d at scale %d", ErrOutOfRange, value, scale)
+		}
+	}
+	return Rate{v: rescale(value, scale, Scale)}, nil
+}
+
+// Scaled returns the rate as an integer with the given number of decimal places, rounding half away from zero.
+func (r Rate) Scaled(scale int32) int64 {
+	return rescale(r.v, Scale, scale)
+}
+
+// DivUnits converts a rate quoted per `units` currency units into a per-unit rate.
+func (r Rate) DivUnits(units int) Rate {
+	if units <= 1 {
+		return r
+	}
+	return Rate{v: divRound(r.v, int64(units))}
+}
+
+// Add returns r + o.
+func (r Rate) Add(o Rate) Rate {
+	return Rate{v: r.v + o.v}
+}
+
+// Sub returns r - o.
+func (r Rate) Sub(o Rate) Rate {
+	return Rate{v: r.v - o.v}
+}
+
+// Mul returns r * o rounded half away from zero to Scale places. It is used to turn a
+// per-unit rate and an amount of currency into a PLN amount. A product beyond the range of
+// Rate saturates at the largest or smallest representable value.
+func (r Rate) Mul(o Rate) Rate {
+	p := new(big.Int).Mul(big.NewInt(r.v), big.NewInt(o.v))
+	q, rem := new(big.Int).QuoRem(p, big.NewInt(scaleFactor), new(big.Int))
+	if new(big.Int).Abs(rem).Cmp(big.NewInt(scaleFactor/2)) >= 0 {
+		q.Add(q, big.NewInt(int64(p.Sign())))
+	}
+	if !q.IsInt64() {
+		if q.Sign() < 0 {
+			return Rate{v: math.MinInt64}
+		}
+		return Rate{v: math.MaxInt64}
+	}
+	return Rate{v: q.Int64()}
+}
+
+// Mid returns the midpoint between r and o.
+func (r Rate) Mid(o Rate) Rate {
+	return Rate{v: divRound(r.v+o.v, 2)}
+}
+
+// Cmp compares r and o and returns -1, 0 or +1.
+func (r Rate) Cmp(o Rate) int {
+	switch {
+	case r.v < o.v:
+		return -1
+	case r.v > o.v:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// IsZero reports whether the rate is exactly zero.
+func (r Rate) IsZero() bool {
+	return r.v == 0
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of r.
+func (r Rate) Sign() int {
+	return r.Cmp(Zero)
+}
+
+// Float64 returns the nearest float64 value. Use only for display and statistics.
+func (r Rate) Float64() float64 {
+	return float64(r.v) / float64(scaleFactor)
+}
+
+// ChangeBasisPoints returns the relative change from prev to r in basis points (1/100 of a percent).
+func (r Rate) ChangeBasisPoints(prev Rate) int64 {
+	if prev.v == 0 {
+		return 0
+	}
+	return divRound((r.v-prev.v)*10000, prev.v)
+}
+
+// StringFixed formats the rate with exactly the given number of decimal places.
+func (r Rate) StringFixed(places int32) string {
+	if places <= 0 {
+		return strconv.FormatInt(r.Scaled(0), 10)
+	}
+	v := r.Scaled(places)
+	sign := ""
+	if v < 0 {
+		sign = "-"
+		v = -v
+	}
+	digits := fmt.Sprintf("%0*d", places+1, v)
+	cut := len(digits) - int(places)
+	return sign + digits[:cut] + "." + digits[cut:]
+}
+
+// String formats the rate with at least 4 decimal places and without trailing zeros beyond that.
+func (r Rate) String() string {
+	s := r.StringFixed(Scale)
+	minLen := len(s) - Scale + 4
+	for len(s) > minLen && s[len(s)-1] == '0' {
+		s = s[:len(s)-1]
+	}
+	return s
+}
+
+// Proto converts the rate into its protobuf representation.
+func (r Rate) Proto() *pb.Decimal {
+	return &pb.Decimal{Value: r.v, Scale: Scale}
+}
+
+// FromProto converts a protobuf decimal into a Rate. A nil message yields Zero; a decimal that
+// FromScaled rejects yields ErrOutOfRange.
+func FromProto(d *pb.Decimal) (Rate, error) {
+	if d == nil {
+		return Zero, nil
+	}
+	return FromScaled(d.Value, d.Scale)
+}
+
+// Scan implements sql.Scanner so NUMERIC columns can be read without going through float64.
+func (r *Rate) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*r = Zero
+		return nil
+	case string:
+		parsed, err := Parse(v)
+		if err != nil {
+			return err
+		}
+		*r = parsed
+		return nil
+	case []byte:
+		return r.Scan(string(v))
+	case float64:
+		*r = FromFloat(v)
+		return nil
+	case int64:
+		*r = Rate{v: v * scaleFactor}
+		return nil
+	default:
+		return fmt.Errorf("cannot scan %T into money.Rate", src)
+	}
+}
+
+// Value implements driver.Valuer, sending the rate as an exact decimal string.
+func (r Rate) Value() (driver.Value, error) {
+	return r.StringFixed(Scale), nil
+}
+
+// MarshalText implements encoding.TextMarshaler, so JSON carries the rate as an exact decimal string.
+func (r Rate) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (r *Rate) UnmarshalText(text []byte) error {
+	parsed, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*r = parsed
+	return nil
+}
+
+// rescale moves value from one number of decimal places to another, rounding half away from zero.
+func rescale(value int64, from, to int32) int64 {
+	for from < to {
+		value *= 10
+		from++
+	}
+	if from > to {
+		div := int64(1)
+		for from > to {
+			div *= 10
+			from--
+		}
+		value = divRound(value, div)
+	}
+	return value
+}
+
+// pow10 returns 10^n for 0 <= n <= MaxScale.
+func pow10(n int32) int64 {
+	p := int64(1)
+	for ; n > 0; n-- {
+		p *= 10
+	}
+	return p
+}
+
+// divRound divides a by b rounding half away from zero.
+func divRound(a, b int64) int64 {
+	if b < 0 {
+		a, b = -a, -b
+	}
+	q, rem := a/b, a%b
+	if rem < 0 {
+		rem = -rem
+	}
+	if rem*2 >= b {
+		if a < 0 {
+			q--
+		} else {
+			q++
+		}
+	}
+	return q
+}
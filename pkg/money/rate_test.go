@@ -0,0 +1,154 @@
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+
+	pb "github.com/Niutaq/Gix/api/proto/v1"
+)
+
+// TestParse checks that decimal strings are converted without float rounding
+func TestParse(t *testing.T) {
+	cases := map[string]string{
+		"4.2550":       "4.2550",
+		"4,255":        "4.2550",
+		"0.011234":     "0.011234",
+		"1.123456789":  "1.12345679",
+		"12":           "12.0000",
+		".5":           "0.5000",
+		"-0.0015":      "-0.0015",
+		"3.999999995":  "4.0000",
+		"100.00000001": "100.00000001",
+	}
+	for in, want := range cases {
+		r, err := Parse(in)
+		if err != nil {
+			t.Fatalf("Parse(%q) returned error: %v", in, err)
+		}
+		if got := r.String(); got != want {
+			t.Errorf("Parse(%q) = %s, want %s", in, got, want)
+		}
+	}
+
+	for _, bad := range []string{"", "abc", "4.2.1", "4.2x", "."} {
+		if _, err := Parse(bad); err == nil {
+			t.Errorf("Parse(%q) expected error", bad)
+		}
+	}
+}
+
+// TestDivUnits checks that per-unit conversion keeps the 4th decimal of quotes per 100 units
+func TestDivUnits(t *testing.T) {
+	r := MustParse("1.1234").DivUnits(100)
+	if got := r.String(); got != "0.011234" {
+		t.Errorf("DivUnits(100) = %s, want 0.011234", got)
+	}
+	if got := MustParse("2").DivUnits(3).StringFixed(8); got != "0.66666667" {
+		t.Errorf("DivUnits(3) = %s, want 0.66666667", got)
+	}
+	if got := MustParse("4.2550").DivUnits(1); got != MustParse("4.2550") {
+		t.Errorf("DivUnits(1) changed the rate: %s", got)
+	}
+}
+
+// TestScaledAndProto checks round-trips through scaled integers and protobuf
+func TestScaledAndProto(t *testing.T) {
+	r := MustParse("4.2556")
+	if got := r.Scaled(3); got != 4256 {
+		t.Errorf("Scaled(3) = %d, want 4256", got)
+	}
+	if got, err := FromScaled(42556, 4); err != nil || got != r {
+		t.Errorf("FromScaled(42556, 4) = %s, %v, want %s", got, err, r)
+	}
+	if got, err := FromProto(r.Proto()); err != nil || got != r {
+		t.Errorf("proto round-trip = %s, %v, want %s", got, err, r)
+	}
+	if got, err := FromProto(nil); err != nil || !got.IsZero() {
+		t.Errorf("FromProto(nil) = %s, %v, want zero", got, err)
+	}
+}
+
+// TestFromScaledRange checks that client supplied scales and values cannot overflow or panic
+func TestFromScaledRange(t *testing.T) {
+	for _, d := range []*pb.Decimal{
+		{Value: 1000, Scale: 80},
+		{Value: 1, Scale: -1},
+		{Value: math.MaxInt64, Scale: 0},
+		{Value: math.MaxInt64/scaleFactor + 1, Scale: 0},
+		{Value: -(math.MaxInt64/10 + 1), Scale: 7},
+	} {
+		if _, err := FromProto(d); !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("FromProto(%v) error = %v, want ErrOutOfRange", d, err)
+		}
+	}
+	if got, err := FromScaled(1234567890123456789, MaxScale); err != nil || got.String() != "1.23456789" {
+		t.Errorf("FromScaled at MaxScale = %s, %v", got, err)
+	}
+	if got, err := FromScaled(math.MaxInt64/scaleFactor, 0); err != nil || got.Scaled(0) != math.MaxInt64/scaleFactor {
+		t.Errorf("FromScaled at the limit = %s, %v", got, err)
+	}
+}
+
+// TestChangeBasisPoints checks the 24h change calculation
+func TestChangeBasisPoints(t *testing.T) {
+	if got := MustParse("4.3000").ChangeBasisPoints(MustParse("4.2000")); got != 238 {
+		t.Errorf("ChangeBasisPoints = %d, want 238", got)
+	}
+	if got := MustParse("4.1000").ChangeBasisPoints(MustParse("4.2000")); got != -238 {
+		t.Errorf("ChangeBasisPoints = %d, want -238", got)
+	}
+	if got := MustParse("4.1000").ChangeBasisPoints(Zero); got != 0 {
+		t.Errorf("ChangeBasisPoints with zero base = %d, want 0", got)
+	}
+}
+
+// TestScan checks the sql.Scanner implementation for the value types pgx hands over
+func TestScan(t *testing.T) {
+	var r Rate
+	for _, src := range []any{"4.2550", []byte("4.2550"), 4.255, nil} {
+		if err := r.Scan(src); err != nil {
+			t.Fatalf("Scan(%v) returned error: %v", src, err)
+		}
+	}
+	if !r.IsZero() {
+		t.Errorf("Scan(nil) should reset the rate")
+	}
+	if err := r.Scan(true); err == nil {
+		t.Errorf("Scan(bool) expected error")
+	}
+}
+
+// TestJSON checks that rates travel through JSON as exact decimal strings
+func TestJSON(t *testing.T) {
+	out, err := json.Marshal(struct{ Buy Rate }{MustParse("0.01234567")})
+	if err != nil || string(out) != `{"Buy":"0.01234567"}` {
+		t.Fatalf("Marshal = %s, %v", out, err)
+	}
+
+	var in struct{ Buy Rate }
+	if err := json.Unmarshal(out, &in); err != nil || in.Buy != MustParse("0.01234567") {
+		t.Errorf("Unmarshal = %v, %v", in.Buy, err)
+	}
+}
+
+// TestMul checks amount conversion without int64 overflow in the intermediate product
+func TestMul(t *testing.T) {
+	if got := MustParse("4.2551").Mul(MustParse("1000")).String(); got != "4255.1000" {
+		t.Errorf("Mul = %s, want 4255.1000", got)
+	}
+	if got := MustParse("0.01234567").Mul(MustParse("250000")).String(); got != "3086.4175" {
+		t.Errorf("Mul = %s, want 3086.4175", got)
+	}
+	if got := MustParse("-0.00000001").Mul(MustParse("0.5")).String(); got != "-0.00000001" {
+		t.Errorf("Mul = %s, want -0.00000001", got)
+	}
+	big := MustParse("90000000000")
+	if got := big.Mul(big); got != (Rate{v: math.MaxInt64}) {
+		t.Errorf("Mul overflow = %s, want saturation at the maximum", got)
+	}
+	if got := big.Mul(MustParse("-2")); got != (Rate{v: math.MinInt64}) {
+		t.Errorf("Mul overflow = %s, want saturation at the minimum", got)
+	}
+}
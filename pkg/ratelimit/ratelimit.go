@@ -0,0 +1,262 @@
+package ratelimit
+
+import (
+	// Standard libraries
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"maps"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	// External utilities
+	"github.com/Niutaq/Gix/pkg/auth"
+	"github.com/redis/go-redis/v9"
+)
+
+// Route classes with separate quotas.
+const (
+	ClassRead     = "read"     // cached reads: cantors, history, quotes
+	ClassRates    = "rates"    // rate lookups that can trigger a live scrape on cache miss
+	ClassDiscover = "discover" // discovery, which can trigger paid LLM calls
+	ClassWrite    = "write"    // other mutating endpoints
+	ClassStream   = "stream"   // opening a streaming RPC
+	ClassExport   = "export"   // bulk history exports
+)
+
+// ConfigKey is the Redis hash holding quota overrides ("class" -> "limit/window"). Changes are
+// picked up by every replica on the next sync, so limits can be tuned without a redeploy.
+const ConfigKey = "ratelimit:quotas"
+
+// DefaultQuotas apply when neither the environment nor Redis configures a class.
+var DefaultQuotas = map[string]Quota{
+	ClassRead:     {Limit: 300, Window: time.Minute},
+	ClassRates:    {Limit: 60, Window: time.Minute},
+	ClassDiscover: {Limit: 10, Window: time.Hour},
+	ClassWrite:    {Limit: 60, Window: time.Minute},
+	ClassStream:   {Limit: 30, Window: time.Minute},
+	ClassExport:   {Limit: 20, Window: time.Hour},
+}
+
+// Quota allows Limit requests per sliding Window. A Limit of 0 disables limiting for the class.
+type Quota struct {
+	Limit  int
+	Window time.Duration
+}
+
+// ParseQuota parses "limit/window", e.g. "60/1m".
+func ParseQuota(s string) (Quota, error) {
+	limitStr, windowStr, ok := strings.Cut(strings.TrimSpace(s), "/")
+	if !ok {
+		return Quota{}, fmt.Errorf("invalid quota %q (want limit/window, e.g. 60/1m)", s)
+	}
+	limit, err := strconv.Atoi(limitStr)
+	if err != nil || limit < 0 {
+		return Quota{}, fmt.Errorf("invalid quota limit %q", limitStr)
+	}
+	window, err := time.ParseDuration(windowStr)
+	if err != nil || window < time.Second {
+		return Quota{}, fmt.Errorf("invalid quota window %q (minimum 1s)", windowStr)
+	}
+	return Quota{Limit: limit, Window: window}, nil
+}
+
+// ParseQuotas parses a comma separated list of "class=limit/window" entries.
+func ParseQuotas(s string) (map[string]Quota, error) {
+	quotas := make(map[string]Quota)
+	for _, entry := range strings.Split(s, ",") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		class, spec, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid quota entry %q (want class=limit/window)", entry)
+		}
+		q, err := ParseQuota(spec)
+		if err != nil {
+			return nil, err
+		}
+		quotas[strings.TrimSpace(class)] = q
+	}
+	return quotas, nil
+}
+
+func (q Quota) String() string {
+	return fmt.Sprintf("%d/%s", q.Limit, q.Window)
+}
+
+// ClientKey identifies the caller a request is counted against: the API key or JWT subject of an
+// authenticated principal, otherwise the client IP.
+func ClientKey(p auth.Principal, authenticated bool, ip string) string {
+	switch {
+	case authenticated && p.KeyID != 0:
+		return "key:" + strconv.Itoa(p.KeyID)
+	case authenticated:
+		return "sub:" + p.Subject
+	default:
+		return "ip:" + ip
+	}
+}
+
+// Decision is the outcome of a rate limit check.
+type Decision struct {
+	Allowed   bool
+	Quota     Quota
+	Remaining int
+	Reset     time.Duration // until the oldest counted request leaves the window
+}
+
+// SetHeaders writes the RateLimit-* headers (IETF draft) and, when denied, Retry-After.
+func (d Decision) SetHeaders(h http.Header) {
+	if d.Quota.Limit == 0 {
+		return
+	}
+	reset := strconv.Itoa(ceilSeconds(d.Reset))
+	h.Set("RateLimit-Limit", strconv.Itoa(d.Quota.Limit))
+	h.Set("RateLimit-Remaining", strconv.Itoa(d.Remaining))
+	h.Set("RateLimit-Reset", reset)
+	h.Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d", d.Quota.Limit, ceilSeconds(d.Quota.Window)))
+	if !d.Allowed {
+		h.Set("Retry-After", reset)
+	}
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+// slidingWindowScript keeps one sorted-set entry per counted request, scored by Redis server time
+// in milliseconds, so replicas share one clock. Returns {allowed, count, reset_ms}.
+var slidingWindowScript = redis.NewScript(`
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+local window = tonumber(ARGV[1])
+local limit = tonumber(ARGV[2])
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now - window)
+local count = redis.call('ZCARD', KEYS[1])
+local allowed = 0
+if count < limit then
+	redis.call('ZADD', KEYS[1], now, ARGV[3])
+	count = count + 1
+	allowed = 1
+end
+redis.call('PEXPIRE', KEYS[1], window)
+local reset = window
+local oldest = redis.call('ZRANGE', KEYS[1], 0, 0, 'WITHSCORES')
+if oldest[2] then
+	reset = tonumber(oldest[2]) + window - now
+end
+return {allowed, count, reset}
+`)
+
+// Limiter enforces per-class sliding window quotas in Redis.
+type Limiter struct {
+	rdb      redis.UniversalClient
+	defaults map[string]Quota
+	mu       sync.RWMutex
+	quotas   map[string]Quota
+}
+
+// NewLimiter creates a limiter using the given defaults until Reload reads overrides from Redis.
+func NewLimiter(rdb redis.UniversalClient, defaults map[string]Quota) *Limiter {
+	return &Limiter{rdb: rdb, defaults: maps.Clone(defaults), quotas: maps.Clone(defaults)}
+}
+
+// Quotas returns the effective quota of every class.
+func (l *Limiter) Quotas() map[string]Quota {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return maps.Clone(l.quotas)
+}
+
+// Allow counts one request of key against the class quota. Unknown classes and Redis failures
+// are allowed, so an outage of the limiter never takes the API down with it.
+func (l *Limiter) Allow(ctx context.Context, class, key string) Decision {
+	l.mu.RLock()
+	q, ok := l.quotas[class]
+	l.mu.RUnlock()
+	if !ok || q.Limit == 0 {
+		return Decision{Allowed: true}
+	}
+
+	res, err := slidingWindowScript.Run(ctx, l.rdb, []string{"ratelimit:" + class + ":" + key},
+		q.Window.Milliseconds(), q.Limit, requestID()).Int64Slice()
+	if err != nil || len(res) != 3 {
+		log.Printf("Rate Limit Error: %v", err)
+		return Decision{Allowed: true}
+	}
+
+	return Decision{
+		Allowed:   res[0] == 1,
+		Quota:     q,
+		Remaining: max(q.Limit-int(res[1]), 0),
+		Reset:     time.Duration(res[2]) * time.Millisecond,
+	}
+}
+
+// SetQuota stores an override in Redis and applies it locally.
+func (l *Limiter) SetQuota(ctx context.Context, class string, q Quota) error {
+	if err := l.rdb.HSet(ctx, ConfigKey, class, q.String()).Err(); err != nil {
+		return err
+	}
+	l.mu.Lock()
+	l.quotas[class] = q
+	l.mu.Unlock()
+	return nil
+}
+
+// Reload merges the overrides stored in Redis over the defaults.
+func (l *Limiter) Reload(ctx context.Context) error {
+	overrides, err := l.rdb.HGetAll(ctx, ConfigKey).Result()
+	if err != nil {
+		return err
+	}
+
+	quotas := maps.Clone(l.defaults)
+	for class, spec := range overrides {
+		q, err := ParseQuota(spec)
+		if err != nil {
+			log.Printf("Rate Limit Config Error (%s): %v", class, err)
+			continue
+		}
+		quotas[class] = q
+	}
+
+	l.mu.Lock()
+	l.quotas = quotas
+	l.mu.Unlock()
+	return nil
+}
+
+// StartSync reloads the overrides immediately and then periodically.
+func (l *Limiter) StartSync(ctx context.Context, interval time.Duration) {
+	if err := l.Reload(ctx); err != nil {
+		log.Printf("Rate Limit Config Load Error: %v", err)
+	}
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := l.Reload(ctx); err != nil {
+					log.Printf("Rate Limit Config Load Error: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+func requestID() string {
+	buf := make([]byte, 8)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
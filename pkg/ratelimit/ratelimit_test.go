@@ -0,0 +1,57 @@
+package ratelimit
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+// TestParseQuotas checks the environment/Redis quota syntax
+func TestParseQuotas(t *testing.T) {
+	quotas, err := ParseQuotas("rates=60/1m, discover=5/1h,")
+	if err != nil {
+		t.Fatalf("ParseQuotas: %v", err)
+	}
+	if quotas[ClassRates] != (Quota{Limit: 60, Window: time.Minute}) || quotas[ClassDiscover] != (Quota{Limit: 5, Window: time.Hour}) {
+		t.Errorf("quotas = %v", quotas)
+	}
+
+	q, err := ParseQuota(quotas[ClassRates].String())
+	if err != nil || q != quotas[ClassRates] {
+		t.Errorf("String round trip = %v, %v", q, err)
+	}
+
+	for _, bad := range []string{"60", "x/1m", "-1/1m", "60/abc", "60/10ms"} {
+		if _, err := ParseQuota(bad); err == nil {
+			t.Errorf("ParseQuota(%q) expected error", bad)
+		}
+	}
+	if _, err := ParseQuotas("rates"); err == nil {
+		t.Errorf("entry without class accepted")
+	}
+}
+
+// TestDecision_SetHeaders checks the RateLimit-* and Retry-After headers
+func TestDecision_SetHeaders(t *testing.T) {
+	h := http.Header{}
+	Decision{Allowed: false, Quota: Quota{Limit: 60, Window: time.Minute}, Remaining: 0, Reset: 1500 * time.Millisecond}.SetHeaders(h)
+
+	want := map[string]string{
+		"RateLimit-Limit":     "60",
+		"RateLimit-Remaining": "0",
+		"RateLimit-Reset":     "2",
+		"RateLimit-Policy":    "60;w=60",
+		"Retry-After":         "2",
+	}
+	for k, v := range want {
+		if got := h.Get(k); got != v {
+			t.Errorf("%s = %q, want %q", k, got, v)
+		}
+	}
+
+	h = http.Header{}
+	Decision{Allowed: true, Quota: Quota{Limit: 60, Window: time.Minute}, Remaining: 59}.SetHeaders(h)
+	if h.Get("Retry-After") != "" {
+		t.Errorf("Retry-After set on allowed request")
+	}
+}
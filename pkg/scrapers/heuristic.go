@@ -25,6 +25,12 @@ type HeuristicResult struct {
 	Confidence   float64 // 0.0 to 1.0
 }
 
+// Confidence assigned to results of the weaker heuristic strategies.
+const (
+	fallbackConfidence = 0.5
+	llmConfidence      = 0.4
+)
+
 var (
 	// Regex to find numbers in text (e.g., 4.1234, 4,12, or 4.255)
 	numberRegex = regexp.MustCompile(`\d+[.,]\d{2,4}`)
@@ -329,6 +335,7 @@ func HeuristicScrape(ctx context.Context, url, targetCurrency string) (ScrapeRes
 	if len(results) > 0 {
 		res := results[0].ScrapeResult
 		res.UsedScraperType = "heuristic"
+		res.Confidence = results[0].Confidence
 		return res, nil
 	}
 
@@ -336,6 +343,7 @@ func HeuristicScrape(ctx context.Context, url, targetCurrency string) (ScrapeRes
 	res, err := fallbackRowSearch(doc, targetCurrency)
 	if err == nil {
 		res.UsedScraperType = "heuristic"
+		res.Confidence = fallbackConfidence
 		return res, nil
 	}
 
@@ -704,7 +712,7 @@ Tekst: %s`, targetCurrency, cleanText)
 	}
 
 	log.Printf("LLM Success! Extracted for %s: Buy %s, Sell %s", targetCurrency, buy, sell)
-	return ScrapeResult{BuyRate: buy, SellRate: sell, UsedScraperType: "llm"}, nil
+	return ScrapeResult{BuyRate: buy, SellRate: sell, UsedScraperType: "llm", Confidence: llmConfidence}, nil
 }
 
 // LLMExtractAddress uses Gemini to find a physical address in the HTML text.
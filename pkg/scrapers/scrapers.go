@@ -28,7 +28,8 @@ const (
 type ScrapeResult struct {
 	BuyRate         string
 	SellRate        string
-	UsedScraperType string // "static", "heuristic", or "llm"
+	UsedScraperType string  // "static", "heuristic", or "llm"
+	Confidence      float64 // 0.0 to 1.0, zero when the scraper does not estimate it
 }
 
 // ScrapeFunc defines the signature for a scraping function
@@ -121,8 +122,8 @@ var httpClient = &http.Client{
 // AllowLocalhostForTesting is a flag used to bypass SSRF protections during unit tests.
 var AllowLocalhostForTesting bool
 
-// validateURL checks if the URL is safe to fetch (SSRF protection)
-func validateURL(rawURL string) error {
+// ValidateURL checks if the URL is safe to fetch (SSRF protection)
+func ValidateURL(rawURL string) error {
 	u, err := url.Parse(rawURL)
 	if err != nil {
 		return fmt.Errorf("invalid URL: %v", err)
@@ -184,7 +185,7 @@ func fetchDocument(ctx context.Context, url string) (*goquery.Document, error) {
 	}
 
 	// Security: Validate URL before fetching
-	if err := validateURL(url); err != nil {
+	if err := ValidateURL(url); err != nil {
 		return nil, fmt.Errorf("security block: %v", err)
 	}
 
@@ -95,6 +95,23 @@ func (se *SearchEngine) IndexCantor(c CantorRecord) error {
 	return nil
 }
 
+// DeleteCantor removes a cantor document from Elasticsearch. Missing documents are not an error.
+func (se *SearchEngine) DeleteCantor(id int) error {
+	res, err := se.client.Delete(
+		"cantors",
+		fmt.Sprintf("%d", id),
+		se.client.Delete.WithContext(context.Background()),
+	)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = res.Body.Close() }()
+	if res.IsError() && res.StatusCode != 404 {
+		return fmt.Errorf(searchErrorFmt, res.String())
+	}
+	return nil
+}
+
 // IndexCity indexes a city record into Elasticsearch.
 func (se *SearchEngine) IndexCity(c types.CityRecord) error {
 	var buf bytes.Buffer
@@ -0,0 +1,97 @@
+package streams
+
+import (
+	// Standard libraries
+	"fmt"
+	"io"
+	"sync/atomic"
+	"time"
+)
+
+// latencyBuckets are the upper bounds of the send latency histogram.
+var latencyBuckets = [...]time.Duration{
+	time.Millisecond, 5 * time.Millisecond, 25 * time.Millisecond,
+	100 * time.Millisecond, 500 * time.Millisecond, 2500 * time.Millisecond,
+}
+
+// Metrics counts connected stream clients and what happened to their messages. It is safe for
+// concurrent use.
+type Metrics struct {
+	clients         atomic.Int64
+	sent            atomic.Int64
+	coalesced       atomic.Int64
+	dropped         atomic.Int64
+	slowDisconnects atomic.Int64
+
+	latencySum     atomic.Int64 // nanoseconds
+	latencyBuckets [len(latencyBuckets) + 1]atomic.Int64
+}
+
+// Stats collects the metrics of every rate stream of the process.
+var Stats = &Metrics{}
+
+// Connect counts a stream client until the returned function is called.
+func (m *Metrics) Connect() (disconnect func()) {
+	m.clients.Add(1)
+	return func() { m.clients.Add(-1) }
+}
+
+// ObserveSend records a message delivered to a client and how long the send took.
+func (m *Metrics) ObserveSend(d time.Duration) {
+	m.sent.Add(1)
+	m.latencySum.Add(int64(d))
+	i := 0
+	for i < len(latencyBuckets) && d > latencyBuckets[i] {
+		i++
+	}
+	m.latencyBuckets[i].Add(1)
+}
+
+// GetSummary returns the current values for the health endpoint.
+func (m *Metrics) GetSummary() map[string]any {
+	sent := m.sent.Load()
+	avg := time.Duration(0)
+	if sent > 0 {
+		avg = time.Duration(m.latencySum.Load() / sent)
+	}
+	return map[string]any{
+		"connected_clients": m.clients.Load(),
+		"sent":              sent,
+		"coalesced":         m.coalesced.Load(),
+		"dropped":           m.dropped.Load(),
+		"slow_disconnects":  m.slowDisconnects.Load(),
+		"avg_send_latency":  avg.String(),
+	}
+}
+
+// WritePrometheus writes the metrics in the Prometheus text exposition format.
+func (m *Metrics) WritePrometheus(w io.Writer) error {
+	var err error
+	printf := func(format string, args ...any) {
+		if err == nil {
+			_, err = fmt.Fprintf(w, format, args...)
+		}
+	}
+	counter := func(name, help string, v int64) {
+		printf("# HELP %s %s\n# TYPE %s counter\n%s %d\n", name, help, name, name, v)
+	}
+
+	printf("# HELP gix_stream_clients Connected rate stream clients.\n# TYPE gix_stream_clients gauge\ngix_stream_clients %d\n", m.clients.Load())
+	counter("gix_stream_messages_sent_total", "Rate stream messages delivered to clients.", m.sent.Load())
+	counter("gix_stream_messages_coalesced_total", "Rate stream messages replaced by a newer one before being sent.", m.coalesced.Load())
+	counter("gix_stream_messages_dropped_total", "Rate stream messages dropped from a full coalescing queue.", m.dropped.Load())
+	counter("gix_stream_slow_disconnects_total", "Rate stream clients disconnected for not keeping up.", m.slowDisconnects.Load())
+
+	printf("# HELP gix_stream_send_latency_seconds Time taken to send a rate stream message.\n")
+	printf("# TYPE gix_stream_send_latency_seconds histogram\n")
+	var cumulative int64
+	for i, le := range latencyBuckets {
+		cumulative += m.latencyBuckets[i].Load()
+		printf("gix_stream_send_latency_seconds_bucket{le=\"%g\"} %d\n", le.Seconds(), cumulative)
+	}
+	cumulative += m.latencyBuckets[len(latencyBuckets)].Load()
+	printf("gix_stream_send_latency_seconds_bucket{le=\"+Inf\"} %d\n", cumulative)
+	printf("gix_stream_send_latency_seconds_sum %g\n", time.Duration(m.latencySum.Load()).Seconds())
+	printf("gix_stream_send_latency_seconds_count %d\n", cumulative)
+	return err
+}
@@ -0,0 +1,127 @@
+package streams
+
+import (
+	// Standard libraries
+	"container/list"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Policy decides what a full send queue does with a new message.
+type Policy string
+
+const (
+	// Coalesce keeps only the latest message of every key: a new message replaces the pending one with
+	// the same key and moves to the back, so the queue stays in the order messages were pushed. A new
+	// key arriving at a full queue drops the oldest message.
+	Coalesce Policy = "coalesce"
+	// Disconnect ends the stream of the slow client.
+	Disconnect Policy = "disconnect"
+)
+
+// ErrSlowConsumer is returned by Push when a full queue uses the Disconnect policy.
+var ErrSlowConsumer = errors.New("client is not keeping up with the stream")
+
+// Config sets the size and overflow policy of every per-client send queue.
+type Config struct {
+	QueueSize int
+	Overflow  Policy
+}
+
+// DefaultConfig is used for zero fields of a Config.
+var DefaultConfig = Config{QueueSize: 256, Overflow: Coalesce}
+
+// ParsePolicy accepts "coalesce" or "disconnect".
+func ParsePolicy(s string) (Policy, error) {
+	switch p := Policy(strings.ToLower(strings.TrimSpace(s))); p {
+	case Coalesce, Disconnect:
+		return p, nil
+	default:
+		return "", fmt.Errorf("unknown overflow policy %q, want coalesce or disconnect", s)
+	}
+}
+
+// Queue buffers the messages of one stream between the goroutine producing them and the one sending
+// them to the client. It never blocks the producer: when full, the overflow policy applies.
+type Queue[T any, K comparable] struct {
+	mu     sync.Mutex
+	items  *list.List          // of T, oldest first
+	index  map[K]*list.Element // pending message of each key, for Coalesce
+	size   int
+	policy Policy
+	key    func(T) K
+	ready  chan struct{}
+	stats  *Metrics
+}
+
+// NewQueue returns an empty queue. key identifies messages superseding each other, e.g. the cantor and
+// currency of a rate. Overflows are counted in stats.
+func NewQueue[T any, K comparable](cfg Config, key func(T) K, stats *Metrics) *Queue[T, K] {
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = DefaultConfig.QueueSize
+	}
+	if cfg.Overflow == "" {
+		cfg.Overflow = DefaultConfig.Overflow
+	}
+	return &Queue[T, K]{
+		items:  list.New(),
+		index:  make(map[K]*list.Element),
+		size:   cfg.QueueSize,
+		policy: cfg.Overflow,
+		key:    key,
+		ready:  make(chan struct{}, 1),
+		stats:  stats,
+	}
+}
+
+// Push appends a message. With Coalesce it also removes the pending message of the same key, or the
+// oldest message when the queue is full; with Disconnect it fails once the queue is full.
+func (q *Queue[T, K]) Push(item T) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.policy == Disconnect {
+		if q.items.Len() >= q.size {
+			q.stats.slowDisconnects.Add(1)
+			return ErrSlowConsumer
+		}
+		q.items.PushBack(item)
+	} else {
+		k := q.key(item)
+		if e, ok := q.index[k]; ok {
+			q.items.Remove(e)
+			q.stats.coalesced.Add(1)
+		} else if q.items.Len() >= q.size {
+			oldest := q.items.Remove(q.items.Front()).(T)
+			delete(q.index, q.key(oldest))
+			q.stats.dropped.Add(1)
+		}
+		q.index[k] = q.items.PushBack(item)
+	}
+
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Ready receives a value after Push while messages may be waiting for Drain.
+func (q *Queue[T, K]) Ready() <-chan struct{} {
+	return q.ready
+}
+
+// Drain removes and returns the queued messages in the order they were pushed.
+func (q *Queue[T, K]) Drain() []T {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	items := make([]T, 0, q.items.Len())
+	for e := q.items.Front(); e != nil; e = e.Next() {
+		items = append(items, e.Value.(T))
+	}
+	q.items.Init()
+	clear(q.index)
+	return items
+}
@@ -0,0 +1,148 @@
+package streams
+
+import (
+	// Standard libraries
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+type update struct {
+	key string
+	seq int
+}
+
+func seqs(items []update) []int {
+	out := make([]int, len(items))
+	for i, u := range items {
+		out[i] = u.seq
+	}
+	return out
+}
+
+func TestQueueCoalesce(t *testing.T) {
+	stats := &Metrics{}
+	q := NewQueue(Config{QueueSize: 3}, func(u update) string { return u.key }, stats)
+
+	for i, key := range []string{"a", "b", "a", "c", "d", "c"} {
+		if err := q.Push(update{key, i + 1}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Every key keeps its latest message, in sequence order; the fourth key drops the oldest, b.
+	if got := seqs(q.Drain()); !slices.Equal(got, []int{3, 5, 6}) {
+		t.Errorf("Drain = %v, want [3 5 6]", got)
+	}
+	if stats.coalesced.Load() != 2 || stats.dropped.Load() != 1 {
+		t.Errorf("coalesced %d, dropped %d, want 2 and 1", stats.coalesced.Load(), stats.dropped.Load())
+	}
+	select {
+	case <-q.Ready():
+	default:
+		t.Error("Ready should fire after Push")
+	}
+	if len(q.Drain()) != 0 {
+		t.Error("Drain should empty the queue")
+	}
+
+	// A drained key is queued anew rather than replacing a message already sent.
+	_ = q.Push(update{"a", 7})
+	_ = q.Push(update{"b", 8})
+	if got := seqs(q.Drain()); !slices.Equal(got, []int{7, 8}) {
+		t.Errorf("Drain after drain = %v, want [7 8]", got)
+	}
+}
+
+// TestQueueResumeMidDrain cuts the stream after every message of a drain and resumes after the
+// highest sequence the client received, as the client does; every key must end at its latest message.
+func TestQueueResumeMidDrain(t *testing.T) {
+	pushed := []update{{"a", 1}, {"b", 2}, {"a", 3}, {"c", 4}, {"b", 5}, {"a", 6}}
+	latest := make(map[string]int)
+	for _, u := range pushed {
+		latest[u.key] = u.seq
+	}
+	newQueue := func() *Queue[update, string] {
+		return NewQueue(Config{}, func(u update) string { return u.key }, &Metrics{})
+	}
+
+	q := newQueue()
+	for _, u := range pushed {
+		_ = q.Push(u)
+	}
+	drained := q.Drain()
+	for cut := 0; cut <= len(drained); cut++ {
+		got := make(map[string]int)
+		resume := 0
+		for _, u := range drained[:cut] {
+			got[u.key] = u.seq
+			resume = max(resume, u.seq)
+		}
+		replay := newQueue()
+		for _, u := range pushed {
+			if u.seq > resume {
+				_ = replay.Push(u)
+			}
+		}
+		for _, u := range replay.Drain() {
+			got[u.key] = u.seq
+		}
+		if !maps.Equal(got, latest) {
+			t.Errorf("cut after %d of %v: client ends at %v, want %v", cut, seqs(drained), got, latest)
+		}
+	}
+}
+
+func TestQueueDisconnect(t *testing.T) {
+	stats := &Metrics{}
+	q := NewQueue(Config{QueueSize: 1, Overflow: Disconnect}, func(u update) string { return u.key }, stats)
+	if err := q.Push(update{"a", 1}); err != nil {
+		t.Fatal(err)
+	}
+	if err := q.Push(update{"a", 2}); !errors.Is(err, ErrSlowConsumer) {
+		t.Errorf("Push on a full queue = %v, want ErrSlowConsumer", err)
+	}
+	if stats.slowDisconnects.Load() != 1 {
+		t.Errorf("slow disconnects = %d, want 1", stats.slowDisconnects.Load())
+	}
+}
+
+func TestParsePolicy(t *testing.T) {
+	if p, err := ParsePolicy(" Disconnect "); err != nil || p != Disconnect {
+		t.Errorf("ParsePolicy = %q, %v", p, err)
+	}
+	if _, err := ParsePolicy("drop"); err == nil {
+		t.Error("unknown policy accepted")
+	}
+}
+
+func TestMetricsPrometheus(t *testing.T) {
+	m := &Metrics{}
+	done := m.Connect()
+	m.ObserveSend(3 * time.Millisecond)
+	m.ObserveSend(time.Second)
+
+	var b strings.Builder
+	if err := m.WritePrometheus(&b); err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{
+		"gix_stream_clients 1\n",
+		"gix_stream_messages_sent_total 2\n",
+		`gix_stream_send_latency_seconds_bucket{le="0.001"} 0` + "\n",
+		`gix_stream_send_latency_seconds_bucket{le="0.005"} 1` + "\n",
+		`gix_stream_send_latency_seconds_bucket{le="2.5"} 2` + "\n",
+		"gix_stream_send_latency_seconds_count 2\n",
+	} {
+		if !strings.Contains(b.String(), line) {
+			t.Errorf("output lacks %q:\n%s", line, b.String())
+		}
+	}
+
+	done()
+	if m.GetSummary()["connected_clients"] != int64(0) {
+		t.Error("client still counted after disconnect")
+	}
+}
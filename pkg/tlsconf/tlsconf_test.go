@@ -0,0 +1,176 @@
+package tlsconf
+
+import (
+	// Standard libraries
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// issued is a generated certificate with its key, written to PEM files.
+type issued struct {
+	cert              *x509.Certificate
+	key               *ecdsa.PrivateKey
+	certFile, keyFile string
+}
+
+// issue creates a certificate for localhost signed by parent, or self-signed when parent is nil.
+func issue(t *testing.T, name string, isCA bool, parent *issued) *issued {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		DNSNames:              []string{"localhost"},
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1)},
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+	}
+	signer, signerKey := tmpl, key
+	if parent != nil {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	keyDER, _ := x509.MarshalECPrivateKey(key)
+
+	dir := t.TempDir()
+	out := &issued{cert: cert, key: key, certFile: filepath.Join(dir, name+".pem"), keyFile: filepath.Join(dir, name+"-key.pem")}
+	if err := os.WriteFile(out.certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(out.keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+// handshake runs a TLS handshake between the configs over a loopback connection and returns the
+// client side error, or the server side one when only the server failed.
+func handshake(t *testing.T, server, client *tls.Config) error {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lis.Close()
+
+	done := make(chan error, 1)
+	go func() {
+		conn, err := lis.Accept()
+		if err != nil {
+			done <- err
+			return
+		}
+		defer conn.Close()
+		_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+		done <- tls.Server(conn, server).Handshake()
+	}()
+
+	conn, err := net.Dial("tcp", lis.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+	err = tls.Client(conn, client).Handshake()
+	conn.Close()
+	if serverErr := <-done; err == nil {
+		err = serverErr
+	}
+	return err
+}
+
+func TestClientVerification(t *testing.T) {
+	ca := issue(t, "ca", true, nil)
+	srv := issue(t, "server", false, ca)
+	other := issue(t, "other", false, nil)
+
+	serverCfg, err := Server(srv.certFile, srv.keyFile, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range map[string]struct {
+		opts ClientOptions
+		ok   bool
+	}{
+		"ca":             {ClientOptions{CAFile: ca.certFile}, true},
+		"unknown ca":     {ClientOptions{CAFile: other.certFile}, false},
+		"pin only":       {ClientOptions{Pins: []string{Fingerprint(srv.cert)}}, true},
+		"wrong pin":      {ClientOptions{Pins: []string{Fingerprint(other.cert)}}, false},
+		"ca and pin":     {ClientOptions{CAFile: ca.certFile, Pins: []string{"sha256/" + Fingerprint(srv.cert)}}, true},
+		"ca, wrong pin":  {ClientOptions{CAFile: ca.certFile, Pins: []string{Fingerprint(other.cert)}}, false},
+		"any of several": {ClientOptions{Pins: []string{Fingerprint(other.cert), Fingerprint(srv.cert)}}, true},
+	} {
+		tc.opts.ServerName = "localhost"
+		clientCfg, err := Client(tc.opts)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if err := handshake(t, serverCfg, clientCfg); (err == nil) != tc.ok {
+			t.Errorf("%s: handshake error = %v, want ok %v", name, err, tc.ok)
+		}
+	}
+
+	clientCfg, _ := Client(ClientOptions{ServerName: "localhost", Pins: []string{Fingerprint(other.cert)}})
+	if err := handshake(t, serverCfg, clientCfg); !errors.Is(err, ErrPinMismatch) {
+		t.Errorf("wrong pin: err = %v, want ErrPinMismatch", err)
+	}
+	if _, err := Client(ClientOptions{Pins: []string{"not-base64"}}); err == nil {
+		t.Error("malformed pin accepted")
+	}
+}
+
+func TestMutualTLS(t *testing.T) {
+	ca := issue(t, "ca", true, nil)
+	srv := issue(t, "server", false, ca)
+	client := issue(t, "client", false, ca)
+	stranger := issue(t, "stranger", false, nil)
+
+	serverCfg, err := Server(srv.certFile, srv.keyFile, ca.certFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range map[string]struct {
+		cert *issued
+		ok   bool
+	}{
+		"signed client":  {client, true},
+		"no certificate": {nil, false},
+		"unknown issuer": {stranger, false},
+	} {
+		opts := ClientOptions{ServerName: "localhost", CAFile: ca.certFile}
+		if tc.cert != nil {
+			opts.CertFile, opts.KeyFile = tc.cert.certFile, tc.cert.keyFile
+		}
+		clientCfg, err := Client(opts)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if err := handshake(t, serverCfg, clientCfg); (err == nil) != tc.ok {
+			t.Errorf("%s: handshake error = %v, want ok %v", name, err, tc.ok)
+		}
+	}
+}
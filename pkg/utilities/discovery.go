@@ -1,7 +1,6 @@
 package utilities
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -16,6 +15,7 @@ import (
 	"time"
 
 	"gioui.org/app"
+	pb "github.com/Niutaq/Gix/api/proto/v1"
 	"github.com/PuerkitoBio/goquery"
 )
 
@@ -96,52 +96,31 @@ func TriggerDiscovery(state *AppState, config AppConfig, lat, lon float64) {
 func triggerHeuristicDiscovery(url string, expectedName string, expectedLat, expectedLon float64, expectedAddress string, state *AppState, config AppConfig, window *app.Window) bool {
 	log.Printf("Triggering Heuristic Discovery for: %s", url)
 
-	payload := map[string]interface{}{
-		"url":              url,
-		"expected_name":    expectedName,
-		"expected_lat":     expectedLat,
-		"expected_lon":     expectedLon,
-		"expected_address": expectedAddress,
-	}
-	body, _ := json.Marshal(payload)
-
-	apiURL := config.APIDiscoverURL
-
-	req, err := http.NewRequest("POST", apiURL, bytes.NewBuffer(body))
-	if err != nil {
-		log.Printf("Heuristic Discovery Request Error: %v", err)
-		return false
-	}
-	req.Header.Set("Content-Type", "application/json")
-
-	client := http.Client{Timeout: 120 * time.Second} // Scraping with LLM fallback takes longer
-	resp, err := client.Do(req)
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second) // Scraping with LLM fallback takes longer
+	defer cancel()
+
+	var result *pb.DiscoverResponse
+	err := callDRPC(ctx, config, func(ctx context.Context, client pb.DRPCRatesServiceClient) error {
+		var err error
+		result, err = client.Discover(ctx, &pb.DiscoverRequest{
+			Url:             url,
+			ExpectedName:    expectedName,
+			ExpectedLat:     expectedLat,
+			ExpectedLon:     expectedLon,
+			ExpectedAddress: expectedAddress,
+		})
+		return err
+	})
 	if err != nil {
 		log.Printf("Heuristic Discovery Error: %v", err)
 		return false
 	}
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != http.StatusCreated {
-		log.Printf("Heuristic Discovery failed with status: %d", resp.StatusCode)
-		return false
-	}
-
-	var result map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		log.Printf("Heuristic Discovery JSON Error: %v", err)
-		return false
-	}
 
 	log.Printf("Heuristic Discovery Success: %v", result)
 
 	// Add to UI state immediately so it shows up on map and list
-	name, _ := result["name"].(string)
-	addr, _ := result["address"].(string)
-	lat, _ := result["lat"].(float64)
-	lon, _ := result["lon"].(float64)
-	idRaw, _ := result["id"].(float64)
-	idStr := fmt.Sprintf("%d", int(idRaw))
+	name, addr, lat, lon := result.Name, result.Address, result.Lat, result.Lon
+	idStr := fmt.Sprintf("%d", result.Id)
 
 	if addr == "" {
 		addr = "Discovered automatically"
@@ -150,7 +129,7 @@ func triggerHeuristicDiscovery(url string, expectedName string, expectedLat, exp
 	state.CantorsMu.Lock()
 	if state.Cantors[idStr] == nil {
 		state.Cantors[idStr] = &CantorInfo{
-			ID:          int(idRaw),
+			ID:          int(result.Id),
 			DisplayName: name,
 			Latitude:    lat,
 			Longitude:   lon,
@@ -173,20 +152,7 @@ func triggerHeuristicDiscovery(url string, expectedName string, expectedLat, exp
 	}
 
 	// Also mock some initial rate in Vault so it shows up
-	buyRate, _ := result["buyRate"].(string)
-	sellRate, _ := result["sellRate"].(string)
-
-	if br, ok := result["buyRate"].(float64); ok {
-		buyRate = fmt.Sprintf("%.4f", br)
-	} else if br, ok := result["buyRate"].(string); ok {
-		buyRate = br
-	}
-
-	if sr, ok := result["sellRate"].(float64); ok {
-		sellRate = fmt.Sprintf("%.4f", sr)
-	} else if sr, ok := result["sellRate"].(string); ok {
-		sellRate = sr
-	}
+	buyRate, sellRate := result.BuyRate, result.SellRate
 
 	if buyRate != "" || sellRate != "" {
 		state.Vault.Mu.Lock()
@@ -219,7 +185,67 @@ func triggerHeuristicDiscovery(url string, expectedName string, expectedLat, exp
 	return true
 }
 
-// TriggerDeleteCantor calls the backend /cantors/:id API with the DELETE method.
+// viewportPageSize is the page size used to load the markers of a map viewport.
+const viewportPageSize = 500
+
+// LoadViewportCantors fetches the cantors inside the map viewport after the user panned or zoomed and adds
+// the ones not known yet, so the map does not depend on the initial listing covering every area.
+func LoadViewportCantors(window *app.Window, state *AppState, config AppConfig) {
+	mapState := &state.UI.MapState
+	if !mapState.ViewportChanged || mapState.Dragging || config.DRPCServerURL == "" {
+		return
+	}
+	if !mapState.ViewportLoading.CompareAndSwap(false, true) {
+		return
+	}
+	mapState.ViewportChanged = false
+	vp := mapState.Viewport
+
+	go func() {
+		defer mapState.ViewportLoading.Store(false)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		list, err := ListCantorsRPC(ctx, config, &pb.ListCantorsRequest{
+			Bbox:  &pb.BoundingBox{MinLat: vp[0], MinLon: vp[1], MaxLat: vp[2], MaxLon: vp[3]},
+			Limit: viewportPageSize,
+		})
+		if err != nil {
+			log.Printf("Viewport Cantors Error: %v", err)
+			return
+		}
+
+		added := 0
+		state.CantorsMu.Lock()
+		for _, c := range list {
+			idStr := strconv.Itoa(c.ID)
+			if _, exists := state.Cantors[idStr]; exists {
+				continue
+			}
+			state.Cantors[idStr] = &CantorInfo{
+				ID:          c.ID,
+				DisplayName: c.DisplayName,
+				Address:     c.Address,
+				Latitude:    c.Latitude,
+				Longitude:   c.Longitude,
+				Strategy:    c.Strategy,
+			}
+			added++
+		}
+		if added > 0 {
+			state.UI.FilteredIDs = nil // Force re-filter so the new markers show up
+		}
+		state.CantorsMu.Unlock()
+
+		if added > 0 {
+			FetchAllRates(window, state, config)
+		}
+		window.Invalidate()
+	}()
+}
+
+// TriggerDeleteCantor deletes the cantor on the server over dRPC and removes it from the UI state.
 func TriggerDeleteCantor(state *AppState, config AppConfig, cantorKey string, window *app.Window) {
 	log.Printf("Triggering Delete for Cantor: %s", cantorKey)
 
@@ -230,23 +256,14 @@ func TriggerDeleteCantor(state *AppState, config AppConfig, cantorKey string, wi
 		return
 	}
 
-	apiURL := fmt.Sprintf("%s/%d", config.APICantorsURL, cantor.ID)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
 
-	req, err := http.NewRequest("DELETE", apiURL, nil)
-	if err != nil {
-		log.Printf("Delete Cantor Request Error: %v", err)
-		return
-	}
-
-	client := http.Client{Timeout: 5 * time.Second}
-	resp, err := client.Do(req)
-	if err != nil {
-		log.Printf("Delete Cantor Error: %v", err)
-		return
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode == http.StatusOK {
+	err := callDRPC(ctx, config, func(ctx context.Context, client pb.DRPCRatesServiceClient) error {
+		_, err := client.DeleteCantor(ctx, &pb.DeleteCantorRequest{Id: int32(cantor.ID)})
+		return err
+	})
+	if err == nil {
 		log.Printf("Successfully deleted cantor %s from DB.", cantorKey)
 		// Remove from UI state
 		state.CantorsMu.Lock()
@@ -265,7 +282,7 @@ func TriggerDeleteCantor(state *AppState, config AppConfig, cantorKey string, wi
 		}
 		window.Invalidate()
 	} else {
-		log.Printf("Delete Cantor failed with status: %d", resp.StatusCode)
+		log.Printf("Delete Cantor Error: %v", err)
 	}
 }
 
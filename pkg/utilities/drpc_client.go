@@ -3,10 +3,11 @@ package utilities
 import (
 	// Standard libraries
 	"context"
+	"crypto/tls"
 	"fmt"
 	"log"
 	"net"
-	"strings"
+	"net/url"
 	"time"
 
 	// Gio utilities
@@ -14,24 +15,32 @@ import (
 
 	// External utilities
 	pb "github.com/Niutaq/Gix/api/proto/v1"
+	"google.golang.org/protobuf/proto"
 	"storj.io/drpc/drpcconn"
+	"storj.io/drpc/drpcmetadata"
+	"storj.io/drpc/drpcmigrate"
 )
 
 // StartDRPCStream establishes a connection to the dRPC server and processes streaming rate updates in real-time.
-func StartDRPCStream(window *app.Window, state *AppState, drpcURL string) {
+// The first connection starts with a snapshot of the current rates; after a reconnect the stream resumes from
+// the last update received, so updates sent in between are replayed.
+func StartDRPCStream(window *app.Window, state *AppState, config AppConfig) {
+	var resumeFrom uint64
 	for {
-		log.Printf("Connecting to dRPC server at %s...", drpcURL)
-		conn, err := net.Dial("tcp", drpcURL)
+		log.Printf("Connecting to dRPC server at %s...", config.DRPCServerURL)
+		drpcConn, err := dialDRPC(context.Background(), config)
 		if err != nil {
 			log.Printf("dRPC dial error: %v. Retrying in 5s...", err)
 			time.Sleep(5 * time.Second)
 			continue
 		}
 
-		drpcConn := drpcconn.New(conn)
 		client := pb.NewDRPCRatesServiceClient(drpcConn)
 
-		stream, err := client.StreamRates(context.Background(), &pb.StreamRatesRequest{})
+		stream, err := client.StreamRates(withAPIKey(context.Background(), config), &pb.StreamRatesRequest{
+			ResumeFrom:      resumeFrom,
+			IncludeSnapshot: resumeFrom == 0,
+		})
 		if err != nil {
 			log.Printf("dRPC stream error: %v. Retrying in 5s...", err)
 			state.IsConnected.Store(false)
@@ -44,7 +53,7 @@ func StartDRPCStream(window *app.Window, state *AppState, drpcURL string) {
 
 		log.Println("Connected to dRPC stream!")
 		state.IsConnected.Store(true)
-		processStreamUpdates(window, state, stream)
+		resumeFrom = processStreamUpdates(window, state, stream, resumeFrom)
 
 		state.IsConnected.Store(false)
 		if err := drpcConn.Close(); err != nil {
@@ -55,19 +64,18 @@ func StartDRPCStream(window *app.Window, state *AppState, drpcURL string) {
 }
 
 // FetchAllRatesRPC performs a single dRPC call to fetch all rates for the given currency.
-func FetchAllRatesRPC(window *app.Window, state *AppState, drpcURL string) {
+func FetchAllRatesRPC(window *app.Window, state *AppState, config AppConfig) {
 	defer func() {
 		state.IsLoading.Store(false)
 		window.Invalidate()
 	}()
 
-	conn, err := net.Dial("tcp", drpcURL)
+	drpcConn, err := dialDRPC(context.Background(), config)
 	if err != nil {
 		log.Printf("FetchAllRatesRPC dial error: %v", err)
 		return
 	}
 
-	drpcConn := drpcconn.New(conn)
 	defer func() {
 		// Closing drpcConn also closes the underlying net.Conn
 		if err := drpcConn.Close(); err != nil {
@@ -78,7 +86,7 @@ func FetchAllRatesRPC(window *app.Window, state *AppState, drpcURL string) {
 	client := pb.NewDRPCRatesServiceClient(drpcConn)
 
 	log.Printf("Fetching all rates via dRPC for %s...", state.UI.Currency)
-	resp, err := client.GetAllRates(context.Background(), &pb.RateRequest{Currency: state.UI.Currency})
+	resp, err := client.GetAllRates(withAPIKey(context.Background(), config), &pb.RateRequest{Currency: state.UI.Currency})
 	if err != nil {
 		log.Printf("FetchAllRatesRPC call error: %v", err)
 		return
@@ -122,13 +130,17 @@ func updateStateWithRates(state *AppState, results []*pb.RateResponse, currency
 	}
 }
 
-// processStreamUpdates handles the message loop for the dRPC stream.
-func processStreamUpdates(window *app.Window, state *AppState, stream pb.DRPCRatesService_StreamRatesClient) {
+// processStreamUpdates handles the message loop for the dRPC stream and returns the sequence of the last update received.
+func processStreamUpdates(window *app.Window, state *AppState, stream pb.DRPCRatesService_StreamRatesClient, lastSeq uint64) uint64 {
 	for {
 		rate, err := stream.Recv()
 		if err != nil {
 			log.Printf("dRPC receive error: %v", err)
-			return
+			return lastSeq
+		}
+		// Updates arrive in sequence order; the check only keeps a snapshot from rewinding it.
+		if rate.Sequence > lastSeq {
+			lastSeq = rate.Sequence
 		}
 
 		if rate.Currency != state.UI.Currency {
@@ -181,35 +193,106 @@ func UpdateStateWithRate(window *app.Window, state *AppState, rate *pb.RateRespo
 	window.Invalidate()
 }
 
-// DeriveDRPCTarget parses the given API URL, extracts the host, and appends a default port of 8081 for dRPC connections.
+// DeriveDRPCTarget returns the host:port of the API server itself, which also serves dRPC on
+// connections opening with the drpcmigrate header. Without an explicit port the scheme default is used.
 func DeriveDRPCTarget(apiURL string) string {
-	// Remove scheme
-	host := apiURL
-	if strings.HasPrefix(host, "http://") {
-		host = host[7:]
-	} else if strings.HasPrefix(host, "https://") {
-		host = host[8:]
+	u, err := url.Parse(apiURL)
+	if err != nil || u.Hostname() == "" {
+		return "localhost:8080"
 	}
 
-	// Remove path if any
-	if idx := strings.Index(host, "/"); idx != -1 {
-		host = host[:idx]
+	port := u.Port()
+	if port == "" {
+		port = "80"
+		if u.Scheme == "https" {
+			port = "443"
+		}
 	}
+	return net.JoinHostPort(u.Hostname(), port)
+}
 
-	// Split host/port
-	h, _, err := net.SplitHostPort(host)
+// dialTimeout bounds connecting to the API server, including the TLS handshake.
+const dialTimeout = 10 * time.Second
+
+// dialDRPC opens a dRPC connection to the API server, sending the drpcmigrate header so the
+// server routes it away from HTTP. With TLS configured the handshake follows the header, and the
+// server name defaults to the dialled host. Connecting gives up after dialTimeout.
+func dialDRPC(ctx context.Context, config AppConfig) (*drpcconn.Conn, error) {
+	ctx, cancel := context.WithTimeout(ctx, dialTimeout)
+	defer cancel()
+	conn, err := drpcmigrate.DialWithHeader(ctx, "tcp", config.DRPCServerURL, drpcmigrate.DRPCHeader)
 	if err != nil {
-		if strings.Contains(err.Error(), "missing port") {
-			h = host
-		} else {
-			// Fallback
-			return "localhost:8081"
+		return nil, err
+	}
+
+	if config.TLS != nil {
+		tlsConfig := config.TLS
+		if tlsConfig.ServerName == "" {
+			tlsConfig = tlsConfig.Clone()
+			tlsConfig.ServerName, _, _ = net.SplitHostPort(config.DRPCServerURL)
 		}
+		tlsConn := tls.Client(conn, tlsConfig)
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("TLS handshake: %w", err)
+		}
+		conn = tlsConn
 	}
+	return drpcconn.New(conn), nil
+}
 
-	if h == "" {
-		return "localhost:8081"
+// withAPIKey attaches the configured API key as bearer token metadata, which the server checks on
+// every call and requires for the methods needing a role.
+func withAPIKey(ctx context.Context, config AppConfig) context.Context {
+	if config.APIKey == "" {
+		return ctx
 	}
+	return drpcmetadata.Add(ctx, "authorization", "Bearer "+config.APIKey)
+}
 
-	return h + ":8081"
+// callDRPC runs unary calls on a connection opened for them, with the configured API key attached.
+func callDRPC(ctx context.Context, config AppConfig, call func(ctx context.Context, client pb.DRPCRatesServiceClient) error) error {
+	drpcConn, err := dialDRPC(ctx, config)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := drpcConn.Close(); err != nil {
+			log.Printf("Error closing dRPC connection: %v", err)
+		}
+	}()
+
+	return call(withAPIKey(ctx, config), pb.NewDRPCRatesServiceClient(drpcConn))
+}
+
+// ListCantorsRPC fetches the cantor listing, following next_cursor until the last page. req.Limit
+// sets the page size; req.Cursor is overwritten.
+func ListCantorsRPC(ctx context.Context, config AppConfig, req *pb.ListCantorsRequest) ([]ApiCantorResponse, error) {
+	var list []ApiCantorResponse
+	err := callDRPC(ctx, config, func(ctx context.Context, client pb.DRPCRatesServiceClient) error {
+		page := proto.Clone(req).(*pb.ListCantorsRequest)
+		page.Cursor = ""
+		for {
+			resp, err := client.ListCantors(ctx, page)
+			if err != nil {
+				return err
+			}
+			for _, c := range resp.Cantors {
+				list = append(list, ApiCantorResponse{
+					ID:          int(c.Id),
+					DisplayName: c.DisplayName,
+					Name:        c.Name,
+					Latitude:    c.Latitude,
+					Longitude:   c.Longitude,
+					Strategy:    c.Strategy,
+					Address:     c.Address,
+				})
+			}
+			if resp.NextCursor == "" || resp.NextCursor == page.Cursor {
+				return nil
+			}
+			page.Cursor = resp.NextCursor
+		}
+	})
+	return list, err
 }
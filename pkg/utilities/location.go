@@ -5,29 +5,19 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"math"
 	"net/http"
 	"os"
 	"strconv"
 	"strings"
 	"time"
+
+	// External utilities
+	"github.com/Niutaq/Gix/pkg/geo"
 )
 
 // CalculateDistance returns the distance (in km) between two coordinates using the Haversine formula.
 func CalculateDistance(lat1, lon1, lat2, lon2 float64) float64 {
-	const R = 6371 // Radius of the Earth in km
-
-	dLat := (lat2 - lat1) * (math.Pi / 180.0)
-	dLon := (lon2 - lon1) * (math.Pi / 180.0)
-
-	lat1Rad := lat1 * (math.Pi / 180.0)
-	lat2Rad := lat2 * (math.Pi / 180.0)
-
-	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
-		math.Sin(dLon/2)*math.Sin(dLon/2)*math.Cos(lat1Rad)*math.Cos(lat2Rad)
-	c := 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
-
-	return R * c
+	return geo.DistanceKm(lat1, lon1, lat2, lon2)
 }
 
 // GeoLocationResponse matches the response structure from ip-api.com
@@ -70,6 +70,9 @@ func LayoutMap(gtx layout.Context, window *app.Window, state *AppState) layout.D
 	halfH := float64(gtx.Constraints.Max.Y) / 2
 
 	tx, ty := latLonToTile(lat, lon, zoom)
+	maxLat, minLon := tileToLatLon(tx-halfW/tileSize, ty-halfH/tileSize, zoom)
+	minLat, maxLon := tileToLatLon(tx+halfW/tileSize, ty+halfH/tileSize, zoom)
+	state.UI.MapState.Viewport = [4]float64{minLat, math.Max(minLon, -180), maxLat, math.Min(maxLon, 180)}
 
 	bgCol := color.NRGBA{R: 18, G: 18, B: 22, A: 255}
 	if state.UI.LightMode {
@@ -110,6 +113,7 @@ func LayoutMap(gtx layout.Context, window *app.Window, state *AppState) layout.D
 						if state.UI.MapState.Zoom > 19 {
 							state.UI.MapState.Zoom = 19
 						}
+						state.UI.MapState.ViewportChanged = true
 						if window != nil {
 							window.Invalidate()
 						}
@@ -125,6 +129,7 @@ func LayoutMap(gtx layout.Context, window *app.Window, state *AppState) layout.D
 						if state.UI.MapState.Zoom < 2 {
 							state.UI.MapState.Zoom = 2
 						}
+						state.UI.MapState.ViewportChanged = true
 						if window != nil {
 							window.Invalidate()
 						}
@@ -212,6 +217,7 @@ func handleMapEvents(gtx layout.Context, window *app.Window, state *AppState) {
 			if state.UI.MapState.Zoom > 19 {
 				state.UI.MapState.Zoom = 19
 			}
+			state.UI.MapState.ViewportChanged = true
 			if window != nil {
 				window.Invalidate()
 			}
@@ -239,7 +245,9 @@ func handleMapEvents(gtx layout.Context, window *app.Window, state *AppState) {
 			}
 		case pointer.Release:
 			state.UI.MapState.Dragging = false
-			if state.Search != nil {
+			if state.Search == nil {
+				state.UI.MapState.ViewportChanged = true
+			} else {
 				go func(lat, lon float64) {
 					cantors, err := state.Search.SearchCantorsNearby(lat, lon, 30.0)
 					if err == nil && len(cantors) > 0 {
@@ -2,6 +2,7 @@ package utilities
 
 import (
 	// Standard libraries
+	"crypto/tls"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -162,6 +163,11 @@ type UIState struct {
 		Dragging   bool
 		ZoomInBtn  widget.Clickable
 		ZoomOutBtn widget.Clickable
+		// Viewport is the visible area of the last frame as minLat, minLon, maxLat, maxLon.
+		Viewport [4]float64
+		// ViewportChanged is set when the user pans or zooms, so markers for the new area get loaded.
+		ViewportChanged bool
+		ViewportLoading atomic.Bool
 	}
 
 	PinnedIDs       []string
@@ -265,15 +271,12 @@ type AppState struct {
 
 // AppConfig stores app configuration
 type AppConfig struct {
-	APICantorsURL  string
-	APIRatesURL    string
-	APIHistoryURL  string
-	APIFinOpsURL   string
-	APIDiscoverURL string
-	DRPCServerURL  string
+	DRPCServerURL string      // host:port of the API server, which serves every call the client makes over dRPC
+	APIKey        string      // sent as bearer token; discovery and delete require the operator/admin role
+	TLS           *tls.Config // dRPC connections are plaintext when nil
 }
 
-// ApiCantorResponse for parsing JSON
+// ApiCantorResponse is a cantor of the server listing
 type ApiCantorResponse struct {
 	ID          int     `json:"id"`
 	DisplayName string  `json:"displayName"`
@@ -2,14 +2,12 @@ package utilities
 
 import (
 	// Standard libraries
-	"encoding/json"
+	"context"
 	"fmt"
 	"image"
 	"image/color"
-	"io"
 	"log"
 	"math"
-	"net/http"
 	"runtime"
 	"sort"
 	"strconv"
@@ -31,13 +29,12 @@ import (
 
 	// External utilities
 	pb "github.com/Niutaq/Gix/api/proto/v1"
+	"github.com/Niutaq/Gix/pkg/money"
 	"github.com/Niutaq/Gix/pkg/reading_data"
-	"google.golang.org/protobuf/proto"
 )
 
 var (
-	modalCloseBtn  widget.Clickable
-	xProtoBufConst = "application/x-protobuf"
+	modalCloseBtn widget.Clickable
 )
 
 // MarketValueArgs holds arguments for rendering a market value display.
@@ -126,26 +123,31 @@ func LayoutUI(gtx layout.Context, window *app.Window, theme *material.Theme, sta
 // StartFinOpsMonitoring periodically fetches metrics from the server for the economic dashboard.
 func StartFinOpsMonitoring(window *app.Window, state *AppState, config AppConfig) {
 	ticker := time.NewTicker(15 * time.Second)
-	client := &http.Client{Timeout: 5 * time.Second}
 
 	fetch := func() {
-		resp, err := client.Get(config.APIFinOpsURL)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		err := callDRPC(ctx, config, func(ctx context.Context, client pb.DRPCRatesServiceClient) error {
+			resp, err := client.GetFinOpsStatus(ctx, &pb.FinOpsStatusRequest{})
+			if err != nil {
+				return err
+			}
+			state.FinOps = FinOpsStatus{
+				DailySpendUSD:    resp.RealSpend_24HUsd,
+				BlockedProviders: resp.BlockedProviders,
+				SystemTime:       resp.SystemTime,
+				IsActive:         resp.IsGovernanceActive,
+				ServiceBreakdown: resp.ServiceBreakdown,
+			}
+			return nil
+		})
 		if err != nil {
 			log.Printf("[FinOps] Error fetching metrics: %v", err)
 			return
 		}
-		defer func() { _ = resp.Body.Close() }()
-
-		var status FinOpsStatus
-		if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
-			log.Printf("[FinOps] Error decoding metrics: %v", err)
-			return
-		}
-
-		state.FinOps = status
 		window.Invalidate()
 	}
-
 	// Initial fetch
 	fetch()
 
@@ -719,6 +721,14 @@ func layoutTimeframeBar(gtx layout.Context, window *app.Window, theme *material.
 		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 			return layoutTimeframeButton(gtx, theme, state, TimeframeButtonArgs{window, config, 2, "30D", GetTranslation(state.UI.Language, "tf_30d")})
 		}),
+		layout.Rigid(layout.Spacer{Width: unit.Dp(4)}.Layout),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layoutTimeframeButton(gtx, theme, state, TimeframeButtonArgs{window, config, 3, "90D", GetTranslation(state.UI.Language, "tf_90d")})
+		}),
+		layout.Rigid(layout.Spacer{Width: unit.Dp(4)}.Layout),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layoutTimeframeButton(gtx, theme, state, TimeframeButtonArgs{window, config, 4, "1Y", GetTranslation(state.UI.Language, "tf_1y")})
+		}),
 	)
 }
 
@@ -794,9 +804,9 @@ func extractHistoryData(history *pb.HistoryResponse, mode string) ([]float64, []
 	var data []float64
 	var timestamps []int64
 	for _, p := range history.Points {
-		val := float64(p.BuyRate) / 1000.0
+		val := historyPointValue(p.Buy, p.BuyRate)
 		if mode == "SELL" {
-			val = float64(p.SellRate) / 1000.0
+			val = historyPointValue(p.Sell, p.SellRate)
 		}
 
 		data = append(data, val)
@@ -806,6 +816,14 @@ func extractHistoryData(history *pb.HistoryResponse, mode string) ([]float64, []
 	return data, timestamps, startTime.Format("02 Jan")
 }
 
+// historyPointValue prefers the exact decimal field of a history point and falls back to the legacy milli-unit value.
+func historyPointValue(exact *pb.Decimal, legacyMilli int64) float64 {
+	if r, err := money.FromProto(exact); exact != nil && err == nil {
+		return r.Float64()
+	}
+	return float64(legacyMilli) / 1000.0
+}
+
 // getBasePrice extracts and returns the base price for the selected currency and chart mode.
 func getBasePrice(state *AppState) float64 {
 	state.Vault.Mu.Lock()
@@ -1010,7 +1028,7 @@ func drawPatternBackground(gtx layout.Context) {
 
 // FetchAllRates initiates the concurrent fetching of exchange rates.
 func FetchAllRates(window *app.Window, state *AppState, config AppConfig) {
-	if config.APIRatesURL == "" {
+	if config.DRPCServerURL == "" {
 		return
 	}
 
@@ -1027,15 +1045,12 @@ func FetchAllRates(window *app.Window, state *AppState, config AppConfig) {
 	}
 	state.Vault.Mu.Unlock()
 
-	go FetchAllRatesRPC(window, state, config.DRPCServerURL)
+	go FetchAllRatesRPC(window, state, config)
 	fetchHistory(window, state, config)
 }
 
 // fetchHistory retrieves historical currency data from the API and updates the application's state with the response.
 func fetchHistory(window *app.Window, state *AppState, config AppConfig) {
-	if config.APIHistoryURL == "" {
-		return
-	}
 
 	currency := state.UI.Currency
 	cantorID := getSelectedCantorID(state)
@@ -1051,6 +1066,10 @@ func timeframeToDays(tf string) int {
 		return 1
 	case "30D":
 		return 30
+	case "90D":
+		return 90
+	case "1Y":
+		return 365
 	default:
 		return 7
 	}
@@ -1066,46 +1085,21 @@ func getSelectedCantorID(state *AppState) int {
 	return 0
 }
 
-// performHistoryFetch retrieves historical currency data from the API and updates the application's state with the response.
+// performHistoryFetch retrieves historical currency data over dRPC and updates the application's state with the response.
 func performHistoryFetch(window *app.Window, state *AppState, config AppConfig, curr string, cID int, days int) {
-	url := buildHistoryURL(config.APIHistoryURL, curr, cID, days)
-
-	client := http.Client{Timeout: 5 * time.Second}
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		log.Printf("Error creating history request: %v", err)
-		return
-	}
-	req.Header.Set("Accept", xProtoBufConst)
-
-	resp, err := client.Do(req)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var history *pb.HistoryResponse
+	err := callDRPC(ctx, config, func(ctx context.Context, client pb.DRPCRatesServiceClient) error {
+		var err error
+		history, err = client.GetHistory(ctx, &pb.HistoryRequest{Currency: curr, CantorId: int32(cID), Days: int32(days)})
+		return err
+	})
 	if err != nil {
 		log.Printf("Error fetching history: %v", err)
 		return
 	}
-	defer func(Body io.ReadCloser) {
-		err := Body.Close()
-		if err != nil {
-			log.Printf("Error closing history response body: %v", err)
-		}
-	}(resp.Body)
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		log.Printf("Error reading history body: %v", err)
-		return
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		log.Printf("Server returned error %d: %s", resp.StatusCode, string(body))
-		return
-	}
-
-	var history pb.HistoryResponse
-	if err := proto.Unmarshal(body, &history); err != nil {
-		log.Printf("Error unmarshalling history: %v", err)
-		return
-	}
 
 	if len(history.Points) > 0 {
 		log.Printf("Received %d history points for %s (CantorID: %d)", len(history.Points), history.Currency, cID)
@@ -1113,20 +1107,11 @@ func performHistoryFetch(window *app.Window, state *AppState, config AppConfig,
 		log.Printf("Received empty history for %s (CantorID: %d)", history.Currency, cID)
 	}
 
-	state.History = &history
+	state.History = history
 	state.ChartAnimStart = time.Now()
 	window.Invalidate()
 }
 
-// buildHistoryURL constructs the URL for fetching historical currency data with optional Cantor ID and timeframe.
-func buildHistoryURL(baseURL, curr string, cID int, days int) string {
-	url := fmt.Sprintf("%s?currency=%s&days=%d", baseURL, curr, days)
-	if cID > 0 {
-		url += fmt.Sprintf("&cantor_id=%d", cID)
-	}
-	return url
-}
-
 // LayoutVerticalCurrencyBar creates a vertical sidebar for currency selection with a given layout, window, theme, state, and config.
 func LayoutVerticalCurrencyBar(
 	gtx layout.Context, window *app.Window,
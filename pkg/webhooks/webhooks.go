@@ -0,0 +1,162 @@
+package webhooks
+
+import (
+	// Standard libraries
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Event types a webhook can subscribe to.
+const (
+	EventRateUpdated       = "rate.updated"
+	EventCantorDiscovered  = "cantor.discovered"
+	EventGovernanceTripped = "governance.tripped"
+)
+
+// Events lists every supported event type.
+var Events = []string{EventRateUpdated, EventCantorDiscovered, EventGovernanceTripped}
+
+// Headers set on every delivery.
+const (
+	HeaderEvent     = "X-Gix-Event"
+	HeaderDelivery  = "X-Gix-Delivery"
+	HeaderSignature = "X-Gix-Signature"
+)
+
+// MaxAttempts is how many times a delivery is tried before it is marked failed.
+const MaxAttempts = 8
+
+const (
+	baseBackoff = 10 * time.Second
+	maxBackoff  = time.Hour
+)
+
+// IsValidEvent reports whether the event type is supported.
+func IsValidEvent(event string) bool {
+	for _, e := range Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// NewSecret generates a random signing secret.
+func NewSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(buf), nil
+}
+
+// Sign computes the signature header value for a payload: "t=<unix>,v1=<hex hmac-sha256>", where the
+// MAC covers "<unix>.<body>" so a captured request cannot be replayed with another timestamp.
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	ts := strconv.FormatInt(timestamp.Unix(), 10)
+	return "t=" + ts + ",v1=" + computeMAC(secret, ts, body)
+}
+
+// Verify checks a signature header against the body and rejects signatures older than tolerance.
+// Receivers written in Go can use it directly.
+func Verify(secret, header string, body []byte, tolerance time.Duration, now time.Time) error {
+	var ts, mac string
+	for _, part := range strings.Split(header, ",") {
+		k, v, _ := strings.Cut(part, "=")
+		switch k {
+		case "t":
+			ts = v
+		case "v1":
+			mac = v
+		}
+	}
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil || mac == "" {
+		return fmt.Errorf("malformed signature header")
+	}
+	if tolerance > 0 && now.Sub(time.Unix(unix, 0)) > tolerance {
+		return fmt.Errorf("signature expired")
+	}
+	if !hmac.Equal([]byte(mac), []byte(computeMAC(secret, ts, body))) {
+		return fmt.Errorf("signature mismatch")
+	}
+	return nil
+}
+
+func computeMAC(secret, ts string, body []byte) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(ts))
+	h.Write([]byte("."))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Backoff returns the delay before retrying after the given (1-based) failed attempt:
+// 10s, 20s, 40s, ... capped at one hour.
+func Backoff(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	d := baseBackoff
+	for i := 1; i < attempt && d < maxBackoff; i++ {
+		d *= 2
+	}
+	if d > maxBackoff {
+		d = maxBackoff
+	}
+	return d
+}
+
+// Result is the outcome of one delivery attempt.
+type Result struct {
+	StatusCode int
+	Err        error
+}
+
+// OK reports whether the receiver accepted the delivery with a 2xx status.
+func (r Result) OK() bool {
+	return r.Err == nil && r.StatusCode >= 200 && r.StatusCode < 300
+}
+
+// Retryable reports whether a failed attempt is worth retrying. Network errors, timeouts, 408, 429 and
+// 5xx are retried; other 4xx responses mean the receiver rejected the payload for good.
+func (r Result) Retryable() bool {
+	if r.OK() {
+		return false
+	}
+	if r.Err != nil {
+		return true
+	}
+	return r.StatusCode == http.StatusRequestTimeout || r.StatusCode == http.StatusTooManyRequests || r.StatusCode >= 500
+}
+
+// Send POSTs a signed JSON payload to url.
+func Send(ctx context.Context, client *http.Client, url, secret, event, deliveryID string, body []byte) Result {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return Result{Err: err}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Gix-Webhooks/1.0")
+	req.Header.Set(HeaderEvent, event)
+	req.Header.Set(HeaderDelivery, deliveryID)
+	req.Header.Set(HeaderSignature, Sign(secret, time.Now(), body))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return Result{Err: err}
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	return Result{StatusCode: resp.StatusCode}
+}
@@ -0,0 +1,94 @@
+package webhooks
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestSend checks that a local receiver gets a correctly signed payload
+func TestSend(t *testing.T) {
+	const secret = "whsec_test"
+	body := []byte(`{"event":"rate.updated"}`)
+
+	var verifyErr error
+	var gotEvent string
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload, _ := io.ReadAll(r.Body)
+		gotEvent = r.Header.Get(HeaderEvent)
+		verifyErr = Verify(secret, r.Header.Get(HeaderSignature), payload, time.Minute, time.Now())
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	res := Send(context.Background(), receiver.Client(), receiver.URL, secret, EventRateUpdated, "1", body)
+	if !res.OK() {
+		t.Fatalf("Send = %+v, want 2xx", res)
+	}
+	if verifyErr != nil {
+		t.Errorf("receiver could not verify signature: %v", verifyErr)
+	}
+	if gotEvent != EventRateUpdated {
+		t.Errorf("event header = %q", gotEvent)
+	}
+}
+
+// TestSendRetryClassification checks which receiver responses are retried
+func TestSendRetryClassification(t *testing.T) {
+	status := http.StatusInternalServerError
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer receiver.Close()
+
+	send := func() Result {
+		return Send(context.Background(), receiver.Client(), receiver.URL, "s", EventRateUpdated, "1", []byte("{}"))
+	}
+
+	if res := send(); res.OK() || !res.Retryable() {
+		t.Errorf("500 should be retryable: %+v", res)
+	}
+	status = http.StatusTooManyRequests
+	if res := send(); !res.Retryable() {
+		t.Errorf("429 should be retryable: %+v", res)
+	}
+	status = http.StatusGone
+	if res := send(); res.Retryable() {
+		t.Errorf("410 should not be retryable: %+v", res)
+	}
+
+	receiver.Close()
+	if res := send(); !res.Retryable() {
+		t.Errorf("connection error should be retryable: %+v", res)
+	}
+}
+
+// TestVerify checks rejection of tampered and expired signatures
+func TestVerify(t *testing.T) {
+	now := time.Now()
+	body := []byte("payload")
+	header := Sign("secret", now, body)
+
+	if err := Verify("secret", header, []byte("tampered"), time.Minute, now); err == nil {
+		t.Errorf("tampered body accepted")
+	}
+	if err := Verify("other", header, body, time.Minute, now); err == nil {
+		t.Errorf("wrong secret accepted")
+	}
+	if err := Verify("secret", header, body, time.Minute, now.Add(2*time.Minute)); err == nil {
+		t.Errorf("expired signature accepted")
+	}
+}
+
+// TestBackoff checks the exponential schedule and its cap
+func TestBackoff(t *testing.T) {
+	if Backoff(1) != 10*time.Second || Backoff(2) != 20*time.Second || Backoff(4) != 80*time.Second {
+		t.Errorf("unexpected schedule: %v %v %v", Backoff(1), Backoff(2), Backoff(4))
+	}
+	if Backoff(30) != time.Hour {
+		t.Errorf("Backoff(30) = %v, want 1h cap", Backoff(30))
+	}
+}